@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+type adoptOpts struct {
+	all       bool
+	inPlace   bool
+	copyFiles bool
+}
+
+func (a *App) adoptCmd(completeBranches completionFunc) *cobra.Command {
+	var opts adoptOpts
+	cmd := &cobra.Command{
+		Use:   "adopt [--all] [--in-place] [--copy-files] [branch...]",
+		Short: "Bring worktrees created outside hashi under management",
+		Args:  validateBranchArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runAdopt(cmd, args, opts)
+		},
+		ValidArgsFunction: completeBranches,
+	}
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Adopt every worktree outside worktree_dir")
+	cmd.Flags().BoolVar(&opts.inPlace, "in-place", false, "Leave worktrees at their current path")
+	cmd.Flags().BoolVar(&opts.copyFiles, "copy-files", false, "Run copy_files for adopted worktrees")
+	return cmd
+}
+
+func (a *App) runAdopt(cmd *cobra.Command, args []string, opts adoptOpts) error {
+	if opts.all && len(args) > 0 {
+		return fmt.Errorf("cannot combine --all with branch arguments")
+	}
+
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		params := make([]resource.AdoptParams, 0, len(args))
+		for _, branch := range args {
+			params = append(params, resource.AdoptParams{Branch: branch})
+		}
+		if len(args) == 0 {
+			candidates, err := svc.FindAdoptable(cmd.Context())
+			if err != nil {
+				return nil, err
+			}
+			if !opts.all {
+				printAdoptCandidates(cmd, candidates)
+				return nil, nil
+			}
+			for i := range candidates {
+				params = append(params, resource.AdoptParams{Branch: candidates[i].Branch, Candidate: &candidates[i]})
+			}
+		}
+
+		var adopted []string
+		for _, p := range params {
+			p.InPlace = opts.inPlace
+			p.CopyFiles = opts.copyFiles
+			res, err := svc.Adopt(cmd.Context(), p)
+			if err != nil {
+				return adopted, err
+			}
+			adopted = append(adopted, p.Branch)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(fmt.Sprintf("Adopted '%s' (%s)", p.Branch, res.WorktreePath)))
+		}
+		return adopted, nil
+	})
+}
+
+// printAdoptCandidates lists adoptable worktrees without changing anything.
+func printAdoptCandidates(cmd *cobra.Command, candidates []resource.AdoptCandidate) {
+	w := cmd.OutOrStdout()
+	if len(candidates) == 0 {
+		_, _ = fmt.Fprintln(w, "No worktrees to adopt")
+		return
+	}
+	for _, c := range candidates {
+		_, _ = fmt.Fprintf(w, "%s\t%s -> %s\n", c.Branch, c.Path, c.Target)
+	}
+	_, _ = fmt.Fprintln(w, "Run 'hashi adopt <branch...>' or 'hashi adopt --all' to adopt them")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func adoptGitMock() *git.ClientMock {
+	return &git.ClientMock{
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/src/repo-a", Branch: "a"},
+				{Path: "/src/repo-b", Branch: "b"},
+			}, nil
+		},
+		ListBranchesFunc: func() ([]string, error) { return []string{"main", "a", "b"}, nil },
+		MoveWorktreeFunc: func(src, dst string) error { return nil },
+	}
+}
+
+func adoptTmuxMock() *tmux.ClientMock {
+	return &tmux.ClientMock{
+		HasSessionFunc:  func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) { return nil, nil },
+		NewWindowFunc:   func(session, name, dir, initCmd string) (string, error) { return "@1", nil },
+	}
+}
+
+func TestRunAdopt(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("lists candidates without arguments", func(t *testing.T) {
+		g := adoptGitMock()
+		app := appWithDeps(newTestDeps(g, adoptTmuxMock()))
+		out, err := executeCommand(t, app, "adopt")
+		require.NoError(t, err)
+		assert.Contains(t, out, "a\t/src/repo-a -> /repo/.worktrees/a")
+		assert.Contains(t, out, "hashi adopt --all")
+		assert.Empty(t, g.MoveWorktreeCalls())
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		g := adoptGitMock()
+		g.ListWorktreesFunc = func() ([]git.Worktree, error) {
+			return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}}, nil
+		}
+		app := appWithDeps(newTestDeps(g, adoptTmuxMock()))
+		out, err := executeCommand(t, app, "adopt")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No worktrees to adopt")
+	})
+
+	t.Run("adopts selected branch", func(t *testing.T) {
+		g := adoptGitMock()
+		tm := adoptTmuxMock()
+		app := appWithDeps(newTestDeps(g, tm))
+		out, err := executeCommand(t, app, "adopt", "b")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Adopted 'b' (/repo/.worktrees/b)")
+		require.Len(t, g.MoveWorktreeCalls(), 1)
+		assert.Equal(t, "/src/repo-b", g.MoveWorktreeCalls()[0].Src)
+		require.Len(t, tm.NewWindowCalls(), 1)
+	})
+
+	t.Run("adopts all in place", func(t *testing.T) {
+		g := adoptGitMock()
+		tm := adoptTmuxMock()
+		app := appWithDeps(newTestDeps(g, tm))
+		out, err := executeCommand(t, app, "adopt", "--all", "--in-place")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Adopted 'a' (/src/repo-a)")
+		assert.Contains(t, out, "Adopted 'b' (/src/repo-b)")
+		assert.Empty(t, g.MoveWorktreeCalls())
+		assert.Len(t, tm.NewWindowCalls(), 2)
+		assert.Len(t, g.ListWorktreesCalls(), 1, "candidates are found once, not per branch")
+	})
+
+	t.Run("rejects --all with branches", func(t *testing.T) {
+		app := appWithDeps(newTestDeps(adoptGitMock(), adoptTmuxMock()))
+		_, err := executeCommand(t, app, "adopt", "--all", "a")
+		assert.ErrorContains(t, err, "cannot combine --all")
+	})
+
+	t.Run("adopt error", func(t *testing.T) {
+		app := appWithDeps(newTestDeps(adoptGitMock(), adoptTmuxMock()))
+		_, err := executeCommand(t, app, "adopt", "main")
+		assert.ErrorContains(t, err, "no worktree outside")
+	})
+
+	t.Run("FindAdoptable error", func(t *testing.T) {
+		g := adoptGitMock()
+		g.ListWorktreesFunc = func() ([]git.Worktree, error) { return nil, fmt.Errorf("git error") }
+		app := appWithDeps(newTestDeps(g, adoptTmuxMock()))
+		_, err := executeCommand(t, app, "adopt", "--all")
+		assert.Error(t, err)
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		app := appWithDepsError(fmt.Errorf("no git"))
+		_, err := executeCommand(t, app, "adopt", "a")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/wasabi0522/hashi/internal/resource"
+)
+
+func (a *App) attachCmd(completeBranches completionFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:               "attach [branch]",
+		Short:             "Attach through a grouped session, so each terminal shows its own window",
+		Args:              cobra.MatchAll(cobra.MaximumNArgs(1), validateBranchArgs),
+		RunE:              a.runAttach,
+		ValidArgsFunction: completeBranches,
+	}
+}
+
+func (a *App) runAttach(cmd *cobra.Command, args []string) error {
+	branch := ""
+	if len(args) > 0 {
+		branch = args[0]
+	}
+	return a.withService(cmd, func(svc *resource.Service) error {
+		return svc.Attach(cmd.Context(), branch)
+	})
+}
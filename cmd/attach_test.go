@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestAttachCmd(t *testing.T) {
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return name == "org/repo", nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+			IsInsideTmuxFunc:  func() bool { return false },
+			AttachGroupedFunc: func(session, name, window string) error { return nil },
+		}
+	}
+
+	t.Run("attaches to the branch window", func(t *testing.T) {
+		tm := newTmux()
+		_, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "attach", "feature")
+		require.NoError(t, err)
+		require.Len(t, tm.AttachGroupedCalls(), 1)
+		assert.Equal(t, "org/repo", tm.AttachGroupedCalls()[0].Session)
+		assert.Equal(t, "org/repo~1", tm.AttachGroupedCalls()[0].Name)
+		assert.Equal(t, "feature", tm.AttachGroupedCalls()[0].Window)
+	})
+
+	t.Run("without a branch", func(t *testing.T) {
+		tm := newTmux()
+		_, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "attach")
+		require.NoError(t, err)
+		require.Len(t, tm.AttachGroupedCalls(), 1)
+		assert.Empty(t, tm.AttachGroupedCalls()[0].Window)
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		tm := newTmux()
+		_, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "attach", "other")
+		assert.ErrorContains(t, err, "'other' has no window")
+		assert.Empty(t, tm.AttachGroupedCalls())
+	})
+
+	t.Run("too many args", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), newTmux())), "attach", "a", "b")
+		assert.Error(t, err)
+	})
+}
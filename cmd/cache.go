@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cachePath returns the path of a cache file, kept in the repository's git
+// common dir so that it is shared by all worktrees and never committed.
+func cachePath(commonDir, name string) string {
+	return filepath.Join(commonDir, "hashi", name)
+}
+
+// readCache returns the cached line if it was written within ttl.
+func readCache(path string, ttl time.Duration) (string, bool) {
+	if ttl <= 0 {
+		return "", false
+	}
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(string(data), "\n"), true
+}
+
+// writeCache stores the line atomically. Failures only cost a cache miss.
+func writeCache(path, line string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.WriteString(line + "\n")
+	if cerr := tmp.Close(); werr != nil || cerr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	path := statusLineCachePath(t.TempDir(), "/repo")
+	_, ok := readCache(path, time.Minute)
+	assert.False(t, ok, "missing cache")
+
+	writeCache(path, "line")
+	line, ok := readCache(path, time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, "line", line)
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+	_, ok = readCache(path, time.Minute)
+	assert.False(t, ok, "stale cache")
+
+	assert.NotEqual(t, path, statusLineCachePath(filepath.Dir(filepath.Dir(path)), "/repo/.worktrees/feat"))
+}
@@ -5,13 +5,16 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 
+	"github.com/spf13/cobra"
 	"github.com/wasabi0522/hashi/internal/config"
 	hashicontext "github.com/wasabi0522/hashi/internal/context"
 	hashiexec "github.com/wasabi0522/hashi/internal/exec"
 	"github.com/wasabi0522/hashi/internal/git"
 	"github.com/wasabi0522/hashi/internal/resource"
 	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
 )
 
 // App holds the dependency resolution functions and builds the CLI command tree.
@@ -19,6 +22,8 @@ type App struct {
 	resolveDeps    func(requireTmux bool) (*deps, error)
 	resolveGitDeps func() (*gitDeps, error)
 	verbose        bool
+	// noAttach is set by --no-attach; see detached.
+	noAttach bool
 }
 
 // NewApp creates an App with default dependency resolvers.
@@ -34,6 +39,8 @@ type deps struct {
 	tmux tmux.Client
 	ctx  *hashicontext.Context
 	cfg  *config.Config
+	// naming is nil when the previous tmux naming is not tracked (e.g. in tests).
+	naming *sessionNaming
 }
 
 // resolveOpts controls how dependencies are resolved.
@@ -68,28 +75,128 @@ func doResolveDeps(opts resolveOpts) (*deps, error) {
 			return nil, fmt.Errorf("required command 'tmux' not found")
 		}
 	}
-	tm := tmux.NewPrefixedClient(tmux.NewClient(opts.exec), tmux.DefaultPrefix)
 	cfg, err := config.Load(filepath.Join(ctx.RepoRoot, ".hashi.yaml"))
 	if err != nil {
 		return nil, err
 	}
-	return &deps{git: g, tmux: tm, ctx: ctx, cfg: cfg}, nil
+	defaultSession := ctx.SessionName
+	if ctx.SessionName, err = ctx.RenderSessionName(cfg.SessionName); err != nil {
+		return nil, err
+	}
+	socket := tmux.WithSocket(cfg.TmuxSocket)
+	base := tmux.NewClient(opts.exec, socket)
+	if cfg.TmuxControl {
+		base = tmux.NewControlClient(opts.exec, socket)
+	}
+	tm := tmux.NewPrefixedClient(base, cfg.TmuxPrefix)
+	naming := newSessionNaming(g, base, cfg.TmuxPrefix, ctx.SessionName, defaultSession)
+	return &deps{git: g, tmux: tm, ctx: ctx, cfg: cfg, naming: naming}, nil
 }
 
-// withService resolves dependencies (requiring tmux) and calls fn with the constructed Service.
-func (a *App) withService(fn func(svc *resource.Service) error) error {
+// withService resolves dependencies (requiring tmux), offers to rename a session
+// left under a previous naming, and calls fn with the constructed Service.
+func (a *App) withService(cmd *cobra.Command, fn func(svc *resource.Service) error) error {
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		return nil, fn(svc)
+	})
+}
+
+// withMutation is withService for commands that change branches or their
+// windows: fn returns the branches it changed, whose window decorations are
+// then refreshed, even if fn failed partway.
+func (a *App) withMutation(cmd *cobra.Command, fn func(svc *resource.Service) ([]string, error)) error {
 	d, err := a.resolveDeps(true)
 	if err != nil {
 		return err
 	}
-	return fn(d.service(a.serviceOpts()...))
+	if err := a.checkSessionNaming(cmd, d, true); err != nil {
+		return err
+	}
+	svc := d.service(a.serviceOpts()...)
+	changed, err := fn(svc)
+	if len(changed) > 0 {
+		a.decorate(cmd, d, svc, changed...)
+	}
+	return err
+}
+
+// decorate refreshes the window decorations of the branches if they are
+// enabled. It is best-effort: failures are only reported with --verbose.
+func (a *App) decorate(cmd *cobra.Command, d *deps, svc *resource.Service, branches ...string) {
+	if !d.cfg.WindowDecorations {
+		return
+	}
+	if err := svc.Decorate(cmd.Context(), branches...); err != nil && a.verbose {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "hashi: decorating windows: %v\n", err)
+	}
 }
 
 func (a *App) serviceOpts() []resource.Option {
+	var opts []resource.Option
 	if a.verbose {
-		return []resource.Option{resource.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, nil)))}
+		opts = append(opts, resource.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, nil))))
+	}
+	if a.detached() {
+		opts = append(opts, resource.WithNoAttach())
+	}
+	return opts
+}
+
+// detached reports whether commands should leave the terminal alone instead
+// of attaching or switching to a window: with --no-attach, or when
+// HASHI_NO_ATTACH is set to a true value.
+func (a *App) detached() bool {
+	if a.noAttach {
+		return true
+	}
+	v, _ := strconv.ParseBool(os.Getenv("HASHI_NO_ATTACH"))
+	return v
+}
+
+// printDetached reports an operation that left the terminal alone, since
+// nothing else shows where the branch is.
+func (a *App) printDetached(cmd *cobra.Command, res *resource.OperationResult, msg string) {
+	if !a.detached() {
+		return
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(fmt.Sprintf("%s (%s)", msg, res.WorktreePath)))
+}
+
+// addNoAttachFlags adds --no-attach and its alias --detach to a command that
+// connects to a window.
+func (a *App) addNoAttachFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&a.noAttach, "no-attach", false, "Create or repair the window without attaching or switching to it")
+	cmd.Flags().BoolVarP(&a.noAttach, "detach", "d", false, "Same as --no-attach")
+}
+
+// windowSpecs converts the configured extra windows for the resource layer.
+func windowSpecs(windows []config.Window) []resource.WindowSpec {
+	specs := make([]resource.WindowSpec, 0, len(windows))
+	for _, w := range windows {
+		specs = append(specs, resource.WindowSpec{Name: w.Name, Command: w.Command})
+	}
+	return specs
+}
+
+// paneSpecs converts the configured layout for the resource layer, splitting
+// the previous pane where no target is set.
+func paneSpecs(layout []config.Pane) []resource.PaneSpec {
+	specs := make([]resource.PaneSpec, 0, len(layout))
+	for i, p := range layout {
+		target := i - 1
+		if p.Target != nil {
+			target = *p.Target
+		}
+		specs = append(specs, resource.PaneSpec{
+			Target:     max(target, 0),
+			Horizontal: p.Split == "horizontal",
+			Size:       p.Size,
+			Command:    p.Command,
+			Focus:      p.Focus,
+			PostNew:    p.PostNew,
+		})
 	}
-	return nil
+	return specs
 }
 
 // resolveShell returns the user's login shell from $SHELL.
@@ -105,13 +212,18 @@ func resolveShell() string {
 func (d *deps) service(opts ...resource.Option) *resource.Service {
 	allOpts := []resource.Option{
 		resource.WithCommonParams(resource.CommonParams{
-			RepoRoot:      d.ctx.RepoRoot,
-			WorktreeDir:   d.cfg.WorktreeDir,
-			DefaultBranch: d.ctx.DefaultBranch,
-			SessionName:   d.ctx.SessionName,
-			Shell:         resolveShell(),
-			CopyFiles:     d.cfg.Hooks.CopyFiles,
-			PostNewHooks:  d.cfg.Hooks.PostNew,
+			RepoRoot:        d.ctx.RepoRoot,
+			WorktreeDir:     d.cfg.WorktreeDir,
+			DefaultBranch:   d.ctx.DefaultBranch,
+			SessionName:     d.ctx.SessionName,
+			Mapping:         resource.Mapping(d.cfg.Mapping),
+			Windows:         windowSpecs(d.cfg.Windows),
+			Layout:          paneSpecs(d.cfg.Layout),
+			WindowOrder:     resource.WindowOrder(d.cfg.WindowOrder),
+			GroupedSessions: d.cfg.GroupedSessions,
+			Shell:           resolveShell(),
+			CopyFiles:       d.cfg.Hooks.CopyFiles,
+			PostNewHooks:    d.cfg.Hooks.PostNew,
 		}),
 	}
 	allOpts = append(allOpts, opts...)
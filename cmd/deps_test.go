@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/config"
 	hashiexec "github.com/wasabi0522/hashi/internal/exec"
+	"github.com/wasabi0522/hashi/internal/resource"
 )
 
 // resolveDepsWithExec is a test helper that resolves deps using the given Executor.
@@ -82,6 +86,47 @@ func TestResolveDepsWithExec(t *testing.T) {
 		assert.NotNil(t, d.git)
 		assert.NotNil(t, d.tmux)
 		assert.Equal(t, repoRoot, d.ctx.RepoRoot)
+		assert.Equal(t, "org/repo", d.ctx.SessionName)
+		assert.Equal(t, "hs/org/repo", d.naming.session)
+	})
+
+	t.Run("session name from config", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".hashi.yaml"),
+			[]byte("tmux_prefix: \"\"\nsession_name: \"{host}/{repo}\"\n"), 0644))
+		e := &hashiexec.ExecutorMock{
+			LookPathFunc: func(name string) error { return nil },
+			OutputFunc: func(name string, args ...string) (string, error) {
+				switch args[0] {
+				case "rev-parse":
+					return repoRoot + "/.git", nil
+				case "remote":
+					return "git@gitlab.example.com:org/repo.git", nil
+				}
+				return "", nil
+			},
+		}
+		d, err := resolveDepsWithExec(e)
+		require.NoError(t, err)
+		assert.Equal(t, "gitlab-example-com/repo", d.ctx.SessionName)
+		assert.Equal(t, "gitlab-example-com/repo", d.naming.session, "no prefix")
+		assert.Equal(t, "hs/org/repo", d.naming.oldSession)
+	})
+
+	t.Run("invalid session name template", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".hashi.yaml"), []byte("session_name: \"{owner}\"\n"), 0644))
+		e := &hashiexec.ExecutorMock{
+			LookPathFunc: func(name string) error { return nil },
+			OutputFunc: func(name string, args ...string) (string, error) {
+				if args[0] == "rev-parse" {
+					return repoRoot + "/.git", nil
+				}
+				return "", nil
+			},
+		}
+		_, err := resolveDepsWithExec(e)
+		assert.ErrorContains(t, err, "unknown field {owner}")
 	})
 }
 
@@ -137,3 +182,19 @@ func TestResolveGitDepsWithExec(t *testing.T) {
 		assert.Equal(t, repoRoot, d.ctx.RepoRoot)
 	})
 }
+
+func TestPaneSpecs(t *testing.T) {
+	zero := 0
+	specs := paneSpecs([]config.Pane{
+		{Command: "nvim", Focus: true},
+		{Split: "horizontal", Size: "40%"},
+		{Split: "vertical", PostNew: true},
+		{Split: "vertical", Target: &zero},
+	})
+	assert.Equal(t, []resource.PaneSpec{
+		{Command: "nvim", Focus: true},
+		{Target: 0, Horizontal: true, Size: "40%"},
+		{Target: 1, PostNew: true},
+		{Target: 0},
+	}, specs)
+}
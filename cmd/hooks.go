@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/githook"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+// postCheckoutHook is the git hook hashi installs into.
+const postCheckoutHook = "post-checkout"
+
+// postCheckoutBody returns the snippet added to the post-checkout hook.
+// It is a no-op when hashi is not on PATH so that other users of the repository are not affected.
+func postCheckoutBody(block bool) string {
+	flag := ""
+	if block {
+		flag = " --block"
+	}
+	return `if command -v hashi >/dev/null 2>&1; then
+	hashi hook post-checkout` + flag + ` "$@" || exit $?
+fi`
+}
+
+func (a *App) hooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage git hooks that guard the branch-worktree mapping",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	var block bool
+	install := &cobra.Command{
+		Use:   "install [--block]",
+		Short: "Install the post-checkout hook",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runHooksInstall(cmd, block)
+		},
+	}
+	install.Flags().BoolVar(&block, "block", false, "Switch back instead of only warning when a managed worktree changes branch")
+
+	uninstall := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the post-checkout hook",
+		Args:  cobra.NoArgs,
+		RunE:  a.runHooksUninstall,
+	}
+
+	cmd.AddCommand(install, uninstall)
+	return cmd
+}
+
+// hookPath returns the path of the named hook, honoring core.hooksPath.
+func (a *App) hookPath(name string) (string, error) {
+	d, err := a.resolveGitDeps()
+	if err != nil {
+		return "", err
+	}
+	dir, err := d.git.GitPath("hooks")
+	if err != nil {
+		return "", fmt.Errorf("resolving hooks directory: %w", err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+func (a *App) runHooksInstall(cmd *cobra.Command, block bool) error {
+	path, err := a.hookPath(postCheckoutHook)
+	if err != nil {
+		return err
+	}
+	if err := githook.Install(path, postCheckoutBody(block)); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(fmt.Sprintf("Installed %s hook at %s", postCheckoutHook, path)))
+	return nil
+}
+
+func (a *App) runHooksUninstall(cmd *cobra.Command, args []string) error {
+	path, err := a.hookPath(postCheckoutHook)
+	if err != nil {
+		return err
+	}
+	found, err := githook.Uninstall(path)
+	if err != nil {
+		return err
+	}
+	if !found {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "No hashi %s hook installed at %s\n", postCheckoutHook, path)
+		return nil
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(fmt.Sprintf("Removed %s hook from %s", postCheckoutHook, path)))
+	return nil
+}
+
+// hookCmd is the hidden entry point invoked by installed git and tmux hooks.
+func (a *App) hookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "hook",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+	}
+
+	var block bool
+	postCheckout := &cobra.Command{
+		Use:          "post-checkout <prev-head> <new-head> <branch-flag>",
+		Args:         cobra.ExactArgs(3),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runPostCheckoutHook(cmd, args[2], block)
+		},
+	}
+	postCheckout.Flags().BoolVar(&block, "block", false, "Switch back to the mapped branch")
+
+	windowRenamed := &cobra.Command{
+		Use:          "window-renamed <branch> <window-name>",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runWindowRenamedHook(cmd, args[0], args[1])
+		},
+	}
+
+	windowUnlinked := &cobra.Command{
+		Use:          "window-unlinked <window-name>",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runWindowUnlinkedHook(cmd, args[0])
+		},
+	}
+
+	cmd.AddCommand(postCheckout, windowRenamed, windowUnlinked)
+	return cmd
+}
+
+// branchCheckoutFlag is the third post-checkout argument for branch checkouts (as opposed to file checkouts).
+const branchCheckoutFlag = "1"
+
+// runPostCheckoutHook warns about, or reverts, a branch switch inside a managed worktree.
+// Failures unrelated to the mapping are reported but never fail the git command.
+func (a *App) runPostCheckoutHook(cmd *cobra.Command, flag string, block bool) error {
+	if flag != branchCheckoutFlag {
+		return nil
+	}
+	stderr := cmd.ErrOrStderr()
+
+	d, err := a.resolveDeps(false)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "hashi: %v\n", err)
+		return nil
+	}
+	svc := d.service(a.serviceOpts()...)
+
+	dir, err := os.Getwd()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "hashi: %v\n", err)
+		return nil
+	}
+	check, err := svc.CheckCheckout(cmd.Context(), dir)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "hashi: %v\n", err)
+		return nil
+	}
+	if !check.Mismatch() {
+		return nil
+	}
+
+	if !block {
+		_, _ = fmt.Fprintf(stderr, "%s\n", ui.Yellow(fmt.Sprintf(
+			"hashi: ⚠ '%s' is mapped to '%s' but now has '%s' checked out; its window no longer matches. Run 'git switch %s' to restore it",
+			check.Worktree, check.Expected, check.Actual, check.Expected)))
+		return nil
+	}
+
+	if err := svc.RestoreCheckout(cmd.Context(), check); err != nil {
+		return err
+	}
+	return fmt.Errorf("switching branches inside a hashi worktree is blocked; restored '%s'. Use 'hashi switch %s' instead", check.Expected, check.Actual)
+}
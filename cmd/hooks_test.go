@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func hooksDeps(hooksDir string) *deps {
+	return newTestDeps(&git.ClientMock{
+		GitPathFunc: func(name string) (string, error) { return hooksDir, nil },
+	}, &tmux.ClientMock{})
+}
+
+func TestRunHooksInstall(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("install and uninstall", func(t *testing.T) {
+		hooksDir := t.TempDir()
+		app := appWithDeps(hooksDeps(hooksDir))
+		path := filepath.Join(hooksDir, "post-checkout")
+
+		out, err := executeCommand(t, app, "hooks", "install", "--block")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Installed post-checkout hook at "+path)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `hashi hook post-checkout --block "$@"`)
+
+		out, err = executeCommand(t, app, "hooks", "uninstall")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Removed post-checkout hook")
+		assert.NoFileExists(t, path)
+
+		out, err = executeCommand(t, app, "hooks", "uninstall")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No hashi post-checkout hook installed")
+	})
+
+	t.Run("warn mode has no block flag", func(t *testing.T) {
+		assert.NotContains(t, postCheckoutBody(false), "--block")
+	})
+
+	t.Run("GitPath error", func(t *testing.T) {
+		d := newTestDeps(&git.ClientMock{
+			GitPathFunc: func(name string) (string, error) { return "", fmt.Errorf("git error") },
+		}, &tmux.ClientMock{})
+		_, err := executeCommand(t, appWithDeps(d), "hooks", "install")
+		assert.ErrorContains(t, err, "resolving hooks directory")
+		_, err = executeCommand(t, appWithDeps(d), "hooks", "uninstall")
+		assert.Error(t, err)
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "hooks", "install")
+		assert.Error(t, err)
+	})
+}
+
+func checkoutDeps(current string) *deps {
+	return newTestDeps(&git.ClientMock{
+		ShowToplevelFunc:        func(dir string) (string, error) { return "/repo/.worktrees/feature", nil },
+		CurrentBranchFunc:       func(dir string) (string, error) { return current, nil },
+		SwitchBranchFunc:        func(dir, branch string) error { return nil },
+		OperationInProgressFunc: func(dir string) (string, error) { return "", nil },
+	}, &tmux.ClientMock{})
+}
+
+func TestRunPostCheckoutHook(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("matching branch is silent", func(t *testing.T) {
+		out, err := executeCommand(t, appWithDeps(checkoutDeps("feature")), "hook", "post-checkout", "a", "b", "1")
+		require.NoError(t, err)
+		assert.Empty(t, out)
+	})
+
+	t.Run("file checkout is ignored", func(t *testing.T) {
+		d := checkoutDeps("other")
+		out, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "a", "b", "0")
+		require.NoError(t, err)
+		assert.Empty(t, out)
+		assert.Empty(t, d.git.(*git.ClientMock).ShowToplevelCalls())
+	})
+
+	t.Run("warns on mismatch", func(t *testing.T) {
+		d := checkoutDeps("other")
+		out, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "a", "b", "1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "is mapped to 'feature' but now has 'other' checked out")
+		assert.Empty(t, d.git.(*git.ClientMock).SwitchBranchCalls())
+	})
+
+	t.Run("blocks on mismatch", func(t *testing.T) {
+		d := checkoutDeps("other")
+		_, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "--block", "a", "b", "1")
+		assert.ErrorContains(t, err, "restored 'feature'")
+		calls := d.git.(*git.ClientMock).SwitchBranchCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, "feature", calls[0].Branch)
+	})
+
+	t.Run("detached HEAD is left alone", func(t *testing.T) {
+		d := checkoutDeps("HEAD")
+		out, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "--block", "a", "b", "1")
+		require.NoError(t, err)
+		assert.Empty(t, out)
+		assert.Empty(t, d.git.(*git.ClientMock).SwitchBranchCalls())
+	})
+
+	t.Run("rebase in progress is left alone", func(t *testing.T) {
+		d := checkoutDeps("other")
+		d.git.(*git.ClientMock).OperationInProgressFunc = func(dir string) (string, error) { return "rebase", nil }
+		out, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "--block", "a", "b", "1")
+		require.NoError(t, err)
+		assert.Empty(t, out)
+		assert.Empty(t, d.git.(*git.ClientMock).SwitchBranchCalls())
+	})
+
+	t.Run("restore error", func(t *testing.T) {
+		d := checkoutDeps("other")
+		d.git.(*git.ClientMock).SwitchBranchFunc = func(dir, branch string) error { return fmt.Errorf("conflict") }
+		_, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "--block", "a", "b", "1")
+		assert.ErrorContains(t, err, "conflict")
+	})
+
+	t.Run("check error does not fail git", func(t *testing.T) {
+		d := checkoutDeps("other")
+		d.git.(*git.ClientMock).ShowToplevelFunc = func(dir string) (string, error) { return "", fmt.Errorf("git error") }
+		out, err := executeCommand(t, appWithDeps(d), "hook", "post-checkout", "a", "b", "1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "hashi: resolving worktree")
+	})
+
+	t.Run("deps error does not fail git", func(t *testing.T) {
+		out, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "hook", "post-checkout", "a", "b", "1")
+		require.NoError(t, err)
+		assert.Contains(t, out, "hashi: no git")
+	})
+}
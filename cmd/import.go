@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) importCmd() *cobra.Command {
+	var session string
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "import [--session <name>] [-f]",
+		Short: "Bring windows opened by hand for a branch under management",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runImport(cmd, session, force)
+		},
+	}
+	cmd.Flags().StringVar(&session, "session", "", "Look for windows in another tmux session")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip the confirmation prompt")
+	return cmd
+}
+
+// runImport resolves deps directly instead of withService because the
+// repository session is looked up by the name tmux gives it.
+func (a *App) runImport(cmd *cobra.Command, session string, force bool) error {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return err
+	}
+	if err := a.checkSessionNaming(cmd, d, true); err != nil {
+		return err
+	}
+	target := d.cfg.TmuxPrefix + d.ctx.SessionName
+	if session == "" {
+		ok, err := d.tmux.HasSession(d.ctx.SessionName)
+		if err != nil {
+			return fmt.Errorf("checking session: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("tmux session '%s' is not running; name the session of the windows with --session", target)
+		}
+		session = target
+	}
+
+	svc := d.service(a.serviceOpts()...)
+	candidates, err := svc.FindImportable(cmd.Context(), session)
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	if len(candidates) == 0 {
+		_, _ = fmt.Fprintln(w, "No windows to import")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Windows to import into '%s':\n", target)
+	for _, c := range candidates {
+		reason := "named after the branch"
+		if !c.ByName {
+			reason = "in " + c.Worktree
+		}
+		_, _ = fmt.Fprintf(w, "  %s:%s (%s) -> %s\n", session, c.Window.Name, reason, c.Branch)
+	}
+	if !force && !confirmPrompt(cmd, fmt.Sprintf("Import %d window(s)?", len(candidates))) {
+		return nil
+	}
+
+	branches, err := svc.Import(cmd.Context(), candidates)
+	for _, b := range branches {
+		_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf("Imported '%s'", b)))
+	}
+	if len(branches) > 0 {
+		a.decorate(cmd, d, svc, branches...)
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestImportCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}}, nil
+			},
+			ListUnmanagedWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{
+					{ID: "@7", Name: "vim", Dir: "/repo/.worktrees/feature"},
+					{ID: "@8", Name: "htop", Dir: "/tmp"},
+				}, nil
+			},
+			MoveWindowFunc:      func(window, session string) error { return nil },
+			RenameWindowFunc:    func(session, old, new string) error { return nil },
+			SetWindowOptionFunc: func(session, window, key, value string) error { return nil },
+		}
+	}
+	importDeps := func(tm tmux.Client) *deps {
+		d := newTestDeps(upDownGit(), tm)
+		d.cfg.TmuxPrefix = "hs/"
+		return d
+	}
+
+	t.Run("imports after confirmation", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommandWithInput(t, appWithDeps(importDeps(tm)), "y\n", "import")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Windows to import into 'hs/org/repo':\n  hs/org/repo:vim (in /repo/.worktrees/feature) -> feature\n")
+		assert.Contains(t, out, "Import 1 window(s)? y/N [N] ")
+		assert.Contains(t, out, "Imported 'feature'\n")
+		assert.Equal(t, "hs/org/repo", tm.ListUnmanagedWindowsCalls()[0].Session)
+		require.Len(t, tm.MoveWindowCalls(), 1)
+		assert.Equal(t, "@7", tm.MoveWindowCalls()[0].Window)
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommandWithInput(t, appWithDeps(importDeps(tm)), "n\n", "import")
+		require.NoError(t, err)
+		assert.NotContains(t, out, "Imported")
+		assert.Empty(t, tm.MoveWindowCalls())
+	})
+
+	t.Run("another session without prompt", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(importDeps(tm)), "import", "--session", "work", "-f")
+		require.NoError(t, err)
+		assert.Contains(t, out, "  work:vim (in /repo/.worktrees/feature) -> feature\nImported 'feature'\n")
+		assert.Equal(t, "work", tm.ListUnmanagedWindowsCalls()[0].Session)
+	})
+
+	t.Run("nothing to import", func(t *testing.T) {
+		tm := newTmux()
+		tm.ListUnmanagedWindowsFunc = func(session string) ([]tmux.Window, error) { return nil, nil }
+		out, err := executeCommand(t, appWithDeps(importDeps(tm)), "import")
+		require.NoError(t, err)
+		assert.Equal(t, "No windows to import\n", out)
+	})
+
+	t.Run("session not running", func(t *testing.T) {
+		tm := newTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return false, nil }
+		_, err := executeCommand(t, appWithDeps(importDeps(tm)), "import")
+		assert.ErrorContains(t, err, "tmux session 'hs/org/repo' is not running; name the session of the windows with --session")
+	})
+
+	t.Run("import fails", func(t *testing.T) {
+		tm := newTmux()
+		tm.MoveWindowFunc = func(window, session string) error { return fmt.Errorf("no such window") }
+		_, err := executeCommand(t, appWithDeps(importDeps(tm)), "import", "-f")
+		assert.ErrorContains(t, err, "importing 'vim': moving window: no such window")
+	})
+
+	t.Run("no prefix", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "import", "-f")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Windows to import into 'org/repo':\n  org/repo:vim")
+		assert.Equal(t, "org/repo", tm.ListUnmanagedWindowsCalls()[0].Session)
+	})
+}
@@ -31,6 +31,9 @@ func (a *App) runList(cmd *cobra.Command, jsonOutput bool) error {
 	if err != nil {
 		return err
 	}
+	if err := a.checkSessionNaming(cmd, d, false); err != nil {
+		return err
+	}
 
 	states, err := d.service(a.serviceOpts()...).CollectState(cmd.Context())
 	if err != nil {
@@ -44,10 +47,10 @@ func (a *App) runList(cmd *cobra.Command, jsonOutput bool) error {
 	return nil
 }
 
-func printJSON(w io.Writer, states []resource.State) error {
+func printJSON(w io.Writer, v any) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
-	return enc.Encode(states)
+	return enc.Encode(v)
 }
 
 var hashiTableStyle = table.Style{
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) logsCmd(completeBranches completionFunc) *cobra.Command {
+	p := resource.LogsParams{Pane: -1}
+	var follow bool
+	var output string
+	cmd := &cobra.Command{
+		Use:   "logs <branch> [--lines N] [--follow] [-o <file>]",
+		Short: "Print the output of a branch window without switching to it",
+		Args:  cobra.MatchAll(cobra.ExactArgs(1), validateBranchArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p.Branch = args[0]
+			if p.Lines < 0 {
+				return fmt.Errorf("--lines must not be negative: %d", p.Lines)
+			}
+			return a.runLogs(cmd, p, follow, output)
+		},
+		ValidArgsFunction: completeBranches,
+	}
+	cmd.Flags().IntVarP(&p.Lines, "lines", "n", 0, "Print the last N lines of the history; 0 for the visible part of the pane")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing the output as the pane prints it, until interrupted")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the output to a file instead of stdout")
+	cmd.Flags().StringVar(&p.Window, "window", "", "Read an extra window of the branch instead of the branch window")
+	cmd.Flags().IntVar(&p.Pane, "pane", -1, "Read the pane with this index instead of the active pane")
+	return cmd
+}
+
+// runLogs prints the pane's output, or follows it. It only reads the branch,
+// so like show it warns about a session left under a previous naming instead
+// of offering to rename it.
+func (a *App) runLogs(cmd *cobra.Command, p resource.LogsParams, follow bool, output string) error {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return err
+	}
+	if err := a.checkSessionNaming(cmd, d, false); err != nil {
+		return err
+	}
+	svc := d.service(a.serviceOpts()...)
+
+	var w io.Writer = cmd.OutOrStdout()
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	if follow {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return svc.FollowLogs(ctx, p, w)
+	}
+	text, err := svc.Logs(cmd.Context(), p)
+	if err != nil {
+		return err
+	}
+	if text != "" {
+		if _, err := fmt.Fprintln(w, text); err != nil {
+			return err
+		}
+	}
+	if output != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(fmt.Sprintf("Saved the output of '%s' to %s", p.Branch, output)))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestLogsCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%2", Active: true}}, nil
+			},
+			CapturePaneFunc: func(pane string, lines int) (string, error) { return "$ make\nok\n", nil },
+		}
+	}
+
+	t.Run("prints the pane", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "logs", "feature", "--lines", "50")
+		require.NoError(t, err)
+		assert.Equal(t, "$ make\nok\n", out)
+		assert.Equal(t, 50, tm.CapturePaneCalls()[0].Lines)
+	})
+
+	t.Run("saves to a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "feature.log")
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), newTmux())), "logs", "feature", "-o", path)
+		require.NoError(t, err)
+		assert.Equal(t, "Saved the output of 'feature' to "+path+"\n", out)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "$ make\nok\n", string(data))
+	})
+
+	t.Run("only warns about a session under a previous naming", func(t *testing.T) {
+		g := upDownGit()
+		g.ConfigGetFunc = func(key string) (string, error) { return "", nil }
+		g.ConfigSetFunc = func(key, value string) error { return nil }
+		raw := rawTmux("hs/org/repo")
+		d := newTestDeps(g, newTmux())
+		d.naming = newSessionNaming(g, raw, "", "gh/org/repo", "org/repo")
+
+		out, err := executeCommandWithInput(t, appWithDeps(d), "y\n", "logs", "feature")
+		require.NoError(t, err)
+		assert.Contains(t, out, "$ make\nok\n")
+		assert.Empty(t, raw.RenameSessionCalls(), "no rename is offered")
+		assert.Empty(t, g.ConfigSetCalls())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			args []string
+			want string
+		}{
+			{[]string{"logs", "other"}, "'other' has no window"},
+			{[]string{"logs", "feature", "--lines", "-1"}, "--lines must not be negative: -1"},
+			{[]string{"logs"}, "accepts 1 arg(s)"},
+		} {
+			_, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), newTmux())), tt.args...)
+			assert.ErrorContains(t, err, tt.want, "%v", tt.args)
+		}
+	})
+}
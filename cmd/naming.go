@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+// Git config keys recording the tmux naming hashi last used in the repository,
+// so that changing tmux_prefix or session_name does not orphan its session.
+const (
+	configTmuxSession = "hashi.tmuxSession"
+	configTmuxPrefix  = "hashi.tmuxPrefix"
+)
+
+// sessionNaming is the full tmux session name hashi uses and the one it used
+// before tmux_prefix or session_name changed.
+type sessionNaming struct {
+	// raw is the tmux client without the prefix.
+	raw     tmux.Client
+	prefix  string
+	session string
+	// oldPrefix and oldSession are the previous naming. Without a recorded
+	// naming, they are the built-in defaults.
+	oldPrefix  string
+	oldSession string
+	// recorded reports whether the current naming is already recorded.
+	recorded bool
+}
+
+// newSessionNaming reads the previously recorded naming from git config.
+// defaultSession is the session name under the default template.
+func newSessionNaming(g git.Client, raw tmux.Client, prefix, session, defaultSession string) *sessionNaming {
+	n := &sessionNaming{
+		raw:        raw,
+		prefix:     prefix,
+		session:    prefix + session,
+		oldPrefix:  tmux.DefaultPrefix,
+		oldSession: tmux.DefaultPrefix + defaultSession,
+	}
+	if recorded, _ := g.ConfigGet(configTmuxSession); recorded != "" {
+		n.oldSession = recorded
+		n.oldPrefix, _ = g.ConfigGet(configTmuxPrefix)
+	}
+	n.recorded = n.oldSession == n.session && n.oldPrefix == n.prefix
+	return n
+}
+
+func (n *sessionNaming) record(g git.Client) error {
+	if err := g.ConfigSet(configTmuxSession, n.session); err != nil {
+		return fmt.Errorf("recording tmux session name: %w", err)
+	}
+	if err := g.ConfigSet(configTmuxPrefix, n.prefix); err != nil {
+		return fmt.Errorf("recording tmux prefix: %w", err)
+	}
+	return nil
+}
+
+// checkSessionNaming detects a session left under the previous tmux_prefix or
+// session_name. With offer, it asks to rename the session and its windows to
+// the current naming and records the naming in git config. Without offer
+// (read-only commands, which may run without tmux), it only warns and ignores
+// tmux errors. The naming is recorded only once no session is left under the
+// previous one: a declined prompt, or one without input to answer it, keeps
+// the old naming so that the next command asks again, until the user records
+// the new naming by hand as the warning explains.
+func (a *App) checkSessionNaming(cmd *cobra.Command, d *deps, offer bool) error {
+	n := d.naming
+	if n == nil || n.recorded {
+		return nil
+	}
+	done := func() error {
+		if !offer {
+			return nil
+		}
+		return n.record(d.git)
+	}
+	if n.oldSession == n.session {
+		return done()
+	}
+
+	oldExists, err := n.raw.HasSession(n.oldSession)
+	if err == nil && oldExists {
+		var newExists bool
+		newExists, err = n.raw.HasSession(n.session)
+		if err == nil && newExists {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s tmux session '%s' from the previous naming still exists alongside '%s'. %s\n", ui.Yellow("⚠"), n.oldSession, n.session, n.dismissHint())
+			return nil
+		}
+	}
+	switch {
+	case err != nil && offer:
+		return fmt.Errorf("checking session: %w", err)
+	case err != nil || !oldExists:
+		return done()
+	case !offer:
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s tmux session '%s' uses the previous tmux_prefix/session_name; run 'hashi switch' to rename it to '%s'\n", ui.Yellow("⚠"), n.oldSession, n.session)
+		return nil
+	case !confirmPrompt(cmd, fmt.Sprintf("tmux session '%s' uses the previous tmux_prefix/session_name. Rename it to '%s'?", n.oldSession, n.session)):
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s kept tmux session '%s'; hashi will ask again. %s\n", ui.Yellow("⚠"), n.oldSession, n.dismissHint())
+		return nil
+	}
+
+	if err := n.raw.RenameSession(n.oldSession, n.session); err != nil {
+		return fmt.Errorf("renaming session: %w", err)
+	}
+	n.renameWindows()
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", ui.Green(fmt.Sprintf("Renamed tmux session '%s' to '%s'", n.oldSession, n.session)))
+	return n.record(d.git)
+}
+
+// dismissHint tells how to keep the old session and stop the warnings about
+// it: recording the current naming by hand, as hashi does after a rename.
+func (n *sessionNaming) dismissHint() string {
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" }
+	return fmt.Sprintf("To leave it alone for good, run: git config %s %s && git config %s %s",
+		configTmuxSession, quote(n.session), configTmuxPrefix, quote(n.prefix))
+}
+
+// renameWindows moves managed windows named with the old prefix to the new one.
+// Failures are ignored: tagged windows stay managed whatever their name.
+func (n *sessionNaming) renameWindows() {
+	if n.oldPrefix == n.prefix {
+		return
+	}
+	windows, err := n.raw.ListWindows(n.session)
+	if err != nil {
+		return
+	}
+	for _, w := range windows {
+		branch := w.Branch
+		if branch == "" && n.oldPrefix != "" {
+			branch = strings.TrimPrefix(w.Name, n.oldPrefix)
+		}
+		if branch == "" || w.Name != n.oldPrefix+branch {
+			continue
+		}
+		_ = n.raw.RenameWindow(n.session, w.ID, n.prefix+branch)
+	}
+}
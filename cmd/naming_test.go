@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// namingGit returns a git mock with the given recorded naming and records ConfigSet calls.
+func namingGit(session, prefix string) *git.ClientMock {
+	return &git.ClientMock{
+		ConfigGetFunc: func(key string) (string, error) {
+			if key == configTmuxSession {
+				return session, nil
+			}
+			return prefix, nil
+		},
+		ConfigSetFunc: func(key, value string) error { return nil },
+	}
+}
+
+// rawTmux returns a tmux mock in which only the given sessions exist.
+func rawTmux(sessions ...string) *tmux.ClientMock {
+	return &tmux.ClientMock{
+		HasSessionFunc: func(name string) (bool, error) {
+			for _, s := range sessions {
+				if s == name {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		RenameSessionFunc: func(old, new string) error { return nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{
+				{ID: "@1", Name: "hs/main", Branch: "main"},
+				{ID: "@2", Name: "hs/legacy"},
+				{ID: "@3", Name: "editor", Branch: "feat"},
+				{ID: "@4", Name: "htop"},
+			}, nil
+		},
+		RenameWindowFunc: func(session, old, new string) error { return nil },
+	}
+}
+
+func namingCmd(input string) (*cobra.Command, *bytes.Buffer) {
+	var errBuf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&errBuf)
+	cmd.SetIn(strings.NewReader(input))
+	return cmd, &errBuf
+}
+
+func TestNewSessionNaming(t *testing.T) {
+	t.Run("defaults to the built-in naming", func(t *testing.T) {
+		n := newSessionNaming(namingGit("", ""), nil, "", "gh/org/repo", "org/repo")
+		assert.Equal(t, "gh/org/repo", n.session)
+		assert.Equal(t, "hs/org/repo", n.oldSession)
+		assert.Equal(t, "hs/", n.oldPrefix)
+		assert.False(t, n.recorded)
+	})
+
+	t.Run("unchanged default naming", func(t *testing.T) {
+		n := newSessionNaming(namingGit("", ""), nil, "hs/", "org/repo", "org/repo")
+		assert.True(t, n.recorded)
+	})
+
+	t.Run("recorded naming", func(t *testing.T) {
+		n := newSessionNaming(namingGit("x-repo", "x-"), nil, "", "repo", "org/repo")
+		assert.Equal(t, "x-repo", n.oldSession)
+		assert.Equal(t, "x-", n.oldPrefix)
+	})
+}
+
+func TestCheckSessionNaming(t *testing.T) {
+	newDeps := func(g *git.ClientMock, raw *tmux.ClientMock) *deps {
+		d := newTestDeps(g, raw)
+		d.naming = newSessionNaming(g, raw, "", "gh/org/repo", "org/repo")
+		return d
+	}
+
+	t.Run("renames the old session and its windows", func(t *testing.T) {
+		g, raw := namingGit("", ""), rawTmux("hs/org/repo")
+		cmd, errBuf := namingCmd("y\n")
+		require.NoError(t, appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), true))
+
+		require.Len(t, raw.RenameSessionCalls(), 1)
+		assert.Equal(t, "hs/org/repo", raw.RenameSessionCalls()[0].Old)
+		assert.Equal(t, "gh/org/repo", raw.RenameSessionCalls()[0].New)
+
+		renamed := map[string]string{}
+		for _, c := range raw.RenameWindowCalls() {
+			assert.Equal(t, "gh/org/repo", c.Session)
+			renamed[c.Old] = c.New
+		}
+		assert.Equal(t, map[string]string{"@1": "main", "@2": "legacy"}, renamed,
+			"prefixed windows are renamed; windows renamed by the user and unmanaged ones are kept")
+		assert.Contains(t, errBuf.String(), "Renamed tmux session")
+
+		require.Len(t, g.ConfigSetCalls(), 2)
+		assert.Equal(t, "gh/org/repo", g.ConfigSetCalls()[0].Value)
+		assert.Equal(t, "", g.ConfigSetCalls()[1].Value)
+	})
+
+	t.Run("declined keeps the session and the old naming", func(t *testing.T) {
+		for _, input := range []string{"n\n", ""} {
+			g, raw := namingGit("", ""), rawTmux("hs/org/repo")
+			cmd, errBuf := namingCmd(input)
+			require.NoError(t, appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), true))
+			assert.Empty(t, raw.RenameSessionCalls())
+			assert.Empty(t, g.ConfigSetCalls(), "input %q", input)
+			assert.Contains(t, errBuf.String(), "hashi will ask again")
+			assert.Contains(t, errBuf.String(), "git config hashi.tmuxSession 'gh/org/repo' && git config hashi.tmuxPrefix ''")
+		}
+	})
+
+	t.Run("no old session records the naming", func(t *testing.T) {
+		g, raw := namingGit("", ""), rawTmux()
+		cmd, errBuf := namingCmd("")
+		require.NoError(t, appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), true))
+		assert.Empty(t, errBuf.String())
+		assert.Len(t, g.ConfigSetCalls(), 2)
+	})
+
+	t.Run("both sessions exist", func(t *testing.T) {
+		g, raw := namingGit("", ""), rawTmux("hs/org/repo", "gh/org/repo")
+		cmd, errBuf := namingCmd("y\n")
+		require.NoError(t, appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), true))
+		assert.Empty(t, raw.RenameSessionCalls())
+		assert.Contains(t, errBuf.String(), "still exists")
+		assert.Empty(t, g.ConfigSetCalls(), "the warning shows until the old session is gone")
+	})
+
+	t.Run("read-only commands only warn", func(t *testing.T) {
+		g, raw := namingGit("", ""), rawTmux("hs/org/repo")
+		cmd, errBuf := namingCmd("y\n")
+		require.NoError(t, appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), false))
+		assert.Empty(t, raw.RenameSessionCalls())
+		assert.Empty(t, g.ConfigSetCalls())
+		assert.Contains(t, errBuf.String(), "previous tmux_prefix/session_name")
+	})
+
+	t.Run("read-only commands ignore tmux errors", func(t *testing.T) {
+		g, raw := namingGit("", ""), rawTmux()
+		raw.HasSessionFunc = func(name string) (bool, error) { return false, fmt.Errorf("tmux not found") }
+		cmd, _ := namingCmd("")
+		require.NoError(t, appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), false))
+
+		err := appWithDeps(nil).checkSessionNaming(cmd, newDeps(g, raw), true)
+		assert.ErrorContains(t, err, "checking session")
+	})
+}
+
+func TestMutatingCommand_withoutInputKeepsNaming(t *testing.T) {
+	g := upDownGit()
+	g.ConfigGetFunc = func(key string) (string, error) { return "", nil }
+	g.ConfigSetFunc = func(key, value string) error { return nil }
+	raw := rawTmux("hs/org/repo")
+	tm := &tmux.ClientMock{
+		HasSessionFunc:  func(name string) (bool, error) { return false, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) { return nil, nil },
+	}
+	d := newTestDeps(g, tm)
+	d.naming = newSessionNaming(g, raw, "", "gh/org/repo", "org/repo")
+
+	out, err := executeCommandWithInput(t, appWithDeps(d), "", "tidy")
+	require.NoError(t, err)
+	assert.Contains(t, out, "hashi will ask again")
+	assert.Empty(t, raw.RenameSessionCalls())
+	assert.Empty(t, g.ConfigSetCalls(), "the previous naming stays recorded")
+	assert.False(t, d.naming.recorded)
+}
@@ -1,19 +1,23 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wasabi0522/hashi/internal/resource"
 )
 
 func (a *App) newCmd(completeBranches completionFunc) *cobra.Command {
-	return &cobra.Command{
-		Use:               "new <branch> [base]",
+	cmd := &cobra.Command{
+		Use:               "new <branch> [base] [--no-attach]",
 		Aliases:           []string{"n"},
 		Short:             "Create a new branch with worktree and tmux window",
 		Args:              cobra.MatchAll(cobra.RangeArgs(1, 2), validateBranchArgs),
 		RunE:              a.runNew,
 		ValidArgsFunction: completeBranches,
 	}
+	a.addNoAttachFlags(cmd)
+	return cmd
 }
 
 func (a *App) runNew(cmd *cobra.Command, args []string) error {
@@ -23,8 +27,16 @@ func (a *App) runNew(cmd *cobra.Command, args []string) error {
 		base = args[1]
 	}
 
-	return a.withService(func(svc *resource.Service) error {
-		_, err := svc.New(cmd.Context(), resource.NewParams{Branch: branch, Base: base})
-		return err
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		res, err := svc.New(cmd.Context(), resource.NewParams{Branch: branch, Base: base})
+		if err != nil {
+			return nil, err
+		}
+		verb := "Opened"
+		if res.Created {
+			verb = "Created"
+		}
+		a.printDetached(cmd, res, fmt.Sprintf("%s '%s'", verb, res.Branch))
+		return []string{res.Branch}, nil
 	})
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -11,6 +12,7 @@ import (
 	hashicontext "github.com/wasabi0522/hashi/internal/context"
 	"github.com/wasabi0522/hashi/internal/git"
 	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
 )
 
 func TestNewCmd(t *testing.T) {
@@ -35,8 +37,8 @@ func TestRunNew(t *testing.T) {
 				HasSessionFunc: func(name string) (bool, error) {
 					return false, nil
 				},
-				NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
-					return nil
+				NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
+					return "@1", nil
 				},
 				IsInsideTmuxFunc: func() bool { return true },
 				SwitchClientFunc: func(session string, window string) error {
@@ -56,6 +58,42 @@ func TestRunNew(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("no attach", func(t *testing.T) {
+		ui.SetNoColor(true)
+		t.Cleanup(func() { ui.SetNoColor(false) })
+
+		newApp := func() (*App, *tmux.ClientMock, string) {
+			repoRoot := t.TempDir()
+			tm := &tmux.ClientMock{
+				HasSessionFunc: func(name string) (bool, error) { return false, nil },
+				NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
+					return "@1", nil
+				},
+			}
+			d := newTestDeps(&git.ClientMock{
+				ListBranchesFunc:         func() ([]string, error) { return []string{"main"}, nil },
+				AddWorktreeNewBranchFunc: func(path string, branch string, base string) error { return nil },
+			}, tm)
+			d.ctx.RepoRoot = repoRoot
+			return appWithDeps(d), tm, repoRoot
+		}
+
+		for _, args := range [][]string{{"--no-attach"}, {"--detach"}, {"-d"}} {
+			app, tm, repoRoot := newApp()
+			out, err := executeCommand(t, app, append([]string{"new", "feature"}, args...)...)
+			require.NoError(t, err, "%v", args)
+			assert.Equal(t, fmt.Sprintf("Created 'feature' (%s)\n", filepath.Join(repoRoot, ".worktrees", "feature")), out)
+			assert.Empty(t, tm.IsInsideTmuxCalls())
+		}
+
+		t.Setenv("HASHI_NO_ATTACH", "1")
+		app, tm, _ := newApp()
+		out, err := executeCommand(t, app, "new", "feature")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Created 'feature'")
+		assert.Empty(t, tm.IsInsideTmuxCalls(), "HASHI_NO_ATTACH works like --no-attach")
+	})
+
 	t.Run("with explicit base", func(t *testing.T) {
 		repoRoot := t.TempDir()
 		var usedBase string
@@ -73,8 +111,8 @@ func TestRunNew(t *testing.T) {
 				HasSessionFunc: func(name string) (bool, error) {
 					return false, nil
 				},
-				NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
-					return nil
+				NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
+					return "@1", nil
 				},
 				IsInsideTmuxFunc: func() bool { return false },
 				AttachSessionFunc: func(session string, window string) error {
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+// pickActions are the keys of `hashi pick` besides Enter, which switches.
+var pickActions = []ui.PickerAction{
+	{Key: ui.KeyCtrlO, Name: "new from"},
+	{Key: ui.KeyCtrlX, Name: "remove"},
+}
+
+func (a *App) pickCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pick",
+		Short: "Pick a branch with a fuzzy finder to switch to, branch from, or remove",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runPick(cmd)
+		},
+	}
+}
+
+// runPick offers every branch, including those without a worktree, and
+// switches to the chosen one, creates a new branch from it, or removes it.
+func (a *App) runPick(cmd *cobra.Command) error {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return err
+	}
+	svc := d.service(a.serviceOpts()...)
+	states, err := svc.Candidates(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	branch, key, err := chooseBranch(cmd, svc, "Switch to:", "switch", states, pickActions)
+	if err != nil || branch == "" {
+		return err
+	}
+	switch key {
+	case ui.KeyCtrlO:
+		return a.newFrom(cmd, branch)
+	case ui.KeyCtrlX:
+		return a.runRemove(cmd, []string{branch}, false)
+	}
+	return a.runSwitch(cmd, []string{branch})
+}
+
+// newFrom prompts for a name and creates a new branch from base.
+func (a *App) newFrom(cmd *cobra.Command, base string) error {
+	branch := promptLine(cmd, fmt.Sprintf("New branch from '%s':", base))
+	if branch == "" {
+		return nil
+	}
+	args := []string{branch, base}
+	if err := validateBranchArgs(cmd, args); err != nil {
+		return err
+	}
+	return a.runNew(cmd, args)
+}
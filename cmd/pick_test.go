@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// pickDeps returns deps with "main", a managed "feature", and an unmanaged "idle" branch.
+func pickDeps() (*deps, *tmux.ClientMock) {
+	tm := &tmux.ClientMock{
+		HasSessionFunc: func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{ID: "@1", Name: "feature"}}, nil
+		},
+		ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+			return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
+		},
+		SendKeysFunc:     func(session, window string, keys ...string) error { return nil },
+		IsInsideTmuxFunc: func() bool { return true },
+		SwitchClientFunc: func(session, window string) error { return nil },
+	}
+	g := &git.ClientMock{
+		BranchExistsFunc: func(name string) (bool, error) { return true, nil },
+		ListBranchesFunc: func() ([]string, error) { return []string{"main", "feature", "idle"}, nil },
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feature", Branch: "feature"},
+			}, nil
+		},
+	}
+	return newTestDeps(g, tm), tm
+}
+
+func TestPickCmd(t *testing.T) {
+	t.Run("switches to the chosen branch", func(t *testing.T) {
+		d, tm := pickDeps()
+		out, err := executeCommandWithInput(t, appWithDeps(d), "feature\n", "pick")
+		require.NoError(t, err)
+		assert.Contains(t, out, "  1) main (default)\n  2) feature\n  3) idle (no worktree)\n")
+		require.Len(t, tm.SwitchClientCalls(), 1)
+		assert.Equal(t, "feature", tm.SwitchClientCalls()[0].Window)
+	})
+
+	t.Run("switch without an argument", func(t *testing.T) {
+		d, tm := pickDeps()
+		_, err := executeCommandWithInput(t, appWithDeps(d), "2\n", "switch")
+		require.NoError(t, err)
+		require.Len(t, tm.SwitchClientCalls(), 1)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		d, tm := pickDeps()
+		_, err := executeCommandWithInput(t, appWithDeps(d), "\n", "pick")
+		require.NoError(t, err)
+		assert.Empty(t, tm.SwitchClientCalls())
+	})
+
+	t.Run("popup switch", func(t *testing.T) {
+		d, tm := pickDeps()
+		_, err := executeCommandWithInput(t, appWithDeps(d), "2\n", "popup", "switch")
+		require.NoError(t, err)
+		require.Len(t, tm.SwitchClientCalls(), 1)
+	})
+}
+
+func TestNewFrom(t *testing.T) {
+	t.Run("empty name cancels", func(t *testing.T) {
+		d, tm := pickDeps()
+		cmd := &cobra.Command{}
+		cmd.SetIn(strings.NewReader("\n"))
+		cmd.SetErr(io.Discard)
+		require.NoError(t, appWithDeps(d).newFrom(cmd, "main"))
+		assert.Empty(t, tm.SwitchClientCalls())
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		d, _ := pickDeps()
+		_, err := executeCommandWithInput(t, appWithDeps(d), "main\nbad..name\n", "popup", "new")
+		assert.Error(t, err)
+	})
+}
+
+func TestPreviewLines(t *testing.T) {
+	assert.Nil(t, previewLines(resource.BranchPreview{}))
+	assert.Equal(t, []string{"Recent commits:", "abc1234 Add feature"},
+		previewLines(resource.BranchPreview{Commits: []string{"abc1234 Add feature"}}))
+	assert.Equal(t, []string{"Recent commits:", "abc1234 Add feature", "", "Uncommitted changes:", " M a.go"},
+		previewLines(resource.BranchPreview{Commits: []string{"abc1234 Add feature"}, Changes: []string{" M a.go"}}))
+	assert.Equal(t, []string{"Uncommitted changes:", "?? b.txt"},
+		previewLines(resource.BranchPreview{Changes: []string{"?? b.txt"}}))
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+	"golang.org/x/term"
+)
+
+// chooseBranch lets the user choose one of the states and returns its branch
+// with the key that chose it. On a terminal it runs the fuzzy picker, which
+// previews the selected branch and offers the given actions besides Enter;
+// enter describes Enter. Otherwise it falls back to pickBranch, where only
+// Enter is available. The branch is "" if the choice was cancelled.
+func chooseBranch(cmd *cobra.Command, svc *resource.Service, title, enter string, states []resource.State, actions []ui.PickerAction) (string, byte, error) {
+	in, ok := cmd.InOrStdin().(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) {
+		branch, err := pickBranch(cmd, title, states)
+		return branch, ui.KeyEnter, err
+	}
+
+	p := &ui.Picker{
+		Enter:   enter,
+		Actions: actions,
+		Preview: func(i int) []string { return previewLines(svc.Preview(states[i])) },
+	}
+	for _, st := range states {
+		p.Items = append(p.Items, ui.PickerItem{Label: st.Branch, Detail: strings.TrimSpace(branchBadges(st))})
+	}
+	i, key, err := p.Pick(in, cmd.ErrOrStderr())
+	if err != nil || i < 0 {
+		return "", 0, err
+	}
+	return states[i].Branch, key, nil
+}
+
+// previewLines renders a branch preview for the picker.
+func previewLines(p resource.BranchPreview) []string {
+	var lines []string
+	if len(p.Commits) > 0 {
+		lines = append(lines, "Recent commits:")
+		lines = append(lines, p.Commits...)
+	}
+	if len(p.Changes) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Uncommitted changes:")
+		lines = append(lines, p.Changes...)
+	}
+	return lines
+}
+
+// pickBranch lists the states as a numbered menu and reads the choice, a
+// number or a branch name, from stdin. It returns "" when the input is empty.
+func pickBranch(cmd *cobra.Command, title string, states []resource.State) (string, error) {
+	if len(states) == 0 {
+		return "", fmt.Errorf("no branches to choose from")
+	}
+	w := cmd.ErrOrStderr()
+	_, _ = fmt.Fprintln(w, title)
+	for i, st := range states {
+		_, _ = fmt.Fprintf(w, "%3d) %s%s\n", i+1, st.Branch, branchBadges(st))
+	}
+	_, _ = fmt.Fprint(w, "> ")
+
+	answer := readLine(cmd.InOrStdin())
+	if answer == "" {
+		return "", nil
+	}
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(states) {
+			return "", fmt.Errorf("no branch numbered %d", n)
+		}
+		return states[n-1].Branch, nil
+	}
+	for _, st := range states {
+		if st.Branch == answer {
+			return answer, nil
+		}
+	}
+	return "", fmt.Errorf("no branch '%s' to choose", answer)
+}
+
+// branchBadges returns the annotations shown after a branch in the picker.
+func branchBadges(st resource.State) string {
+	var badges []string
+	if st.IsDefault {
+		badges = append(badges, "default")
+	}
+	if st.Active {
+		badges = append(badges, "current")
+	}
+	switch {
+	case !st.Status.IsHealthy():
+		badges = append(badges, st.Status.Label())
+	case st.Worktree == "":
+		badges = append(badges, "no worktree")
+	}
+	if len(badges) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(badges, ", ") + ")"
+}
+
+// promptLine prints the prompt and reads one line from stdin.
+func promptLine(cmd *cobra.Command, prompt string) string {
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s ", prompt)
+	return readLine(cmd.InOrStdin())
+}
+
+// readLine reads a single trimmed line from r. It reads byte by byte so that
+// later prompts reading the same input see the remaining lines.
+func readLine(r io.Reader) string {
+	var b strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			b.WriteByte(buf[0])
+		}
+		if err != nil {
+			break
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
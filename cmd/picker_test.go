@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/resource"
+)
+
+func TestPickBranch(t *testing.T) {
+	states := []resource.State{
+		{Branch: "main", Worktree: "/repo", IsDefault: true},
+		{Branch: "feat", Worktree: "/repo/.worktrees/feat", Active: true},
+		{Branch: "idle"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "by number", input: "2\n", want: "feat"},
+		{name: "by name", input: "idle\n", want: "idle"},
+		{name: "empty cancels", input: "\n", want: ""},
+		{name: "no input cancels", input: "", want: ""},
+		{name: "out of range", input: "4\n", wantErr: "no branch numbered 4"},
+		{name: "unknown name", input: "nope\n", wantErr: "no branch 'nope'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetErr(&buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+
+			got, err := pickBranch(cmd, "Switch to:", states)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "Switch to:\n  1) main (default)\n  2) feat (current)\n  3) idle (no worktree)\n> ", buf.String())
+		})
+	}
+
+	t.Run("no states", func(t *testing.T) {
+		_, err := pickBranch(&cobra.Command{}, "Switch to:", nil)
+		assert.ErrorContains(t, err, "no branches")
+	})
+}
+
+func TestBranchBadges(t *testing.T) {
+	assert.Equal(t, "", branchBadges(resource.State{Branch: "feat", Worktree: "/wt"}))
+	assert.Equal(t, " (orphaned window)", branchBadges(resource.State{Branch: "gone", Status: resource.StatusOrphanedWindow}))
+	assert.Equal(t, " (default, current)", branchBadges(resource.State{Branch: "main", Worktree: "/repo", IsDefault: true, Active: true}))
+}
+
+func TestReadLine(t *testing.T) {
+	r := strings.NewReader(" feat \ny\n")
+	assert.Equal(t, "feat", readLine(r))
+	assert.Equal(t, "y", readLine(r), "later reads see the remaining lines")
+	assert.Equal(t, "", readLine(r))
+}
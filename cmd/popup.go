@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+)
+
+// popupCmd groups the interactive actions that the key bindings printed by
+// tmux-conf run in a tmux popup. Actions on "the current branch" resolve it
+// from the window the popup was opened over.
+func (a *App) popupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "popup <action>",
+		Short:  "Run a hashi action interactively inside a tmux popup",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	var current bool
+	remove := &cobra.Command{
+		Use:   "remove [--current]",
+		Short: "Pick a branch to remove",
+		RunE: popupAction(func(cmd *cobra.Command) error {
+			return a.runPopupRemove(cmd, current)
+		}),
+	}
+	remove.Flags().BoolVar(&current, "current", false, "Remove the current branch instead of picking one")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "switch",
+			Short: "Pick a branch to switch to, branch from, or remove",
+			RunE:  popupAction(a.runPick),
+		},
+		&cobra.Command{
+			Use:   "new",
+			Short: "Pick a base and create a new branch from it",
+			RunE:  popupAction(a.runPopupNew),
+		},
+		remove,
+		&cobra.Command{
+			Use:   "rename",
+			Short: "Rename the current branch",
+			RunE:  popupAction(a.runPopupRename),
+		},
+		&cobra.Command{
+			Use:   "show",
+			Short: "Show the current branch",
+			RunE:  popupAction(a.runPopupShow),
+		},
+		&cobra.Command{
+			Use:   "hooks",
+			Short: "Run the post_new hooks in the current branch's window",
+			RunE:  popupAction(a.runPopupHooks),
+		},
+	)
+	for _, c := range cmd.Commands() {
+		c.Args = cobra.NoArgs
+		c.SilenceErrors = true
+		c.SilenceUsage = true
+	}
+	return cmd
+}
+
+// popupAction adapts fn to a RunE. The popup closes as soon as hashi exits,
+// so an error is printed and kept on screen until Enter is pressed.
+func popupAction(fn func(cmd *cobra.Command) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		err := fn(cmd)
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			waitEnter(cmd)
+		}
+		return err
+	}
+}
+
+// waitEnter blocks until a line is read from stdin.
+func waitEnter(cmd *cobra.Command) {
+	promptLine(cmd, "Press Enter to close")
+}
+
+// candidates returns a service with the branches offered by the pickers.
+func (a *App) candidates(cmd *cobra.Command) (*resource.Service, []resource.State, error) {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc := d.service(a.serviceOpts()...)
+	states, err := svc.Candidates(cmd.Context())
+	return svc, states, err
+}
+
+// currentBranch returns the branch of the window the popup was opened over.
+func (a *App) currentBranch(cmd *cobra.Command) (string, error) {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return "", err
+	}
+	return d.service(a.serviceOpts()...).CurrentBranch(cmd.Context())
+}
+
+func (a *App) runPopupNew(cmd *cobra.Command) error {
+	svc, states, err := a.candidates(cmd)
+	if err != nil {
+		return err
+	}
+	base, _, err := chooseBranch(cmd, svc, "New branch from:", "branch from", states, nil)
+	if err != nil || base == "" {
+		return err
+	}
+	return a.newFrom(cmd, base)
+}
+
+func (a *App) runPopupRemove(cmd *cobra.Command, current bool) error {
+	var branch string
+	if current {
+		b, err := a.currentBranch(cmd)
+		if err != nil {
+			return err
+		}
+		branch = b
+	} else {
+		svc, states, err := a.candidates(cmd)
+		if err != nil {
+			return err
+		}
+		var removable []resource.State
+		for _, st := range states {
+			if !st.IsDefault {
+				removable = append(removable, st)
+			}
+		}
+		if branch, _, err = chooseBranch(cmd, svc, "Remove:", "remove", removable, nil); err != nil || branch == "" {
+			return err
+		}
+	}
+	return a.runRemove(cmd, []string{branch}, false)
+}
+
+func (a *App) runPopupRename(cmd *cobra.Command) error {
+	branch, err := a.currentBranch(cmd)
+	if err != nil {
+		return err
+	}
+	name := promptLine(cmd, fmt.Sprintf("Rename '%s' to:", branch))
+	if name == "" || name == branch {
+		return nil
+	}
+	if err := validateBranchArgs(cmd, []string{name}); err != nil {
+		return err
+	}
+	return a.runRename(cmd, []string{branch, name})
+}
+
+func (a *App) runPopupShow(cmd *cobra.Command) error {
+	branch, err := a.currentBranch(cmd)
+	if err != nil {
+		return err
+	}
+	if err := a.runShow(cmd, branch, false); err != nil {
+		return err
+	}
+	waitEnter(cmd)
+	return nil
+}
+
+func (a *App) runPopupHooks(cmd *cobra.Command) error {
+	return a.withService(cmd, func(svc *resource.Service) error {
+		branch, err := svc.CurrentBranch(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return svc.RunHooks(cmd.Context(), branch)
+	})
+}
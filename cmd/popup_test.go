@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestPopupCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("hidden", func(t *testing.T) {
+		out, err := executeCommand(t, NewApp(), "--help")
+		require.NoError(t, err)
+		assert.NotContains(t, out, "\n  popup")
+	})
+
+	t.Run("remove picks a branch and confirms", func(t *testing.T) {
+		d := defaultRemoveDeps(t)
+		d.git.(*git.ClientMock).ListBranchesFunc = func() ([]string, error) { return []string{"main", "feature"}, nil }
+
+		out, err := executeCommandWithInput(t, appWithDeps(d), "1\ny\n", "popup", "remove")
+		require.NoError(t, err)
+		assert.Contains(t, out, "  1) feature (no worktree)\n")
+		assert.NotContains(t, out, "main")
+		assert.Contains(t, out, "Removed 'feature'")
+	})
+
+	t.Run("remove cancelled", func(t *testing.T) {
+		d := defaultRemoveDeps(t)
+		d.git.(*git.ClientMock).ListBranchesFunc = func() ([]string, error) { return []string{"main", "feature"}, nil }
+
+		out, err := executeCommandWithInput(t, appWithDeps(d), "\n", "popup", "remove")
+		require.NoError(t, err)
+		assert.NotContains(t, out, "Removed")
+		assert.Empty(t, d.git.(*git.ClientMock).DeleteBranchFromCalls())
+	})
+
+	t.Run("remove current", func(t *testing.T) {
+		d := defaultRemoveDeps(t)
+		tm := d.tmux.(*tmux.ClientMock)
+		tm.CurrentWindowFunc = func() (string, error) { return "@2", nil }
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+		}
+		tm.KillWindowFunc = func(session, window string) error { return nil }
+
+		out, err := executeCommandWithInput(t, appWithDeps(d), "y\n", "popup", "remove", "--current")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Remove 'feature'?")
+		assert.Contains(t, out, "Removed 'feature'")
+	})
+
+	t.Run("errors wait for enter", func(t *testing.T) {
+		d := defaultRemoveDeps(t)
+		d.tmux.(*tmux.ClientMock).CurrentWindowFunc = func() (string, error) { return "@9", nil }
+
+		out, err := executeCommandWithInput(t, appWithDeps(d), "\n", "popup", "rename")
+		assert.ErrorContains(t, err, "not managed by hashi")
+		assert.Contains(t, out, "Error: the current window is not managed by hashi\nPress Enter to close")
+		assert.NotContains(t, out, "Usage:")
+	})
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultRefreshTTL is how long after a refresh further refreshes are skipped.
+// tmux refreshes status-right every status-interval (15s by default), so with
+// the defaults every other refresh is skipped.
+const defaultRefreshTTL = 30 * time.Second
+
+func (a *App) refreshCmd() *cobra.Command {
+	var ttl time.Duration
+	cmd := &cobra.Command{
+		Use:   "refresh [--ttl <duration>]",
+		Short: "Refresh the window decorations, e.g. periodically with #(hashi refresh)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runRefresh(cmd, ttl)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().DurationVar(&ttl, "ttl", defaultRefreshTTL, "Skip the refresh if the last one is more recent (0 to always refresh)")
+	return cmd
+}
+
+// runRefresh sets the window decorations of the repository's windows. It
+// prints nothing, so it can run from status-right on every status refresh;
+// a stamp in the git common dir skips refreshes within ttl of the last one,
+// such as those of other clients.
+func (a *App) runRefresh(cmd *cobra.Command, ttl time.Duration) error {
+	g, err := a.resolveGitDeps()
+	if err != nil {
+		return err
+	}
+	commonDir, err := g.git.GitCommonDir()
+	if err != nil {
+		return fmt.Errorf("resolving git common dir: %w", err)
+	}
+	stamp := cachePath(commonDir, "refresh")
+	if _, ok := readCache(stamp, ttl); ok {
+		return nil
+	}
+	if ttl > 0 {
+		writeCache(stamp, "")
+	}
+
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return err
+	}
+	return d.service(a.serviceOpts()...).Decorate(cmd.Context())
+}
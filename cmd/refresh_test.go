@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func refreshDeps(t *testing.T) (*deps, *tmux.ClientMock) {
+	t.Helper()
+	commonDir := t.TempDir()
+	g := &git.ClientMock{
+		GitCommonDirFunc: func() (string, error) { return commonDir, nil },
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}}, nil
+		},
+		ListBranchesFunc:          func() ([]string, error) { return []string{"main"}, nil },
+		HasUncommittedChangesFunc: func(path string) (bool, error) { return true, nil },
+		UpstreamFunc:              func(branch string) (string, error) { return "", nil },
+	}
+	tm := &tmux.ClientMock{
+		HasSessionFunc: func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{ID: "@1", Name: "main", Active: true}}, nil
+		},
+		SetWindowOptionFunc: func(session, window, key, value string) error { return nil },
+	}
+	return newTestDeps(g, tm), tm
+}
+
+func TestRefreshCmd(t *testing.T) {
+	t.Run("sets decorations once per ttl", func(t *testing.T) {
+		d, tm := refreshDeps(t)
+		app := appWithDeps(d)
+
+		out, err := executeCommand(t, app, "refresh")
+		require.NoError(t, err)
+		assert.Empty(t, out)
+		calls := tm.SetWindowOptionCalls()
+		require.Len(t, calls, 3)
+		assert.Equal(t, "@1", calls[0].Window)
+		assert.Equal(t, tmux.OptionDirty, calls[0].Key)
+		assert.Equal(t, "1", calls[0].Value)
+
+		_, err = executeCommand(t, app, "refresh")
+		require.NoError(t, err)
+		assert.Len(t, tm.SetWindowOptionCalls(), 3, "skipped within ttl")
+
+		_, err = executeCommand(t, app, "refresh", "--ttl", "0")
+		require.NoError(t, err)
+		assert.Len(t, tm.SetWindowOptionCalls(), 6)
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "refresh")
+		assert.Error(t, err)
+	})
+}
+
+func TestWithMutation_decorates(t *testing.T) {
+	d, tm := refreshDeps(t)
+	tm.SwapWindowFunc = func(session, window string, index int) error { return nil }
+	tm.SelectWindowFunc = func(session, window string) error { return nil }
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	changed := func(svc *resource.Service) ([]string, error) { return []string{"main"}, nil }
+
+	require.NoError(t, appWithDeps(d).withMutation(cmd, changed))
+	assert.Empty(t, tm.SetWindowOptionCalls(), "disabled in config")
+
+	d.cfg.WindowDecorations = true
+	require.NoError(t, appWithDeps(d).withMutation(cmd, func(svc *resource.Service) ([]string, error) { return nil, nil }))
+	assert.Empty(t, tm.SetWindowOptionCalls(), "nothing changed")
+
+	_, err := executeCommand(t, appWithDeps(d), "tidy")
+	require.NoError(t, err)
+	assert.Empty(t, tm.SetWindowOptionCalls(), "tidy changes no branch")
+
+	require.NoError(t, appWithDeps(d).withMutation(cmd, changed))
+	assert.Len(t, tm.SetWindowOptionCalls(), 3)
+
+	err = appWithDeps(d).withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		return []string{"main"}, fmt.Errorf("partway")
+	})
+	assert.ErrorContains(t, err, "partway")
+	assert.Len(t, tm.SetWindowOptionCalls(), 6, "changes made before the error are decorated")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) relocateCmd() *cobra.Command {
+	var relativePaths bool
+	cmd := &cobra.Command{
+		Use:   "relocate [--relative-paths]",
+		Short: "Repair worktrees and windows after the repository directory was moved",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runRelocate(cmd, relativePaths)
+		},
+	}
+	cmd.Flags().BoolVar(&relativePaths, "relative-paths", false, "Enable git's relative worktree paths without prompting")
+	return cmd
+}
+
+func (a *App) runRelocate(cmd *cobra.Command, relativePaths bool) error {
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		res, err := svc.Relocate(cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		w := cmd.OutOrStdout()
+		if len(res.Repaired) == 0 && len(res.Windows) == 0 {
+			_, _ = fmt.Fprintln(w, "No moved worktrees found")
+		} else {
+			for _, p := range res.Repaired {
+				_, _ = fmt.Fprintf(w, "Repaired %s\n", p)
+			}
+			_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf("Relocated %d worktree(s), updated %d window(s)", len(res.Repaired), len(res.Windows))))
+		}
+
+		enabled, err := svc.RelativeWorktreePathsEnabled()
+		if err != nil {
+			return res.Windows, err
+		}
+		if enabled {
+			return res.Windows, nil
+		}
+		if !relativePaths && !confirmPrompt(cmd, "Enable git's relative worktree paths so future moves don't break? (requires git 2.48+)") {
+			return res.Windows, nil
+		}
+		if err := svc.EnableRelativeWorktreePaths(); err != nil {
+			return res.Windows, err
+		}
+		_, _ = fmt.Fprintln(w, "Enabled worktree.useRelativePaths")
+		return res.Windows, nil
+	})
+}
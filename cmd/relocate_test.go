@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func relocateGitMock(relative bool) *git.ClientMock {
+	return &git.ClientMock{
+		GitCommonDirFunc:    func() (string, error) { return "/repo/.git", nil },
+		ConfigGetBoolFunc:   func(key string) (bool, error) { return relative, nil },
+		ConfigSetFunc:       func(key, value string) error { return nil },
+		RepairWorktreesFunc: func(paths ...string) error { return nil },
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}}, nil
+		},
+	}
+}
+
+func noSessionTmux() *tmux.ClientMock {
+	return &tmux.ClientMock{HasSessionFunc: func(name string) (bool, error) { return false, nil }}
+}
+
+func runRelocateWithInput(t *testing.T, app *App, input string, relativePaths bool) (string, error) {
+	t.Helper()
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetIn(strings.NewReader(input))
+	err := app.runRelocate(cmd, relativePaths)
+	return buf.String(), err
+}
+
+func TestRunRelocate(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("repairs moved worktrees", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		wtPath := filepath.Join(repoRoot, ".worktrees", "feature")
+		require.NoError(t, os.MkdirAll(wtPath, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(wtPath, ".git"), []byte("gitdir: /old/.git/worktrees/feature\n"), 0644))
+
+		g := relocateGitMock(true)
+		d := newTestDeps(g, noSessionTmux())
+		d.ctx.RepoRoot = repoRoot
+		out, err := runRelocateWithInput(t, appWithDeps(d), "", false)
+		require.NoError(t, err)
+		assert.Contains(t, out, "Repaired "+wtPath)
+		assert.Contains(t, out, "Relocated 1 worktree(s), updated 0 window(s)")
+		assert.Empty(t, g.ConfigSetCalls())
+	})
+
+	t.Run("nothing moved and relative paths declined", func(t *testing.T) {
+		g := relocateGitMock(false)
+		out, err := runRelocateWithInput(t, appWithDeps(newTestDeps(g, noSessionTmux())), "n\n", false)
+		require.NoError(t, err)
+		assert.Contains(t, out, "No moved worktrees found")
+		assert.Contains(t, out, "relative worktree paths")
+		assert.Empty(t, g.ConfigSetCalls())
+	})
+
+	t.Run("relative paths accepted", func(t *testing.T) {
+		g := relocateGitMock(false)
+		out, err := runRelocateWithInput(t, appWithDeps(newTestDeps(g, noSessionTmux())), "y\n", false)
+		require.NoError(t, err)
+		assert.Contains(t, out, "Enabled worktree.useRelativePaths")
+		assert.Len(t, g.ConfigSetCalls(), 1)
+	})
+
+	t.Run("relative paths flag skips prompt", func(t *testing.T) {
+		g := relocateGitMock(false)
+		out, err := runRelocateWithInput(t, appWithDeps(newTestDeps(g, noSessionTmux())), "", true)
+		require.NoError(t, err)
+		assert.NotContains(t, out, "y/N")
+		assert.Len(t, g.ConfigSetCalls(), 1)
+	})
+
+	t.Run("enable error", func(t *testing.T) {
+		g := relocateGitMock(false)
+		g.ConfigSetFunc = func(key, value string) error { return fmt.Errorf("git error") }
+		_, err := runRelocateWithInput(t, appWithDeps(newTestDeps(g, noSessionTmux())), "", true)
+		assert.Error(t, err)
+	})
+
+	t.Run("config read error", func(t *testing.T) {
+		g := relocateGitMock(false)
+		g.ConfigGetBoolFunc = func(key string) (bool, error) { return false, fmt.Errorf("git error") }
+		_, err := runRelocateWithInput(t, appWithDeps(newTestDeps(g, noSessionTmux())), "", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("relocate error", func(t *testing.T) {
+		g := relocateGitMock(false)
+		g.GitCommonDirFunc = func() (string, error) { return "", fmt.Errorf("git error") }
+		_, err := runRelocateWithInput(t, appWithDeps(newTestDeps(g, noSessionTmux())), "", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := runRelocateWithInput(t, appWithDepsError(fmt.Errorf("no git")), "", false)
+		assert.Error(t, err)
+	})
+}
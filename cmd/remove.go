@@ -33,6 +33,9 @@ func (a *App) runRemove(cmd *cobra.Command, args []string, force bool) error {
 	if err != nil {
 		return err
 	}
+	if err := a.checkSessionNaming(cmd, d, true); err != nil {
+		return err
+	}
 
 	svc := d.service(a.serviceOpts()...)
 
@@ -73,6 +76,7 @@ func buildRemovePrompt(check resource.RemoveCheck) string {
 		{check.HasBranch, "branch"},
 		{check.HasWorktree, "worktree"},
 		{check.HasWindow, "window"},
+		{len(check.Windows) > 0, "windows " + strings.Join(check.Windows, "/")},
 	} {
 		if r.has {
 			b.WriteString(sep)
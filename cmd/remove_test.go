@@ -72,6 +72,11 @@ func TestBuildRemovePrompt(t *testing.T) {
 			check: resource.RemoveCheck{Branch: "orphan", HasWindow: true},
 			exact: "Remove 'orphan'? (window)",
 		},
+		{
+			name:  "extra windows",
+			check: resource.RemoveCheck{Branch: "feature", HasBranch: true, HasWindow: true, Windows: []string{"server", "logs"}},
+			exact: "Remove 'feature'? (branch, window, windows server/logs)",
+		},
 		{
 			name:  "orphaned worktree only",
 			check: resource.RemoveCheck{Branch: "orphan", HasWorktree: true},
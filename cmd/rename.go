@@ -1,24 +1,32 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/wasabi0522/hashi/internal/resource"
 )
 
 func (a *App) renameCmd(completeBranches completionFunc) *cobra.Command {
-	return &cobra.Command{
-		Use:               "rename <old> <new>",
+	cmd := &cobra.Command{
+		Use:               "rename <old> <new> [--no-attach]",
 		Aliases:           []string{"mv"},
 		Short:             "Rename a branch with its worktree and tmux window",
 		Args:              cobra.MatchAll(cobra.ExactArgs(2), validateBranchArgs),
 		RunE:              a.runRename,
 		ValidArgsFunction: completeBranches,
 	}
+	a.addNoAttachFlags(cmd)
+	return cmd
 }
 
 func (a *App) runRename(cmd *cobra.Command, args []string) error {
-	return a.withService(func(svc *resource.Service) error {
-		_, err := svc.Rename(cmd.Context(), resource.RenameParams{Old: args[0], New: args[1]})
-		return err
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		res, err := svc.Rename(cmd.Context(), resource.RenameParams{Old: args[0], New: args[1]})
+		if err != nil {
+			return nil, err
+		}
+		a.printDetached(cmd, res, fmt.Sprintf("Renamed '%s' to '%s'", args[0], res.Branch))
+		return []string{res.Branch}, nil
 	})
 }
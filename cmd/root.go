@@ -31,10 +31,31 @@ func (a *App) BuildRootCmd() *cobra.Command {
 	// Register subcommands
 	rootCmd.AddCommand(a.newCmd(completeBranches))
 	rootCmd.AddCommand(a.switchCmd(completeBranches))
+	rootCmd.AddCommand(a.pickCmd())
+	rootCmd.AddCommand(a.attachCmd(completeBranches))
+	rootCmd.AddCommand(a.sendCmd(completeBranches))
+	rootCmd.AddCommand(a.logsCmd(completeBranches))
 	rootCmd.AddCommand(a.renameCmd(completeBranches))
 	rootCmd.AddCommand(a.removeCmd(completeBranches))
+	rootCmd.AddCommand(a.adoptCmd(completeBranches))
+	rootCmd.AddCommand(a.importCmd())
+	rootCmd.AddCommand(a.relocateCmd())
+	rootCmd.AddCommand(a.tidyCmd())
+	rootCmd.AddCommand(a.downCmd())
+	rootCmd.AddCommand(a.upCmd())
+	rootCmd.AddCommand(a.saveCmd())
+	rootCmd.AddCommand(a.restoreCmd())
 	rootCmd.AddCommand(a.listCmd())
+	rootCmd.AddCommand(a.showCmd(completeBranches))
+	rootCmd.AddCommand(a.statusLineCmd())
+	rootCmd.AddCommand(a.refreshCmd())
+	rootCmd.AddCommand(a.tmuxFormatCmd())
+	rootCmd.AddCommand(a.tmuxConfCmd())
+	rootCmd.AddCommand(a.tmuxHooksCmd())
+	rootCmd.AddCommand(a.popupCmd())
 	rootCmd.AddCommand(a.initCmd())
+	rootCmd.AddCommand(a.hooksCmd())
+	rootCmd.AddCommand(a.hookCmd())
 	rootCmd.AddCommand(completionCmd(rootCmd))
 
 	return rootCmd
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) sendCmd(completeBranches completionFunc) *cobra.Command {
+	var p resource.SendParams
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "send [--all|--status dirty|<branch...>] -- <command>",
+		Short: "Type a command into the shells of several branch windows",
+		Args:  validateSendArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dash := cmd.ArgsLenAtDash()
+			p.Branches, p.Keys = args[:dash], args[dash:]
+			if !all && p.Status == "" && len(p.Branches) == 0 {
+				return errors.New("select the windows with branch names, --all or --status")
+			}
+			if all && len(p.Branches) > 0 {
+				return errors.New("--all cannot be combined with branch names")
+			}
+			return a.runSend(cmd, p)
+		},
+		ValidArgsFunction: completeBranches,
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Send to every branch window")
+	cmd.Flags().StringVar(&p.Status, "status", "", "Send only to branches whose worktree is dirty or clean")
+	cmd.Flags().BoolVar(&p.Raw, "keys", false, "Send tmux key names such as C-c as they are, without pressing Enter")
+	cmd.Flags().BoolVar(&p.AllPanes, "all-panes", false, "Send to every pane, not only those running a shell")
+	return cmd
+}
+
+// validateSendArgs requires the command after "--" and validates the
+// branch names before it.
+func validateSendArgs(cmd *cobra.Command, args []string) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 || dash == len(args) {
+		return errors.New("give the command to send after '--'")
+	}
+	return validateBranchArgs(cmd, args[:dash])
+}
+
+func (a *App) runSend(cmd *cobra.Command, p resource.SendParams) error {
+	return a.withService(cmd, func(svc *resource.Service) error {
+		results, err := svc.Send(cmd.Context(), p)
+		for _, r := range results {
+			if r.Panes == 0 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Skipped '%s': no pane is running a shell\n", r.Branch)
+				continue
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(fmt.Sprintf("Sent to '%s' (%d pane(s))", r.Branch, r.Panes)))
+		}
+		if err == nil && len(results) == 0 {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No windows to send to")
+		}
+		return err
+	})
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestSendCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				if window == "@1" {
+					return []tmux.Pane{{ID: "%1", Command: "nvim"}}, nil
+				}
+				return []tmux.Pane{{ID: "%2", Command: "zsh"}, {ID: "%3", Command: "bash"}}, nil
+			},
+			SendKeysFunc: func(session, window string, keys ...string) error { return nil },
+		}
+	}
+
+	t.Run("all", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "send", "--all", "--", "git", "pull", "--rebase")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Skipped 'main': no pane is running a shell\n")
+		assert.Contains(t, out, "Sent to 'feature' (2 pane(s))\n")
+		require.Len(t, tm.SendKeysCalls(), 2)
+		assert.Equal(t, []string{"C-u", "git pull --rebase", "Enter"}, tm.SendKeysCalls()[0].Keys)
+	})
+
+	t.Run("branches with raw keys", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "send", "main", "--keys", "--all-panes", "--", "C-c")
+		require.NoError(t, err)
+		assert.Equal(t, "Sent to 'main' (1 pane(s))\n", out)
+		require.Len(t, tm.SendKeysCalls(), 1)
+		assert.Equal(t, []string{"C-c"}, tm.SendKeysCalls()[0].Keys)
+	})
+
+	t.Run("argument errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			args []string
+			want string
+		}{
+			{[]string{"send", "--all"}, "give the command to send after '--'"},
+			{[]string{"send", "--all", "--"}, "give the command to send after '--'"},
+			{[]string{"send", "--", "make"}, "select the windows with branch names, --all or --status"},
+			{[]string{"send", "--all", "feature", "--", "make"}, "--all cannot be combined with branch names"},
+			{[]string{"send", "bad..name", "--", "make"}, "branch name contains '..'"},
+		} {
+			tm := newTmux()
+			_, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), tt.args...)
+			assert.ErrorContains(t, err, tt.want, "%v", tt.args)
+			assert.Empty(t, tm.SendKeysCalls(), "%v", tt.args)
+		}
+	})
+}
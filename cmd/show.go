@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) showCmd(completeBranches completionFunc) *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "show <branch>",
+		Short: "Show details of a single branch",
+		Args:  cobra.MatchAll(cobra.ExactArgs(1), validateBranchArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runShow(cmd, args[0], jsonOutput)
+		},
+		ValidArgsFunction: completeBranches,
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func (a *App) runShow(cmd *cobra.Command, branch string, jsonOutput bool) error {
+	d, err := a.resolveDeps(false)
+	if err != nil {
+		return err
+	}
+	if err := a.checkSessionNaming(cmd, d, false); err != nil {
+		return err
+	}
+
+	detail, err := d.service(a.serviceOpts()...).Show(cmd.Context(), branch)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printJSON(cmd.OutOrStdout(), detail)
+	}
+	printDetail(cmd.OutOrStdout(), detail)
+	return nil
+}
+
+// shortHashLen is the number of hash characters shown in the readable output.
+const shortHashLen = 7
+
+func printDetail(w io.Writer, d *resource.BranchDetail) {
+	line := func(label, value string) {
+		_, _ = fmt.Fprintf(w, "%-10s %s\n", label+":", value)
+	}
+
+	line("Branch", d.Branch)
+	if d.Tip != "" {
+		line("Tip", strings.TrimSpace(d.Tip[:min(len(d.Tip), shortHashLen)]+" "+d.Subject))
+	}
+	if d.Upstream != "" {
+		line("Upstream", fmt.Sprintf("%s (ahead %d, behind %d)", d.Upstream, d.Ahead, d.Behind))
+	} else {
+		line("Upstream", "-")
+	}
+	if d.Worktree != "" {
+		line("Worktree", fmt.Sprintf("%s (%s)", d.Worktree, formatBytes(d.DiskUsage)))
+	} else {
+		line("Worktree", "-")
+	}
+	line("Window", formatWindow(d))
+	if len(d.Windows) > 0 {
+		line("Windows", strings.Join(d.Windows, ", "))
+	}
+	line("Stashes", fmt.Sprintf("%d", d.Stashes))
+	if d.Status.IsHealthy() {
+		line("Status", d.Status.String())
+	} else {
+		line("Status", ui.Yellow(fmt.Sprintf("⚠ %s, run '%s'", d.Status.Label(), d.Suggestion)))
+	}
+	printFileList(w, "Dirty", d.Dirty)
+	printFileList(w, "Untracked", d.Untracked)
+}
+
+func formatWindow(d *resource.BranchDetail) string {
+	if !d.Window {
+		return "-"
+	}
+	s := "yes"
+	if d.PaneCommand != "" {
+		s += " (" + d.PaneCommand + ")"
+	}
+	if d.Active {
+		s += " " + ui.Green("*active")
+	}
+	return s
+}
+
+func printFileList(w io.Writer, label string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s:\n", label)
+	for _, f := range files {
+		_, _ = fmt.Fprintf(w, "  %s\n", f)
+	}
+}
+
+// formatBytes formats a byte count using binary units (e.g. "1.5 MiB").
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
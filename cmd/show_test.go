@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func showDeps() *deps {
+	g := &git.ClientMock{
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}}, nil
+		},
+		ListBranchesFunc: func() ([]string, error) { return []string{"main"}, nil },
+		BranchTipFunc: func(branch string) (string, string, error) {
+			return "0123456789abcdef", "Initial commit", nil
+		},
+		UpstreamFunc:       func(branch string) (string, error) { return "", nil },
+		ListStashesFunc:    func() ([]string, error) { return nil, nil },
+		WorktreeStatusFunc: func(path string) (git.WorktreeStatus, error) { return git.WorktreeStatus{}, nil },
+	}
+	tm := &tmux.ClientMock{HasSessionFunc: func(name string) (bool, error) { return false, nil }}
+	return newTestDeps(g, tm)
+}
+
+func TestRunShow(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("readable output", func(t *testing.T) {
+		out, err := executeCommand(t, appWithDeps(showDeps()), "show", "main")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Branch:    main")
+		assert.Contains(t, out, "Tip:       0123456 Initial commit")
+		assert.Contains(t, out, "Upstream:  -")
+		assert.Contains(t, out, "Status:    ok")
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		out, err := executeCommand(t, appWithDeps(showDeps()), "show", "--json", "main")
+		require.NoError(t, err)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+		assert.Equal(t, "main", decoded["branch"])
+		assert.Equal(t, "0123456789abcdef", decoded["tip"])
+		assert.Equal(t, "ok", decoded["status"])
+	})
+
+	t.Run("show error", func(t *testing.T) {
+		d := showDeps()
+		d.git.(*git.ClientMock).BranchExistsFunc = func(name string) (bool, error) { return false, nil }
+		_, err := executeCommand(t, appWithDeps(d), "show", "nope")
+		assert.ErrorContains(t, err, "does not exist")
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "show", "main")
+		assert.Error(t, err)
+	})
+}
+
+func TestPrintDetail(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	var buf bytes.Buffer
+	printDetail(&buf, &resource.BranchDetail{
+		State: resource.State{
+			Branch: "feat", Worktree: "/repo/.worktrees/feat", Window: true, Active: true,
+			Windows: []string{"server", "logs"},
+			Status:  resource.StatusOrphanedWorktree,
+		},
+		Upstream: "origin/feat", Ahead: 2, Behind: 1,
+		DiskUsage: 2048, Stashes: 1, PaneCommand: "nvim",
+		Dirty: []string{" M a.go"}, Untracked: []string{"b.txt"},
+		Suggestion: "hashi remove feat",
+	})
+	out := buf.String()
+	assert.Contains(t, out, "Upstream:  origin/feat (ahead 2, behind 1)")
+	assert.Contains(t, out, "Worktree:  /repo/.worktrees/feat (2.0 KiB)")
+	assert.Contains(t, out, "Window:    yes (nvim) *active")
+	assert.Contains(t, out, "Windows:   server, logs")
+	assert.Contains(t, out, "Stashes:   1")
+	assert.Contains(t, out, "⚠ orphaned worktree, run 'hashi remove feat'")
+	assert.Contains(t, out, "Dirty:\n   M a.go\n")
+	assert.Contains(t, out, "Untracked:\n  b.txt\n")
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "3.0 MiB", formatBytes(3*1024*1024))
+}
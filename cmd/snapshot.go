@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+// defaultSnapshot is the name of the snapshot used when none is given.
+const defaultSnapshot = "default"
+
+func (a *App) saveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "save [name]",
+		Short: "Save the windows, pane layouts and running commands to a snapshot",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runSave(cmd, snapshotName(args))
+		},
+	}
+}
+
+func (a *App) restoreCmd() *cobra.Command {
+	var run bool
+	cmd := &cobra.Command{
+		Use:   "restore [name] [--run]",
+		Short: "Recreate the windows of a snapshot that are not open",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runRestore(cmd, snapshotName(args), run)
+		},
+	}
+	cmd.Flags().BoolVar(&run, "run", false, "Re-run the commands that were running in the panes")
+	return cmd
+}
+
+func snapshotName(args []string) string {
+	if len(args) == 0 {
+		return defaultSnapshot
+	}
+	return args[0]
+}
+
+func (a *App) runSave(cmd *cobra.Command, name string) error {
+	path, err := a.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+	return a.withService(cmd, func(svc *resource.Service) error {
+		snap, err := svc.Snapshot(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if err := writeSnapshot(path, snap); err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Saved %d window(s) to '%s'", len(snap.Windows), name)
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.Green(msg))
+		return nil
+	})
+}
+
+func (a *App) runRestore(cmd *cobra.Command, name string, run bool) error {
+	path, err := a.snapshotPath(name)
+	if err != nil {
+		return err
+	}
+	snap, err := readSnapshot(path, name)
+	if err != nil {
+		return err
+	}
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		windows, err := svc.Restore(cmd.Context(), snap, run)
+		w := cmd.OutOrStdout()
+		for _, win := range windows {
+			_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf("Restored '%s'", win)))
+		}
+		if err == nil && len(windows) == 0 {
+			_, _ = fmt.Fprintln(w, "Every window of the snapshot is already open")
+		}
+		return windows, err
+	})
+}
+
+// snapshotPath returns the file of the named snapshot, kept in the git
+// common dir so that it is shared by all worktrees.
+func (a *App) snapshotPath(name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid snapshot name '%s'", name)
+	}
+	g, err := a.resolveGitDeps()
+	if err != nil {
+		return "", err
+	}
+	commonDir, err := g.git.GitCommonDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving git common dir: %w", err)
+	}
+	return cachePath(commonDir, filepath.Join("snapshots", name+".json")), nil
+}
+
+// writeSnapshot writes the snapshot atomically, so an interrupted save
+// leaves the previous one intact.
+func writeSnapshot(path string, snap *resource.Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	_, werr := tmp.Write(append(data, '\n'))
+	if err := errors.Join(werr, tmp.Close()); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return nil
+}
+
+func readSnapshot(path, name string) (*resource.Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no snapshot named '%s': save one with 'hashi save %s'", name, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	var snap resource.Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("reading snapshot '%s': %w", name, err)
+	}
+	return &snap, nil
+}
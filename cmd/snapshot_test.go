@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestSaveRestoreCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	commonDir := t.TempDir()
+	g := upDownGit()
+	g.GitCommonDirFunc = func() (string, error) { return commonDir, nil }
+	open := []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature", Layout: "aaaa,80x24,0,0,2"}}
+	tm := &tmux.ClientMock{
+		HasSessionFunc:  func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) { return open, nil },
+		ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+			if window == "feature" {
+				return []tmux.Pane{{ID: "%2", Active: true, Command: "vim", Dir: "/repo/.worktrees/feature"}}, nil
+			}
+			return []tmux.Pane{{ID: "%1", Active: true, Command: "zsh", Dir: "/repo"}}, nil
+		},
+		ForegroundCommandFunc: func(pane string) (string, error) { return "vim README.md", nil },
+		NewWindowFunc:         func(session, name, dir, initCmd string) (string, error) { return "@3", nil },
+		SendKeysFunc:          func(session, window string, keys ...string) error { return nil },
+	}
+	app := appWithDeps(newTestDeps(g, tm))
+
+	out, err := executeCommand(t, app, "save", "work")
+	require.NoError(t, err)
+	assert.Equal(t, "Saved 2 window(s) to 'work'\n", out)
+	data, err := os.ReadFile(filepath.Join(commonDir, "hashi", "snapshots", "work.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"command": "vim README.md"`)
+
+	t.Run("restore", func(t *testing.T) {
+		open = []tmux.Window{{ID: "@1", Name: "main"}}
+		out, err := executeCommand(t, app, "restore", "work", "--run")
+		require.NoError(t, err)
+		assert.Equal(t, "Restored 'feature'\n", out)
+		require.Len(t, tm.NewWindowCalls(), 1)
+		require.Len(t, tm.SendKeysCalls(), 1)
+		assert.Equal(t, []string{"vim README.md", "Enter"}, tm.SendKeysCalls()[0].Keys)
+	})
+
+	t.Run("already open", func(t *testing.T) {
+		open = []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}
+		out, err := executeCommand(t, app, "restore", "work")
+		require.NoError(t, err)
+		assert.Equal(t, "Every window of the snapshot is already open\n", out)
+	})
+
+	t.Run("missing snapshot", func(t *testing.T) {
+		_, err := executeCommand(t, app, "restore")
+		assert.EqualError(t, err, "no snapshot named 'default': save one with 'hashi save default'")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := executeCommand(t, app, "save", "../x")
+		assert.EqualError(t, err, "invalid snapshot name '../x'")
+	})
+
+	t.Run("corrupt snapshot", func(t *testing.T) {
+		path := filepath.Join(commonDir, "hashi", "snapshots", "bad.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+		_, err := executeCommand(t, app, "restore", "bad")
+		assert.ErrorContains(t, err, "reading snapshot 'bad'")
+	})
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+)
+
+// defaultStatusLineTTL is how long a rendered status line is reused. tmux
+// refreshes status-right every status-interval (15s by default), so with the
+// defaults every other refresh is served from the cache.
+const defaultStatusLineTTL = 30 * time.Second
+
+func (a *App) statusLineCmd() *cobra.Command {
+	var ttl time.Duration
+	cmd := &cobra.Command{
+		Use:   "status-line [--ttl <duration>]",
+		Short: "Print a summary for the tmux status line, e.g. #(hashi status-line)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runStatusLine(cmd, ttl)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().DurationVar(&ttl, "ttl", defaultStatusLineTTL, "Reuse the cached summary for this long (0 to disable)")
+	return cmd
+}
+
+// runStatusLine prints the cached status line while it is fresh, so a
+// refresh costs a single git process. Otherwise it collects the state of
+// every branch once and caches the result.
+func (a *App) runStatusLine(cmd *cobra.Command, ttl time.Duration) error {
+	g, err := a.resolveGitDeps()
+	if err != nil {
+		return err
+	}
+	commonDir, err := g.git.GitCommonDir()
+	if err != nil {
+		return fmt.Errorf("resolving git common dir: %w", err)
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	cache := statusLineCachePath(commonDir, dir)
+	w := cmd.OutOrStdout()
+
+	if line, ok := readCache(cache, ttl); ok {
+		_, _ = fmt.Fprintln(w, line)
+		return nil
+	}
+
+	d, err := a.resolveDeps(false)
+	if err != nil {
+		return err
+	}
+	sum, err := d.service(a.serviceOpts()...).Summarize(cmd.Context(), dir)
+	if err != nil {
+		return err
+	}
+	line := formatStatusLine(sum)
+	if ttl > 0 {
+		writeCache(cache, line)
+	}
+	_, _ = fmt.Fprintln(w, line)
+	return nil
+}
+
+// statusLineCachePath returns the cache file for status lines printed in dir.
+// tmux runs #() commands in the session's directory, so each session of the
+// repository gets its own entry.
+func statusLineCachePath(commonDir, dir string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(dir))
+	return cachePath(commonDir, fmt.Sprintf("status-line-%08x", h.Sum32()))
+}
+
+// formatStatusLine renders the summary with tmux style directives, e.g.
+// "#[fg=green]feature#[default] ↑2 ↓1 · 5 branches · #[fg=yellow]2 dirty#[default]".
+func formatStatusLine(sum *resource.Summary) string {
+	var parts []string
+	if sum.Current != "" {
+		cur := "#[fg=green]" + tmuxEscape(sum.Current) + "#[default]"
+		if sum.Ahead > 0 {
+			cur += fmt.Sprintf(" ↑%d", sum.Ahead)
+		}
+		if sum.Behind > 0 {
+			cur += fmt.Sprintf(" ↓%d", sum.Behind)
+		}
+		parts = append(parts, cur)
+	}
+	parts = append(parts, fmt.Sprintf("%d %s", sum.Branches, plural(sum.Branches, "branch", "branches")))
+	if sum.Dirty > 0 {
+		parts = append(parts, fmt.Sprintf("#[fg=yellow]%d dirty#[default]", sum.Dirty))
+	}
+	if sum.Unhealthy > 0 {
+		parts = append(parts, fmt.Sprintf("#[fg=red]%d unhealthy#[default]", sum.Unhealthy))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// tmuxEscape escapes '#' so tmux does not expand it as a format.
+func tmuxEscape(s string) string {
+	return strings.ReplaceAll(s, "#", "##")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
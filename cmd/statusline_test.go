@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func statusLineDeps(t *testing.T) (*deps, *git.ClientMock) {
+	t.Helper()
+	commonDir := t.TempDir()
+	g := &git.ClientMock{
+		GitCommonDirFunc: func() (string, error) { return commonDir, nil },
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feat#1", Branch: "feat#1"},
+			}, nil
+		},
+		ListBranchesFunc:          func() ([]string, error) { return []string{"main", "feat#1"}, nil },
+		HasUncommittedChangesFunc: func(path string) (bool, error) { return path == "/repo", nil },
+		UpstreamFunc:              func(branch string) (string, error) { return "origin/" + branch, nil },
+		AheadBehindFunc:           func(branch, upstream string) (int, int, error) { return 3, 0, nil },
+	}
+	tm := &tmux.ClientMock{
+		HasSessionFunc: func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "main"}, {Name: "feat#1", Active: true}}, nil
+		},
+	}
+	return newTestDeps(g, tm), g
+}
+
+func TestStatusLineCmd(t *testing.T) {
+	t.Run("prints and caches the summary", func(t *testing.T) {
+		d, g := statusLineDeps(t)
+		app := appWithDeps(d)
+
+		out, err := executeCommand(t, app, "status-line")
+		require.NoError(t, err)
+		assert.Equal(t, "#[fg=green]feat##1#[default] ↑3 · 2 branches · #[fg=yellow]1 dirty#[default]\n", out)
+
+		g.ListWorktreesFunc = func() ([]git.Worktree, error) { return nil, fmt.Errorf("not called") }
+		cached, err := executeCommand(t, app, "status-line")
+		require.NoError(t, err)
+		assert.Equal(t, out, cached)
+		assert.Len(t, g.ListWorktreesCalls(), 1, "a fresh cache skips collecting state")
+	})
+
+	t.Run("expired cache is refreshed", func(t *testing.T) {
+		d, g := statusLineDeps(t)
+		app := appWithDeps(d)
+		_, err := executeCommand(t, app, "status-line")
+		require.NoError(t, err)
+
+		_, err = executeCommand(t, app, "status-line", "--ttl", "0")
+		require.NoError(t, err)
+		assert.Len(t, g.ListWorktreesCalls(), 2)
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "status-line")
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatStatusLine(t *testing.T) {
+	tests := []struct {
+		name string
+		sum  resource.Summary
+		want string
+	}{
+		{name: "no active branch", sum: resource.Summary{Branches: 1}, want: "1 branch"},
+		{
+			name: "ahead and behind",
+			sum:  resource.Summary{Branches: 3, Current: "main", Ahead: 1, Behind: 2},
+			want: "#[fg=green]main#[default] ↑1 ↓2 · 3 branches",
+		},
+		{
+			name: "unhealthy",
+			sum:  resource.Summary{Branches: 4, Dirty: 2, Unhealthy: 1},
+			want: "4 branches · #[fg=yellow]2 dirty#[default] · #[fg=red]1 unhealthy#[default]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatStatusLine(&tt.sum))
+		})
+	}
+}
@@ -1,25 +1,36 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/wasabi0522/hashi/internal/resource"
 )
 
 func (a *App) switchCmd(completeBranches completionFunc) *cobra.Command {
-	return &cobra.Command{
-		Use:               "switch <branch>",
+	cmd := &cobra.Command{
+		Use:               "switch [branch] [--no-attach]",
 		Aliases:           []string{"sw"},
-		Short:             "Switch to an existing branch",
-		Args:              cobra.MatchAll(cobra.ExactArgs(1), validateBranchArgs),
+		Short:             "Switch to an existing branch, or pick one without an argument",
+		Args:              cobra.MatchAll(cobra.MaximumNArgs(1), validateBranchArgs),
 		RunE:              a.runSwitch,
 		ValidArgsFunction: completeBranches,
 	}
+	a.addNoAttachFlags(cmd)
+	return cmd
 }
 
 func (a *App) runSwitch(cmd *cobra.Command, args []string) error {
-	return a.withService(func(svc *resource.Service) error {
-		_, err := svc.Switch(cmd.Context(), resource.SwitchParams{Branch: args[0]})
-		return err
+	if len(args) == 0 {
+		return a.runPick(cmd)
+	}
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		res, err := svc.Switch(cmd.Context(), resource.SwitchParams{Branch: args[0]})
+		if err != nil {
+			return nil, err
+		}
+		a.printDetached(cmd, res, fmt.Sprintf("Opened '%s'", res.Branch))
+		return []string{res.Branch}, nil
 	})
 }
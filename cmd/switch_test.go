@@ -39,8 +39,8 @@ func TestRunSwitch(t *testing.T) {
 				ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 					return []tmux.Window{{Name: "feature", Active: false}}, nil
 				},
-				PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-					return "zsh", nil
+				ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+					return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
 				},
 				SendKeysFunc: func(session string, window string, keys ...string) error {
 					return nil
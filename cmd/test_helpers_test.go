@@ -2,7 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/wasabi0522/hashi/internal/config"
+	hashicontext "github.com/wasabi0522/hashi/internal/context"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
 )
 
 // appWithDeps creates an App that resolves to the given deps.
@@ -34,3 +40,30 @@ func executeCommand(t *testing.T, app *App, args ...string) (string, error) {
 	err := root.Execute()
 	return buf.String(), err
 }
+
+// executeCommandWithInput is executeCommand with the given stdin.
+func executeCommandWithInput(t *testing.T, app *App, input string, args ...string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	root := app.BuildRootCmd()
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetIn(strings.NewReader(input))
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.String(), err
+}
+
+// newTestDeps creates deps with the given clients and a default repository context.
+func newTestDeps(g git.Client, tm tmux.Client) *deps {
+	return &deps{
+		git:  g,
+		tmux: tm,
+		ctx: &hashicontext.Context{
+			RepoRoot:      "/repo",
+			DefaultBranch: "main",
+			SessionName:   "org/repo",
+		},
+		cfg: &config.Config{WorktreeDir: ".worktrees"},
+	}
+}
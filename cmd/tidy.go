@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) tidyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tidy",
+		Short: "Sort the session's branch windows by window_order",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runTidy(cmd)
+		},
+	}
+}
+
+func (a *App) runTidy(cmd *cobra.Command) error {
+	return a.withService(cmd, func(svc *resource.Service) error {
+		moved, err := svc.Tidy(cmd.Context())
+		if err != nil {
+			return err
+		}
+		w := cmd.OutOrStdout()
+		if moved == 0 {
+			_, _ = fmt.Fprintln(w, "Windows already in order")
+			return nil
+		}
+		_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf("Moved %d window(s)", moved)))
+		return nil
+	})
+}
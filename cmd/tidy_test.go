@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestTidyCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	tidyTmux := func(windows ...tmux.Window) *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return windows, nil
+			},
+			SwapWindowFunc:   func(session, window string, index int) error { return nil },
+			SelectWindowFunc: func(session, window string) error { return nil },
+		}
+	}
+
+	t.Run("moves windows", func(t *testing.T) {
+		tm := tidyTmux(
+			tmux.Window{ID: "@2", Name: "feat", Index: 0, Active: true},
+			tmux.Window{ID: "@1", Name: "main", Index: 1},
+		)
+		out, err := executeCommand(t, appWithDeps(newTestDeps(&git.ClientMock{}, tm)), "tidy")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Moved 1 window(s)")
+		require.Len(t, tm.SwapWindowCalls(), 1)
+		assert.Equal(t, "@1", tm.SwapWindowCalls()[0].Window)
+		assert.Equal(t, 0, tm.SwapWindowCalls()[0].Index)
+	})
+
+	t.Run("already in order", func(t *testing.T) {
+		tm := tidyTmux(
+			tmux.Window{ID: "@1", Name: "main", Index: 0},
+			tmux.Window{ID: "@2", Name: "feat", Index: 1},
+		)
+		out, err := executeCommand(t, appWithDeps(newTestDeps(&git.ClientMock{}, tm)), "tidy")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Windows already in order")
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		d := newTestDeps(&git.ClientMock{}, &tmux.ClientMock{})
+		d.cfg.Mapping = "session"
+		_, err := executeCommand(t, appWithDeps(d), "tidy")
+		assert.ErrorContains(t, err, "window mapping")
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "tidy")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *App) tmuxConfCmd() *cobra.Command {
+	var key string
+	cmd := &cobra.Command{
+		Use:   "tmux-conf [--key <key>]",
+		Short: "Print a tmux.conf snippet with key bindings for hashi popups and menus",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printTmuxConf(cmd.OutOrStdout(), key)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&key, "key", "H", "Key that, after the prefix, starts the hashi key table")
+	return cmd
+}
+
+// printTmuxConf prints key bindings in a "hashi" key table, entered with
+// prefix + key. Popups start in the current pane's directory so hashi
+// resolves the repository of the window they were opened over.
+func printTmuxConf(w io.Writer, key string) {
+	popup := func(action string) string {
+		return fmt.Sprintf(`display-popup -E -w 80%% -h 80%% -d '#{pane_current_path}' 'hashi popup %s'`, action)
+	}
+	menuItem := func(action string) string {
+		return `"` + popup(action) + `"`
+	}
+	_, _ = fmt.Fprintf(w, `# hashi key bindings: prefix + %[1]s, then
+#   s  switch to a branch    n  create a branch    x  remove a branch
+#   m  menu for the branch of the current window
+bind-key %[1]s switch-client -T hashi
+bind-key -T hashi s %[2]s
+bind-key -T hashi n %[3]s
+bind-key -T hashi x %[4]s
+bind-key -T hashi m display-menu -T 'hashi: #W' \
+  'Rename' r %[5]s \
+  'Remove' x %[6]s \
+  'Show' s %[7]s \
+  'Run hooks' h %[8]s
+`, key, popup("switch"), popup("new"), popup("remove"),
+		menuItem("rename"), menuItem("remove --current"), menuItem("show"), menuItem("hooks"))
+}
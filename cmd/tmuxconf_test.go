@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTmuxConfCmd(t *testing.T) {
+	t.Run("default key", func(t *testing.T) {
+		out, err := executeCommand(t, NewApp(), "tmux-conf")
+		require.NoError(t, err)
+		assert.Contains(t, out, "bind-key H switch-client -T hashi\n")
+		for _, key := range []string{"s", "n", "x"} {
+			assert.Contains(t, out, "bind-key -T hashi "+key+" display-popup -E ")
+		}
+		assert.Contains(t, out, "-d '#{pane_current_path}' 'hashi popup switch'\n")
+		assert.Contains(t, out, "bind-key -T hashi m display-menu -T 'hashi: #W'")
+		assert.Contains(t, out, `'Remove' x "display-popup -E -w 80% -h 80% -d '#{pane_current_path}' 'hashi popup remove --current'"`)
+	})
+
+	t.Run("custom key", func(t *testing.T) {
+		out, err := executeCommand(t, NewApp(), "tmux-conf", "--key", "W")
+		require.NoError(t, err)
+		assert.Contains(t, out, "bind-key W switch-client -T hashi\n")
+	})
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// windowStatusFormat renders a window as "<index>:<name>" followed by the
+// hashi decorations: "!" when the branch is unhealthy, "*" when its worktree
+// is dirty, and "↑N" when it is ahead of its upstream. Managed windows show
+// their branch instead of the prefixed window name.
+const windowStatusFormat = "#I:#{?@hashi_branch,#{@hashi_branch},#W}" +
+	"#{?#{&&:#{@hashi_status},#{!=:#{@hashi_status},ok}},#[fg=red]!#[default],}" +
+	"#{?@hashi_dirty,#[fg=yellow]*#[default],}" +
+	"#{?@hashi_ahead,#[fg=cyan]↑#{@hashi_ahead}#[default],}" +
+	"#F"
+
+func (a *App) tmuxFormatCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tmux-format",
+		Short: "Print a tmux.conf snippet that renders the window decorations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printTmuxFormat(cmd.OutOrStdout())
+			return nil
+		},
+	}
+}
+
+func printTmuxFormat(w io.Writer) {
+	_, _ = fmt.Fprintf(w, `# hashi window decorations: ! unhealthy, * dirty, ↑N ahead of upstream.
+set -g window-status-format '%[1]s'
+set -g window-status-current-format '%[1]s'
+# Refresh the decorations on every status-interval; prints nothing.
+set -ag status-right '#(hashi refresh)'
+# To also refresh them after each hashi command, set window_decorations: true
+# in .hashi.yaml.
+`, windowStatusFormat)
+}
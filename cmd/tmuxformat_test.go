@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTmuxFormatCmd(t *testing.T) {
+	out, err := executeCommand(t, NewApp(), "tmux-format")
+	require.NoError(t, err)
+	assert.Contains(t, out, "set -g window-status-format '"+windowStatusFormat+"'\n")
+	assert.Contains(t, out, "set -g window-status-current-format '"+windowStatusFormat+"'\n")
+	assert.Contains(t, out, "set -ag status-right '#(hashi refresh)'\n")
+	assert.Contains(t, out, "window_decorations: true")
+	assert.NotContains(t, windowStatusFormat, "'", "the format is single-quoted in tmux.conf")
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+// tmuxHooksOption is the session option that enables the tmux hooks for
+// the session's windows.
+const tmuxHooksOption = "@hashi_hooks"
+
+// tmuxHookIndex is the index hashi's commands take in the tmux hook arrays,
+// so that hooks set by the user are kept.
+const tmuxHookIndex = 71
+
+// tmuxHook is a global tmux hook hashi installs.
+type tmuxHook struct {
+	name    string
+	command string
+}
+
+// tmuxHooks returns the global tmux hooks hashi installs. tmux runs
+// window-renamed as a window hook, so it cannot be set on a session;
+// instead, both hooks only act in sessions with tmuxHooksOption.
+// window-renamed only acts on windows tagged with a branch, and runs in the
+// branch's worktree; window-unlinked runs in the session's current pane,
+// since the window is gone.
+func tmuxHooks() []tmuxHook {
+	return []tmuxHook{
+		{"window-renamed", `if-shell -F '#{&&:#{@hashi_branch},#{` + tmuxHooksOption + `}}' ` +
+			`{ run-shell -b 'cd #{q:@hashi_worktree} && hashi hook window-renamed -- #{q:@hashi_branch} #{q:window_name}' }`},
+		{"window-unlinked", `if-shell -F '#{` + tmuxHooksOption + `}' ` +
+			`{ run-shell -b 'cd #{q:pane_current_path} && hashi hook window-unlinked -- #{q:hook_window_name}' }`},
+	}
+}
+
+func (a *App) tmuxHooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tmux-hooks",
+		Short: "Manage tmux hooks that sync windows renamed or closed by hand",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	install := &cobra.Command{
+		Use:   "install",
+		Short: "Rename the branch of a window renamed in tmux, and report windows closed in tmux",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runTmuxHooks(cmd, true)
+		},
+	}
+
+	uninstall := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Stop syncing the session's windows",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runTmuxHooks(cmd, false)
+		},
+	}
+
+	cmd.AddCommand(install, uninstall)
+	return cmd
+}
+
+// runTmuxHooks enables or disables the hooks for the repository session.
+// Installing also sets the global hooks, which are the same for every
+// repository; uninstalling leaves them, since other sessions may use them.
+func (a *App) runTmuxHooks(cmd *cobra.Command, enable bool) error {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		return err
+	}
+	if resource.Mapping(d.cfg.Mapping) == resource.MappingSession {
+		return errors.New("tmux-hooks requires the window mapping: every branch has its own session")
+	}
+	session := d.ctx.SessionName
+	ok, err := d.tmux.HasSession(session)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("tmux session '%s' is not running; open a branch with 'hashi switch' first", d.cfg.TmuxPrefix+session)
+	}
+
+	w := cmd.OutOrStdout()
+	if !enable {
+		if err := d.tmux.SetSessionOption(session, tmuxHooksOption, ""); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf("Removed tmux hooks from '%s'", d.cfg.TmuxPrefix+session)))
+		return nil
+	}
+	for _, h := range tmuxHooks() {
+		if err := d.tmux.SetGlobalHook(fmt.Sprintf("%s[%d]", h.name, tmuxHookIndex), h.command); err != nil {
+			return fmt.Errorf("setting %s hook: %w", h.name, err)
+		}
+	}
+	if err := d.tmux.SetSessionOption(session, tmuxHooksOption, "1"); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf("Installed tmux hooks for '%s'", d.cfg.TmuxPrefix+session)))
+	return nil
+}
+
+// runWindowRenamedHook renames the branch of a window renamed in tmux to
+// the window's new name, without the prefix. If the branch cannot be
+// renamed, the window gets its name back. Hashi's own renames also run the
+// hook; they are no-ops since the branch is already renamed.
+func (a *App) runWindowRenamedHook(cmd *cobra.Command, branch, name string) error {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "hashi: %v\n", err)
+		return nil
+	}
+	newBranch := strings.TrimPrefix(name, d.cfg.TmuxPrefix)
+	if newBranch == branch || strings.Contains(branch, ":") {
+		return nil // unchanged, or an extra window
+	}
+	// The window is already where the user is looking.
+	svc := d.service(append(a.serviceOpts(), resource.WithNoAttach())...)
+	_, err = svc.Rename(cmd.Context(), resource.RenameParams{Old: branch, New: newBranch})
+	var notFound *resource.BranchNotFoundError
+	switch {
+	case errors.As(err, &notFound) && notFound.Branch == branch:
+		return nil
+	case err != nil:
+		_ = d.tmux.RenameWindow(d.ctx.SessionName, branch, branch)
+		displayHookMessage(d, fmt.Sprintf("cannot rename '%s' to '%s': %v", branch, newBranch, err))
+		return nil
+	}
+	a.decorate(cmd, d, svc, newBranch)
+	displayHookMessage(d, fmt.Sprintf("renamed '%s' to '%s' with its worktree", branch, newBranch))
+	return nil
+}
+
+// runWindowUnlinkedHook reports a branch window closed in tmux. The branch
+// is parked: its worktree is kept, and 'hashi switch' opens a new window.
+// Nothing is reported when the branch has no worktree left, as after
+// 'hashi remove'.
+func (a *App) runWindowUnlinkedHook(cmd *cobra.Command, name string) error {
+	d, err := a.resolveDeps(true)
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "hashi: %v\n", err)
+		return nil
+	}
+	branch, ok := strings.CutPrefix(name, d.cfg.TmuxPrefix)
+	if !ok || strings.Contains(branch, ":") {
+		return nil // not a managed name, or an extra window
+	}
+	states, err := d.service(a.serviceOpts()...).CollectState(cmd.Context())
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "hashi: %v\n", err)
+		return nil
+	}
+	for _, st := range states {
+		if st.Branch == branch && !st.Window && st.Worktree != "" && st.Status.IsHealthy() {
+			displayHookMessage(d, fmt.Sprintf("parked '%s': the worktree is kept, 'hashi switch %s' reopens it", branch, branch))
+		}
+	}
+	return nil
+}
+
+func displayHookMessage(d *deps, msg string) {
+	_ = d.tmux.DisplayMessage("hashi: " + msg)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func TestTmuxHooksCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc:       func(name string) (bool, error) { return true, nil },
+			SetGlobalHookFunc:    func(hook, command string) error { return nil },
+			SetSessionOptionFunc: func(session, key, value string) error { return nil },
+		}
+	}
+	hookDeps := func(tm tmux.Client) *deps {
+		d := newTestDeps(upDownGit(), tm)
+		d.cfg.TmuxPrefix = "hs/"
+		return d
+	}
+
+	t.Run("install", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(hookDeps(tm)), "tmux-hooks", "install")
+		require.NoError(t, err)
+		assert.Equal(t, "Installed tmux hooks for 'hs/org/repo'\n", out)
+
+		require.Len(t, tm.SetGlobalHookCalls(), 2)
+		assert.Equal(t, "window-renamed[71]", tm.SetGlobalHookCalls()[0].Hook)
+		assert.Contains(t, tm.SetGlobalHookCalls()[0].Command, "hashi hook window-renamed -- #{q:@hashi_branch} #{q:window_name}")
+		assert.Equal(t, "window-unlinked[71]", tm.SetGlobalHookCalls()[1].Hook)
+		assert.Contains(t, tm.SetGlobalHookCalls()[1].Command, "hashi hook window-unlinked -- #{q:hook_window_name}")
+		require.Len(t, tm.SetSessionOptionCalls(), 1)
+		assert.Equal(t, "org/repo", tm.SetSessionOptionCalls()[0].Session)
+		assert.Equal(t, "@hashi_hooks", tm.SetSessionOptionCalls()[0].Key)
+		assert.Equal(t, "1", tm.SetSessionOptionCalls()[0].Value)
+	})
+
+	t.Run("uninstall", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(hookDeps(tm)), "tmux-hooks", "uninstall")
+		require.NoError(t, err)
+		assert.Equal(t, "Removed tmux hooks from 'hs/org/repo'\n", out)
+		assert.Empty(t, tm.SetGlobalHookCalls(), "other sessions may use the global hooks")
+		require.Len(t, tm.SetSessionOptionCalls(), 1)
+		assert.Empty(t, tm.SetSessionOptionCalls()[0].Value)
+	})
+
+	t.Run("session not running", func(t *testing.T) {
+		tm := newTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return false, nil }
+		_, err := executeCommand(t, appWithDeps(hookDeps(tm)), "tmux-hooks", "install")
+		assert.ErrorContains(t, err, "tmux session 'hs/org/repo' is not running")
+		assert.Empty(t, tm.SetGlobalHookCalls())
+	})
+
+	t.Run("set-hook fails", func(t *testing.T) {
+		tm := newTmux()
+		tm.SetGlobalHookFunc = func(hook, command string) error { return fmt.Errorf("invalid hook") }
+		_, err := executeCommand(t, appWithDeps(hookDeps(tm)), "tmux-hooks", "install")
+		assert.ErrorContains(t, err, "setting window-renamed hook: invalid hook")
+		assert.Empty(t, tm.SetSessionOptionCalls())
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		d := hookDeps(newTmux())
+		d.cfg.Mapping = "session"
+		_, err := executeCommand(t, appWithDeps(d), "tmux-hooks", "install")
+		assert.ErrorContains(t, err, "tmux-hooks requires the window mapping")
+	})
+
+	t.Run("no prefix", func(t *testing.T) {
+		tm := newTmux()
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "tmux-hooks", "install")
+		require.NoError(t, err)
+		assert.Equal(t, "Installed tmux hooks for 'org/repo'\n", out)
+		assert.Len(t, tm.SetGlobalHookCalls(), 2)
+	})
+}
+
+func TestWindowRenamedHook(t *testing.T) {
+	renameGit := func() *git.ClientMock {
+		return &git.ClientMock{
+			ListBranchesFunc:  func() ([]string, error) { return []string{"main", "feature"}, nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, nil },
+			RenameBranchFunc:  func(old, newName string) error { return nil },
+			AddWorktreeFunc:   func(path, branch string) error { return nil },
+		}
+	}
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "feature"}}, nil
+			},
+			RenameWindowFunc:    func(session, oldName, newName string) error { return nil },
+			SetWindowOptionFunc: func(session, window, key, value string) error { return nil },
+			ListPanesFunc:       func(session, window string) ([]tmux.Pane, error) { return nil, nil },
+			DisplayMessageFunc:  func(message string) error { return nil },
+		}
+	}
+	hookDeps := func(g git.Client, tm tmux.Client) *deps {
+		d := newTestDeps(g, tm)
+		d.ctx.RepoRoot = t.TempDir()
+		d.cfg.TmuxPrefix = "hs/"
+		return d
+	}
+
+	t.Run("renames the branch", func(t *testing.T) {
+		g, tm := renameGit(), newTmux()
+		_, err := executeCommand(t, appWithDeps(hookDeps(g, tm)), "hook", "window-renamed", "feature", "hs/topic")
+		require.NoError(t, err)
+		require.Len(t, g.RenameBranchCalls(), 1)
+		assert.Equal(t, "feature", g.RenameBranchCalls()[0].Old)
+		assert.Equal(t, "topic", g.RenameBranchCalls()[0].New)
+		require.Len(t, tm.DisplayMessageCalls(), 1)
+		assert.Equal(t, "hashi: renamed 'feature' to 'topic' with its worktree", tm.DisplayMessageCalls()[0].Message)
+	})
+
+	t.Run("reverts the window on error", func(t *testing.T) {
+		g, tm := renameGit(), newTmux()
+		_, err := executeCommand(t, appWithDeps(hookDeps(g, tm)), "hook", "window-renamed", "feature", "hs/my editor")
+		require.NoError(t, err, "hooks do not fail")
+		assert.Empty(t, g.RenameBranchCalls())
+		require.Len(t, tm.RenameWindowCalls(), 1)
+		assert.Equal(t, "feature", tm.RenameWindowCalls()[0].Old)
+		assert.Equal(t, "feature", tm.RenameWindowCalls()[0].New)
+		require.Len(t, tm.DisplayMessageCalls(), 1)
+		assert.Contains(t, tm.DisplayMessageCalls()[0].Message, "hashi: cannot rename 'feature' to 'my editor'")
+	})
+
+	t.Run("no-ops", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"feature", "hs/feature"},      // unchanged
+			{"feature:server", "hs/other"}, // an extra window
+			{"gone", "hs/topic"},           // already renamed by hashi
+		} {
+			g, tm := renameGit(), newTmux()
+			_, err := executeCommand(t, appWithDeps(hookDeps(g, tm)), append([]string{"hook", "window-renamed"}, args...)...)
+			require.NoError(t, err)
+			assert.Empty(t, g.RenameBranchCalls(), "%v", args)
+			assert.Empty(t, tm.RenameWindowCalls(), "%v", args)
+			assert.Empty(t, tm.DisplayMessageCalls(), "%v", args)
+		}
+	})
+}
+
+func TestWindowUnlinkedHook(t *testing.T) {
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}}, nil
+			},
+			DisplayMessageFunc: func(message string) error { return nil },
+		}
+	}
+	hookDeps := func(tm tmux.Client) *deps {
+		d := newTestDeps(upDownGit(), tm)
+		d.cfg.TmuxPrefix = "hs/"
+		return d
+	}
+
+	t.Run("reports a parked branch", func(t *testing.T) {
+		tm := newTmux()
+		_, err := executeCommand(t, appWithDeps(hookDeps(tm)), "hook", "window-unlinked", "hs/feature")
+		require.NoError(t, err)
+		require.Len(t, tm.DisplayMessageCalls(), 1)
+		assert.Equal(t, "hashi: parked 'feature': the worktree is kept, 'hashi switch feature' reopens it", tm.DisplayMessageCalls()[0].Message)
+	})
+
+	t.Run("silent for other windows", func(t *testing.T) {
+		for _, name := range []string{"hs/feature:server", "editor", "hs/gone"} {
+			tm := newTmux()
+			_, err := executeCommand(t, appWithDeps(hookDeps(tm)), "hook", "window-unlinked", name)
+			require.NoError(t, err)
+			assert.Empty(t, tm.DisplayMessageCalls(), name)
+		}
+	})
+}
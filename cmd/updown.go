@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wasabi0522/hashi/internal/resource"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func (a *App) downCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Close every branch window, keeping branches and worktrees",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runUpDown(cmd, (*resource.Service).Down, "Closed '%s'", "No windows to close")
+		},
+	}
+}
+
+func (a *App) upCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Open a window for every worktree that has none, without running post_new hooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runUpDown(cmd, (*resource.Service).Up, "Opened '%s'", "Every worktree already has a window")
+		},
+	}
+}
+
+// runUpDown runs op and reports each branch it handled with done, or none
+// when there was nothing to do. Branches handled before an error are
+// reported too.
+func (a *App) runUpDown(cmd *cobra.Command, op func(*resource.Service, context.Context) ([]string, error), done, none string) error {
+	return a.withMutation(cmd, func(svc *resource.Service) ([]string, error) {
+		branches, err := op(svc, cmd.Context())
+		w := cmd.OutOrStdout()
+		for _, b := range branches {
+			_, _ = fmt.Fprintf(w, "%s\n", ui.Green(fmt.Sprintf(done, b)))
+		}
+		if err == nil && len(branches) == 0 {
+			_, _ = fmt.Fprintln(w, none)
+		}
+		return branches, err
+	})
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+	"github.com/wasabi0522/hashi/internal/ui"
+)
+
+func upDownGit() *git.ClientMock {
+	return &git.ClientMock{
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feature", Branch: "feature"},
+			}, nil
+		},
+		ListBranchesFunc: func() ([]string, error) { return []string{"main", "feature"}, nil },
+	}
+}
+
+func TestDownCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("closes windows", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+			CurrentWindowFunc: func() (string, error) { return "", fmt.Errorf("not inside tmux") },
+			KillWindowFunc:    func(session, window string) error { return nil },
+		}
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "down")
+		require.NoError(t, err)
+		assert.Equal(t, "Closed 'main'\nClosed 'feature'\n", out)
+		assert.Len(t, tm.KillWindowCalls(), 2)
+	})
+
+	t.Run("nothing to close", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			HasSessionFunc:    func(name string) (bool, error) { return false, nil },
+			CurrentWindowFunc: func() (string, error) { return "", fmt.Errorf("not inside tmux") },
+		}
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "down")
+		require.NoError(t, err)
+		assert.Equal(t, "No windows to close\n", out)
+	})
+
+	t.Run("deps error", func(t *testing.T) {
+		_, err := executeCommand(t, appWithDepsError(fmt.Errorf("no git")), "down")
+		assert.Error(t, err)
+	})
+}
+
+func TestUpCmd(t *testing.T) {
+	ui.SetNoColor(true)
+	t.Cleanup(func() { ui.SetNoColor(false) })
+
+	t.Run("opens windows", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}}, nil
+			},
+			NewWindowFunc: func(session, name, dir, initCmd string) (string, error) { return "@2", nil },
+		}
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "up")
+		require.NoError(t, err)
+		assert.Equal(t, "Opened 'feature'\n", out)
+	})
+
+	t.Run("reports branches opened before an error", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return nil, nil
+			},
+			NewWindowFunc: func(session, name, dir, initCmd string) (string, error) {
+				if name == "main" {
+					return "", fmt.Errorf("new-window failed")
+				}
+				return "@2", nil
+			},
+		}
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "up")
+		assert.ErrorContains(t, err, "opening window of 'main'")
+		assert.Contains(t, out, "Opened 'feature'\n")
+	})
+
+	t.Run("nothing to open", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+		}
+		out, err := executeCommand(t, appWithDeps(newTestDeps(upDownGit(), tm)), "up")
+		require.NoError(t, err)
+		assert.Equal(t, "Every worktree already has a window\n", out)
+	})
+}
@@ -13,6 +13,7 @@ require (
 	github.com/matryer/moq v0.6.0
 	github.com/spf13/cobra v1.10.2
 	github.com/stretchr/testify v1.11.1
+	golang.org/x/term v0.31.0
 )
 
 require (
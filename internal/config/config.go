@@ -6,7 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/confmap"
@@ -19,7 +21,49 @@ import (
 // Config represents the hashi configuration.
 type Config struct {
 	WorktreeDir string `koanf:"worktree_dir"`
-	Hooks       Hooks  `koanf:"hooks"`
+	// TmuxControl sends tmux commands over one persistent control-mode connection.
+	TmuxControl bool `koanf:"tmux_control"`
+	// TmuxSocket selects a dedicated tmux server: a socket name (-L) or path (-S).
+	TmuxSocket string `koanf:"tmux_socket"`
+	// TmuxPrefix is prepended to hashi's tmux session and window names; empty for none.
+	TmuxPrefix string `koanf:"tmux_prefix"`
+	// SessionName is the session-name template, e.g. "{host}/{org}/{repo}".
+	SessionName string `koanf:"session_name"`
+	// Mapping is "window" (a window per branch) or "session" (a session per branch).
+	Mapping string `koanf:"mapping"`
+	// WindowOrder is "created", "alpha", or "recent"; empty leaves windows unsorted.
+	WindowOrder string `koanf:"window_order"`
+	// WindowDecorations maintains the @hashi_dirty, @hashi_ahead, and
+	// @hashi_status window options after each command.
+	WindowDecorations bool `koanf:"window_decorations"`
+	// GroupedSessions connects each terminal through its own session grouped
+	// with the repository session, so terminals can show different windows.
+	GroupedSessions bool `koanf:"grouped_sessions"`
+	// Windows are extra per-branch windows, created next to the branch window.
+	Windows []Window `koanf:"windows"`
+	// Layout lists the panes of new branch windows; the first is the initial pane.
+	Layout []Pane `koanf:"layout"`
+	Hooks  Hooks  `koanf:"hooks"`
+}
+
+// Window defines an extra per-branch tmux window.
+type Window struct {
+	Name    string `koanf:"name"`
+	Command string `koanf:"command"`
+}
+
+// Pane defines a pane of the branch window layout.
+type Pane struct {
+	// Split is "horizontal" (beside) or "vertical" (below); unset for the first pane.
+	Split string `koanf:"split"`
+	// Target is the index of the earlier pane to split; defaults to the previous pane.
+	Target *int `koanf:"target"`
+	// Size is a number of lines/columns or a percentage, e.g. "30%".
+	Size    string `koanf:"size"`
+	Command string `koanf:"command"`
+	Focus   bool   `koanf:"focus"`
+	// PostNew runs the post_new hooks in this pane instead of the first one.
+	PostNew bool `koanf:"post_new"`
 }
 
 // Hooks defines lifecycle hooks.
@@ -72,7 +116,10 @@ func LoadFromReader(r io.Reader) (*Config, error) {
 func newKoanfWithDefaults() *koanf.Koanf {
 	k := koanf.New(".")
 	_ = k.Load(confmap.Provider(map[string]any{
-		"worktree_dir": ".worktrees",
+		"worktree_dir":       ".worktrees",
+		"tmux_prefix":        "hs/",
+		"mapping":            "window",
+		"window_decorations": false,
 	}, "."), nil)
 	return k
 }
@@ -99,6 +146,37 @@ func (c *Config) validate() error {
 	if c.WorktreeDir == "." {
 		return fmt.Errorf("worktree_dir must not be '.': worktrees would be created directly in the repository root")
 	}
+	// tmux splits targets at ':' and '.', and the prefix starts session and
+	// window names verbatim, unlike the sanitized session_name.
+	if strings.ContainsFunc(c.TmuxPrefix, func(r rune) bool {
+		return r == ':' || r == '.' || unicode.IsSpace(r) || unicode.IsControl(r)
+	}) {
+		return fmt.Errorf("tmux_prefix must not contain ':', '.', whitespace or control characters: %q", c.TmuxPrefix)
+	}
+	if c.Mapping != "window" && c.Mapping != "session" {
+		return fmt.Errorf("mapping must be \"window\" or \"session\": %s", c.Mapping)
+	}
+	switch c.WindowOrder {
+	case "", "created", "alpha", "recent":
+	default:
+		return fmt.Errorf("window_order must be \"created\", \"alpha\", or \"recent\": %s", c.WindowOrder)
+	}
+	names := make(map[string]struct{}, len(c.Windows))
+	for _, w := range c.Windows {
+		if w.Name == "" {
+			return fmt.Errorf("windows entry must have a name")
+		}
+		if strings.ContainsAny(w.Name, ":.") {
+			return fmt.Errorf("windows entry name must not contain ':' or '.': %s", w.Name)
+		}
+		if _, dup := names[w.Name]; dup {
+			return fmt.Errorf("duplicate windows entry: %s", w.Name)
+		}
+		names[w.Name] = struct{}{}
+	}
+	if err := c.validateLayout(); err != nil {
+		return err
+	}
 	for _, f := range c.Hooks.CopyFiles {
 		if filepath.IsAbs(f) {
 			return fmt.Errorf("copy_files entry must be a relative path: %s", f)
@@ -109,3 +187,40 @@ func (c *Config) validate() error {
 	}
 	return nil
 }
+
+// paneSizePattern matches a pane size: lines/columns or a percentage.
+var paneSizePattern = regexp.MustCompile(`^[0-9]+%?$`)
+
+func (c *Config) validateLayout() error {
+	var focus, postNew int
+	for i, p := range c.Layout {
+		if i == 0 {
+			if p.Split != "" || p.Target != nil {
+				return fmt.Errorf("layout: the first pane is the window itself and cannot set split or target")
+			}
+		} else {
+			if p.Split != "horizontal" && p.Split != "vertical" {
+				return fmt.Errorf("layout: pane %d split must be \"horizontal\" or \"vertical\": %s", i, p.Split)
+			}
+			if p.Target != nil && (*p.Target < 0 || *p.Target >= i) {
+				return fmt.Errorf("layout: pane %d target must be an earlier pane: %d", i, *p.Target)
+			}
+		}
+		if p.Size != "" && !paneSizePattern.MatchString(p.Size) {
+			return fmt.Errorf("layout: pane %d size must be a number or a percentage: %s", i, p.Size)
+		}
+		if p.Focus {
+			focus++
+		}
+		if p.PostNew {
+			postNew++
+		}
+	}
+	if focus > 1 {
+		return fmt.Errorf("layout: only one pane can set focus")
+	}
+	if postNew > 1 {
+		return fmt.Errorf("layout: only one pane can set post_new")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -51,6 +52,123 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "env_dir", cfg.WorktreeDir)
 	})
 
+	t.Run("tmux_control from file and env", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("tmux_control: true\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.True(t, cfg.TmuxControl)
+
+		t.Setenv("HASHI_TMUX_CONTROL", "false")
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.False(t, cfg.TmuxControl)
+	})
+
+	t.Run("tmux_socket from file and env", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("tmux_socket: hashi\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "hashi", cfg.TmuxSocket)
+
+		t.Setenv("HASHI_TMUX_SOCKET", "/tmp/hashi.sock")
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/hashi.sock", cfg.TmuxSocket)
+	})
+
+	t.Run("tmux naming", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "hs/", cfg.TmuxPrefix, "default prefix")
+		assert.Empty(t, cfg.SessionName)
+
+		require.NoError(t, os.WriteFile(path, []byte("tmux_prefix: \"\"\nsession_name: \"{host}/{org}/{repo}\"\n"), 0644))
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.TmuxPrefix, "empty prefix disables it")
+		assert.Equal(t, "{host}/{org}/{repo}", cfg.SessionName)
+
+		t.Setenv("HASHI_TMUX_PREFIX", "h-")
+		t.Setenv("HASHI_SESSION_NAME", "{dir}")
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "h-", cfg.TmuxPrefix)
+		assert.Equal(t, "{dir}", cfg.SessionName)
+	})
+
+	t.Run("mapping", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "window", cfg.Mapping, "default mapping")
+
+		require.NoError(t, os.WriteFile(path, []byte("mapping: session\n"), 0644))
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "session", cfg.Mapping)
+
+		t.Setenv("HASHI_MAPPING", "pane")
+		_, err = Load(path)
+		assert.ErrorContains(t, err, "mapping must be")
+	})
+
+	t.Run("window_order", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.WindowOrder, "windows are unsorted by default")
+
+		require.NoError(t, os.WriteFile(path, []byte("window_order: recent\n"), 0644))
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "recent", cfg.WindowOrder)
+
+		t.Setenv("HASHI_WINDOW_ORDER", "random")
+		_, err = Load(path)
+		assert.ErrorContains(t, err, "window_order must be")
+	})
+
+	t.Run("window_decorations", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.False(t, cfg.WindowDecorations, "disabled by default")
+
+		t.Setenv("HASHI_WINDOW_DECORATIONS", "true")
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.True(t, cfg.WindowDecorations)
+	})
+
+	t.Run("grouped_sessions", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, ".hashi.yaml")
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.False(t, cfg.GroupedSessions, "disabled by default")
+
+		t.Setenv("HASHI_GROUPED_SESSIONS", "true")
+		cfg, err = Load(path)
+		require.NoError(t, err)
+		assert.True(t, cfg.GroupedSessions)
+	})
+
 	t.Run("absolute worktree_dir rejected", func(t *testing.T) {
 		dir := t.TempDir()
 		path := filepath.Join(dir, ".hashi.yaml")
@@ -153,4 +271,73 @@ func TestLoadFromReader(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "relative path")
 	})
+
+	t.Run("windows", func(t *testing.T) {
+		r := strings.NewReader("windows:\n  - name: server\n    command: npm run dev\n  - name: logs\n")
+		cfg, err := LoadFromReader(r)
+		require.NoError(t, err)
+		assert.Equal(t, []Window{{Name: "server", Command: "npm run dev"}, {Name: "logs"}}, cfg.Windows)
+	})
+
+	t.Run("layout", func(t *testing.T) {
+		r := strings.NewReader(`layout:
+  - command: nvim
+  - split: horizontal
+    size: 40%
+    post_new: true
+  - split: vertical
+    target: 0
+    command: npm test
+    focus: true
+`)
+		cfg, err := LoadFromReader(r)
+		require.NoError(t, err)
+		require.Len(t, cfg.Layout, 3)
+		assert.Equal(t, "nvim", cfg.Layout[0].Command)
+		assert.Equal(t, "horizontal", cfg.Layout[1].Split)
+		assert.Equal(t, "40%", cfg.Layout[1].Size)
+		assert.True(t, cfg.Layout[1].PostNew)
+		assert.Nil(t, cfg.Layout[1].Target)
+		require.NotNil(t, cfg.Layout[2].Target)
+		assert.Equal(t, 0, *cfg.Layout[2].Target)
+		assert.True(t, cfg.Layout[2].Focus)
+	})
+
+	t.Run("invalid layout", func(t *testing.T) {
+		for yaml, want := range map[string]string{
+			"layout:\n  - split: vertical\n":                                         "first pane",
+			"layout:\n  - {}\n  - command: top\n":                                    "split must be",
+			"layout:\n  - {}\n  - split: vertical\n    target: 1\n":                  "target must be an earlier pane",
+			"layout:\n  - {}\n  - split: vertical\n    size: half\n":                 "size must be",
+			"layout:\n  - focus: true\n  - split: vertical\n    focus: true\n":       "only one pane can set focus",
+			"layout:\n  - post_new: true\n  - split: vertical\n    post_new: true\n": "only one pane can set post_new",
+		} {
+			_, err := LoadFromReader(strings.NewReader(yaml))
+			assert.ErrorContains(t, err, want, yaml)
+		}
+	})
+
+	t.Run("invalid tmux_prefix", func(t *testing.T) {
+		for _, prefix := range []string{"hs:", "hs.", "my prefix/", "hs\t"} {
+			_, err := LoadFromReader(strings.NewReader(fmt.Sprintf("tmux_prefix: %q\n", prefix)))
+			assert.ErrorContains(t, err, "tmux_prefix must not contain", prefix)
+		}
+		for _, prefix := range []string{"", "hs/", "x-", "日本/"} {
+			cfg, err := LoadFromReader(strings.NewReader(fmt.Sprintf("tmux_prefix: %q\n", prefix)))
+			require.NoError(t, err, prefix)
+			assert.Equal(t, prefix, cfg.TmuxPrefix)
+		}
+	})
+
+	t.Run("invalid windows", func(t *testing.T) {
+		for yaml, want := range map[string]string{
+			"windows:\n  - command: top\n":               "must have a name",
+			"windows:\n  - name: a:b\n":                  "must not contain",
+			"windows:\n  - name: a.b\n":                  "must not contain",
+			"windows:\n  - name: logs\n  - name: logs\n": "duplicate windows entry",
+		} {
+			_, err := LoadFromReader(strings.NewReader(yaml))
+			assert.ErrorContains(t, err, want, yaml)
+		}
+	})
 }
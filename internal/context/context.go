@@ -14,9 +14,20 @@ import (
 type Context struct {
 	RepoRoot      string
 	DefaultBranch string
-	SessionName   string
+	// SessionName is the tmux session name without tmux_prefix: Resolve
+	// renders DefaultSessionTemplate, and callers re-render it from the
+	// configured session_name template with RenderSessionName.
+	SessionName string
+	// Host, Org and Repo identify the origin remote; empty without one.
+	// Org may contain '/' for nested groups (e.g. GitLab subgroups).
+	Host string
+	Org  string
+	Repo string
 }
 
+// DefaultSessionTemplate is the session-name template used when none is configured.
+const DefaultSessionTemplate = "{org}/{repo}"
+
 // Resolver resolves repository context from git metadata.
 type Resolver struct {
 	git git.Client
@@ -39,13 +50,13 @@ func (r *Resolver) Resolve() (*Context, error) {
 		return nil, err
 	}
 
-	sessionName := r.resolveSessionName(repoRoot)
-
-	return &Context{
+	ctx := &Context{
 		RepoRoot:      repoRoot,
 		DefaultBranch: defaultBranch,
-		SessionName:   sessionName,
-	}, nil
+	}
+	ctx.Host, ctx.Org, ctx.Repo = r.resolveRemote()
+	ctx.SessionName, _ = ctx.RenderSessionName(DefaultSessionTemplate)
+	return ctx, nil
 }
 
 func (r *Resolver) resolveRepoRoot() (string, error) {
@@ -83,16 +94,75 @@ func (r *Resolver) resolveDefaultBranch() (string, error) {
 	return "", fmt.Errorf("could not determine default branch")
 }
 
-func (r *Resolver) resolveSessionName(repoRoot string) string {
+// resolveRemote returns the host, org and repo of the origin remote, or empty
+// strings if there is none or its URL has no repository path.
+func (r *Resolver) resolveRemote() (host, org, repo string) {
 	rawURL, err := r.git.RemoteGetURL("origin")
-	if err == nil {
-		if orgRepo := parseOrgRepo(rawURL); orgRepo != "" {
-			return sanitizeSessionName(orgRepo)
+	if err != nil {
+		return "", "", ""
+	}
+	host, path := parseRemote(rawURL)
+	if path == "" {
+		return "", "", ""
+	}
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return host, path[:i], path[i+1:]
+	}
+	return host, "", path
+}
+
+// RenderSessionName expands a session-name template (DefaultSessionTemplate if
+// empty) with the fields {host}, {org}, {repo} and {dir}, the base name of the
+// repository root. Without a remote, {repo} falls back to {dir}. Empty path
+// segments left by empty fields are dropped, dots in {host} are replaced
+// because tmux does not allow them in session names, and the result is
+// sanitized with sanitizeSessionName.
+func (c *Context) RenderSessionName(tmpl string) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultSessionTemplate
+	}
+	dir := filepath.Base(c.RepoRoot)
+	repo := c.Repo
+	if repo == "" {
+		repo = dir
+	}
+	fields := map[string]string{
+		"host": strings.ReplaceAll(c.Host, ".", "-"),
+		"org":  c.Org,
+		"repo": repo,
+		"dir":  dir,
+	}
+
+	var b strings.Builder
+	rest := tmpl
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			b.WriteString(rest)
+			break
 		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("session_name %q: unclosed '{'", tmpl)
+		}
+		key := rest[start+1 : start+end]
+		value, ok := fields[key]
+		if !ok {
+			return "", fmt.Errorf("session_name %q: unknown field {%s} (want {host}, {org}, {repo} or {dir})", tmpl, key)
+		}
+		b.WriteString(rest[:start])
+		b.WriteString(value)
+		rest = rest[start+end+1:]
 	}
 
-	// Fallback: directory name
-	return sanitizeSessionName(filepath.Base(repoRoot))
+	segments := strings.Split(b.String(), "/")
+	kept := segments[:0]
+	for _, seg := range segments {
+		if seg != "" {
+			kept = append(kept, seg)
+		}
+	}
+	return sanitizeSessionName(strings.Join(kept, "/")), nil
 }
 
 // sanitizeSessionName makes a string safe for use as a tmux session name.
@@ -115,22 +185,22 @@ func sanitizeSessionName(s string) string {
 	return s
 }
 
-// parseOrgRepo extracts "org/repo" from a git remote URL.
-func parseOrgRepo(rawURL string) string {
+// parseRemote extracts the host and the "org/repo" path from a git remote URL.
+func parseRemote(rawURL string) (host, path string) {
 	// SSH format: git@host:org/repo.git
 	if idx := strings.Index(rawURL, "@"); idx >= 0 && !strings.Contains(rawURL, "://") {
 		colonIdx := strings.Index(rawURL, ":")
 		if colonIdx > idx {
-			return cleanRepoPath(rawURL[colonIdx+1:])
+			return rawURL[idx+1 : colonIdx], cleanRepoPath(rawURL[colonIdx+1:])
 		}
 	}
 
 	// URL format: https://host/org/repo.git or ssh://git@host/org/repo.git
 	u, err := url.Parse(rawURL)
 	if err != nil {
-		return ""
+		return "", ""
 	}
-	return cleanRepoPath(u.Path)
+	return u.Hostname(), cleanRepoPath(u.Path)
 }
 
 // cleanRepoPath normalizes a repository path by removing leading slashes and .git suffix.
@@ -33,6 +33,9 @@ func TestResolve(t *testing.T) {
 		assert.Equal(t, "/Users/user/repo", ctx.RepoRoot)
 		assert.Equal(t, "main", ctx.DefaultBranch)
 		assert.Equal(t, "wasabi0522/hashi", ctx.SessionName)
+		assert.Equal(t, "github.com", ctx.Host)
+		assert.Equal(t, "wasabi0522", ctx.Org)
+		assert.Equal(t, "hashi", ctx.Repo)
 	})
 
 	t.Run("not a git repository", func(t *testing.T) {
@@ -107,30 +110,6 @@ func TestResolveDefaultBranch(t *testing.T) {
 	})
 }
 
-func TestResolveSessionName(t *testing.T) {
-	t.Run("from SSH remote URL", func(t *testing.T) {
-		mock := newMock()
-		mock.RemoteGetURLFunc = func(remote string) (string, error) {
-			return "git@github.com:wasabi0522/hashi.git", nil
-		}
-
-		r := &Resolver{git: mock}
-		name := r.resolveSessionName("/Users/user/repo")
-		assert.Equal(t, "wasabi0522/hashi", name)
-	})
-
-	t.Run("fallback to directory name", func(t *testing.T) {
-		mock := newMock()
-		mock.RemoteGetURLFunc = func(remote string) (string, error) {
-			return "", errors.New("no remote")
-		}
-
-		r := &Resolver{git: mock}
-		name := r.resolveSessionName("/Users/user/my-project")
-		assert.Equal(t, "my-project", name)
-	})
-}
-
 func TestSanitizeSessionName(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -198,24 +177,74 @@ func TestResolveWithDefaultBranchError(t *testing.T) {
 	assert.Contains(t, err.Error(), "could not determine default branch")
 }
 
-func TestParseOrgRepo(t *testing.T) {
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantHost string
+		wantPath string
+	}{
+		{"HTTPS with .git", "https://github.com/wasabi0522/hashi.git", "github.com", "wasabi0522/hashi"},
+		{"HTTPS without .git", "https://github.com/wasabi0522/hashi", "github.com", "wasabi0522/hashi"},
+		{"SSH", "git@github.com:wasabi0522/hashi.git", "github.com", "wasabi0522/hashi"},
+		{"SSH without .git", "git@github.com:wasabi0522/hashi", "github.com", "wasabi0522/hashi"},
+		{"SSH protocol", "ssh://git@github.com/wasabi0522/hashi.git", "github.com", "wasabi0522/hashi"},
+		{"empty", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, path := parseRemote(tt.url)
+			assert.Equal(t, tt.wantHost, host)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
+
+func TestResolveRemote(t *testing.T) {
+	mock := newMock()
+	mock.RemoteGetURLFunc = func(remote string) (string, error) {
+		return "git@gitlab.example.com:group/sub/hashi.git", nil
+	}
+	host, org, repo := (&Resolver{git: mock}).resolveRemote()
+	assert.Equal(t, "gitlab.example.com", host)
+	assert.Equal(t, "group/sub", org)
+	assert.Equal(t, "hashi", repo)
+}
+
+func TestRenderSessionName(t *testing.T) {
+	remote := &Context{RepoRoot: "/src/hashi-main", Host: "github.com", Org: "wasabi0522", Repo: "hashi"}
+	local := &Context{RepoRoot: "/src/my project"}
+
 	tests := []struct {
 		name string
-		url  string
+		ctx  *Context
+		tmpl string
 		want string
 	}{
-		{"HTTPS with .git", "https://github.com/wasabi0522/hashi.git", "wasabi0522/hashi"},
-		{"HTTPS without .git", "https://github.com/wasabi0522/hashi", "wasabi0522/hashi"},
-		{"SSH", "git@github.com:wasabi0522/hashi.git", "wasabi0522/hashi"},
-		{"SSH without .git", "git@github.com:wasabi0522/hashi", "wasabi0522/hashi"},
-		{"SSH protocol", "ssh://git@github.com/wasabi0522/hashi.git", "wasabi0522/hashi"},
-		{"empty", "", ""},
+		{"default template", remote, "", "wasabi0522/hashi"},
+		{"default template without remote", local, "", "my-project"},
+		{"host", remote, "{host}/{org}/{repo}", "github-com/wasabi0522/hashi"},
+		{"dir", remote, "{dir}", "hashi-main"},
+		{"literal text", remote, "work:{repo}", "work-hashi"},
+		{"empty fields drop their segment", local, "{host}/{org}/{repo}", "my-project"},
+		{"all fields empty", &Context{RepoRoot: "/"}, "{host}/{org}", "hashi"},
 	}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := parseOrgRepo(tt.url)
+			got, err := tt.ctx.RenderSessionName(tt.tmpl)
+			require.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
 	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := remote.RenderSessionName("{owner}/{repo}")
+		assert.ErrorContains(t, err, "unknown field {owner}")
+	})
+
+	t.Run("unclosed brace", func(t *testing.T) {
+		_, err := remote.RenderSessionName("{repo")
+		assert.ErrorContains(t, err, "unclosed")
+	})
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	osexec "os/exec"
 	"strings"
@@ -25,6 +26,26 @@ func IsExitCode(err error, code int) bool {
 	return false
 }
 
+// OutputError is returned by DefaultExecutor.Output when the command fails.
+// Stdout holds what the command wrote to standard output before failing.
+type OutputError struct {
+	Stdout string
+	Err    error
+}
+
+func (e *OutputError) Error() string { return e.Err.Error() }
+
+func (e *OutputError) Unwrap() error { return e.Err }
+
+// PartialOutput returns the standard output carried by an *OutputError in err's chain, or "".
+func PartialOutput(err error) string {
+	var outErr *OutputError
+	if errors.As(err, &outErr) {
+		return outErr.Stdout
+	}
+	return ""
+}
+
 //go:generate moq -out exec_mock.go . Executor
 
 // Executor abstracts command execution for testing.
@@ -35,6 +56,16 @@ type Executor interface {
 	RunInteractive(name string, args ...string) error
 	RunShell(command, dir string) error
 	RunShellContext(ctx context.Context, command, dir string) error
+	Start(name string, args ...string) (Process, error)
+}
+
+// Process is a running command whose stdin and stdout are connected to the caller.
+// Writes go to the command's stdin and reads come from its stdout.
+type Process interface {
+	io.Writer
+	io.Reader
+	// Close closes the command's stdin and waits for it to exit.
+	Close() error
 }
 
 var _ Executor = (*DefaultExecutor)(nil)
@@ -68,7 +99,7 @@ func (e *DefaultExecutor) Output(name string, args ...string) (string, error) {
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
-		return "", wrapExecError(err, stderr.String())
+		return "", &OutputError{Stdout: stdout.String(), Err: wrapExecError(err, stderr.String())}
 	}
 	return strings.TrimRight(stdout.String(), "\n"), nil
 }
@@ -106,3 +137,35 @@ func (e *DefaultExecutor) RunShellContext(ctx context.Context, command, dir stri
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+// Start starts name with piped stdin and stdout. Stderr is discarded.
+func (e *DefaultExecutor) Start(name string, args ...string) (Process, error) {
+	cmd := osexec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &process{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+}
+
+type process struct {
+	cmd    *osexec.Cmd
+	stdin  io.WriteCloser
+	stdout io.Reader
+}
+
+func (p *process) Write(b []byte) (int, error) { return p.stdin.Write(b) }
+
+func (p *process) Read(b []byte) (int, error) { return p.stdout.Read(b) }
+
+func (p *process) Close() error {
+	_ = p.stdin.Close()
+	return p.cmd.Wait()
+}
@@ -36,6 +36,9 @@ var _ Executor = &ExecutorMock{}
 //			RunShellContextFunc: func(ctx context.Context, command string, dir string) error {
 //				panic("mock out the RunShellContext method")
 //			},
+//			StartFunc: func(name string, args ...string) (Process, error) {
+//				panic("mock out the Start method")
+//			},
 //		}
 //
 //		// use mockedExecutor in code that requires Executor
@@ -61,6 +64,9 @@ type ExecutorMock struct {
 	// RunShellContextFunc mocks the RunShellContext method.
 	RunShellContextFunc func(ctx context.Context, command string, dir string) error
 
+	// StartFunc mocks the Start method.
+	StartFunc func(name string, args ...string) (Process, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// LookPath holds details about calls to the LookPath method.
@@ -105,6 +111,13 @@ type ExecutorMock struct {
 			// Dir is the dir argument value.
 			Dir string
 		}
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Name is the name argument value.
+			Name string
+			// Args is the args argument value.
+			Args []string
+		}
 	}
 	lockLookPath        sync.RWMutex
 	lockOutput          sync.RWMutex
@@ -112,6 +125,7 @@ type ExecutorMock struct {
 	lockRunInteractive  sync.RWMutex
 	lockRunShell        sync.RWMutex
 	lockRunShellContext sync.RWMutex
+	lockStart           sync.RWMutex
 }
 
 // LookPath calls LookPathFunc.
@@ -329,3 +343,39 @@ func (mock *ExecutorMock) RunShellContextCalls() []struct {
 	mock.lockRunShellContext.RUnlock()
 	return calls
 }
+
+// Start calls StartFunc.
+func (mock *ExecutorMock) Start(name string, args ...string) (Process, error) {
+	if mock.StartFunc == nil {
+		panic("ExecutorMock.StartFunc: method is nil but Executor.Start was just called")
+	}
+	callInfo := struct {
+		Name string
+		Args []string
+	}{
+		Name: name,
+		Args: args,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	return mock.StartFunc(name, args...)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedExecutor.StartCalls())
+func (mock *ExecutorMock) StartCalls() []struct {
+	Name string
+	Args []string
+} {
+	var calls []struct {
+		Name string
+		Args []string
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
@@ -1,7 +1,9 @@
 package exec
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -49,6 +51,18 @@ func TestOutput(t *testing.T) {
 		_, err := e.Output("sh", "-c", "exit 1")
 		assert.Error(t, err)
 	})
+
+	t.Run("error keeps partial output", func(t *testing.T) {
+		_, err := e.Output("sh", "-c", "echo first; exit 2")
+		require.Error(t, err)
+		assert.Equal(t, "first\n", PartialOutput(err))
+		assert.True(t, IsExitCode(err, 2))
+	})
+}
+
+func TestPartialOutput(t *testing.T) {
+	assert.Empty(t, PartialOutput(fmt.Errorf("plain")))
+	assert.Equal(t, "out", PartialOutput(fmt.Errorf("wrapped: %w", &OutputError{Stdout: "out", Err: fmt.Errorf("x")})))
 }
 
 func TestRun(t *testing.T) {
@@ -136,3 +150,23 @@ func TestRunInteractive(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestStart(t *testing.T) {
+	e := NewDefaultExecutor()
+
+	t.Run("pipes stdin and stdout", func(t *testing.T) {
+		p, err := e.Start("cat")
+		require.NoError(t, err)
+		_, err = io.WriteString(p, "hello\n")
+		require.NoError(t, err)
+		line, err := bufio.NewReader(p).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", line)
+		require.NoError(t, p.Close())
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		_, err := e.Start("nonexistent-command-xyz-12345")
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,9 @@
 package git
 
 import (
+	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/wasabi0522/hashi/internal/exec"
@@ -29,6 +32,77 @@ func (c *client) RemoteGetURL(remote string) (string, error) {
 	return c.exec.Output("git", "remote", "get-url", remote)
 }
 
+// GitPath resolves a path inside the git directory (e.g. "hooks"), honoring
+// settings such as core.hooksPath.
+func (c *client) GitPath(name string) (string, error) {
+	return c.exec.Output("git", "rev-parse", "--path-format=absolute", "--git-path", name)
+}
+
+func (c *client) ShowToplevel(dir string) (string, error) {
+	return c.exec.Output("git", "-C", dir, "rev-parse", "--show-toplevel")
+}
+
+// inProgressMarkers maps the files git keeps in a worktree's git directory
+// while an operation is stopped halfway to the name of that operation.
+var inProgressMarkers = []struct{ file, op string }{
+	{"rebase-merge", "rebase"},
+	{"rebase-apply", "rebase"},
+	{"MERGE_HEAD", "merge"},
+	{"BISECT_LOG", "bisect"},
+}
+
+// OperationInProgress returns "rebase", "merge" or "bisect" if the worktree
+// containing dir is in the middle of one, or "" if it is not.
+func (c *client) OperationInProgress(dir string) (string, error) {
+	args := []string{"-C", dir, "rev-parse", "--path-format=absolute"}
+	for _, m := range inProgressMarkers {
+		args = append(args, "--git-path", m.file)
+	}
+	out, err := c.exec.Output("git", args...)
+	if err != nil {
+		return "", err
+	}
+	paths := strings.Split(out, "\n")
+	for i, m := range inProgressMarkers {
+		if i >= len(paths) {
+			break
+		}
+		if _, err := os.Stat(paths[i]); err == nil {
+			return m.op, nil
+		}
+	}
+	return "", nil
+}
+
+// ConfigGet returns the value of a git config key, or "" if the key is unset.
+func (c *client) ConfigGet(key string) (string, error) {
+	out, err := c.exec.Output("git", "config", "--get", key)
+	if err == nil {
+		return out, nil
+	}
+	if exec.IsExitCode(err, 1) {
+		return "", nil
+	}
+	return "", err
+}
+
+// ConfigGetBool lets git interpret the value, so yes, on, 1 and any casing
+// count as true. An unset key is false.
+func (c *client) ConfigGetBool(key string) (bool, error) {
+	out, err := c.exec.Output("git", "config", "--type=bool", "--get", key)
+	if err == nil {
+		return out == "true", nil
+	}
+	if exec.IsExitCode(err, 1) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (c *client) ConfigSet(key, value string) error {
+	return c.exec.Run("git", "config", key, value)
+}
+
 func (c *client) ListBranches() ([]string, error) {
 	out, err := c.exec.Output("git", "branch", "--format=%(refname:short)")
 	if err != nil {
@@ -93,6 +167,95 @@ func (c *client) HasUncommittedChanges(worktreePath string) (bool, error) {
 	return out != "", nil
 }
 
+// BranchTip returns the full hash and subject of the branch's latest commit.
+func (c *client) BranchTip(branch string) (string, string, error) {
+	out, err := c.exec.Output("git", "log", "-1", "--format=%H%x09%s", "refs/heads/"+branch, "--")
+	if err != nil {
+		return "", "", err
+	}
+	hash, subject, _ := strings.Cut(out, "\t")
+	return hash, subject, nil
+}
+
+// RecentCommits returns up to n of the branch's latest commits as
+// "<short hash> <subject>", newest first.
+func (c *client) RecentCommits(branch string, n int) ([]string, error) {
+	out, err := c.exec.Output("git", "log", "-n", strconv.Itoa(n), "--format=%h %s", "refs/heads/"+branch, "--")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Upstream returns the short name of the branch's upstream, or "" if none is configured.
+func (c *client) Upstream(branch string) (string, error) {
+	return c.exec.Output("git", "for-each-ref", "--format=%(upstream:short)", "refs/heads/"+branch)
+}
+
+func (c *client) AheadBehind(branch, upstream string) (int, int, error) {
+	out, err := c.exec.Output("git", "rev-list", "--left-right", "--count", branch+"..."+upstream, "--")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseAheadBehind(out)
+}
+
+// parseAheadBehind parses the "<ahead>\t<behind>" output of `git rev-list --left-right --count`.
+func parseAheadBehind(out string) (int, int, error) {
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+	ahead, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing ahead count: %w", err)
+	}
+	behind, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing behind count: %w", err)
+	}
+	return ahead, behind, nil
+}
+
+func (c *client) WorktreeStatus(worktreePath string) (WorktreeStatus, error) {
+	out, err := c.exec.Output("git", "-C", worktreePath, "status", "--porcelain", "--")
+	if err != nil {
+		return WorktreeStatus{}, err
+	}
+	return parseStatusPorcelain(out), nil
+}
+
+// parseStatusPorcelain splits `git status --porcelain` output into dirty and untracked files.
+func parseStatusPorcelain(out string) WorktreeStatus {
+	var st WorktreeStatus
+	for line := range strings.SplitSeq(out, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		if strings.HasPrefix(line, "?? ") {
+			st.Untracked = append(st.Untracked, line[3:])
+			continue
+		}
+		st.Dirty = append(st.Dirty, line)
+	}
+	return st
+}
+
+// ListStashes returns the reflog subjects of all stash entries (e.g. "WIP on main: abc123 msg").
+func (c *client) ListStashes() ([]string, error) {
+	out, err := c.exec.Output("git", "stash", "list", "--format=%gs")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
 func (c *client) ListWorktrees() ([]Worktree, error) {
 	out, err := c.exec.Output("git", "worktree", "list", "--porcelain")
 	if err != nil {
@@ -113,8 +276,19 @@ func (c *client) RemoveWorktree(path string) error {
 	return c.exec.Run("git", "worktree", "remove", "--force", path)
 }
 
-func (c *client) RepairWorktrees() error {
-	return c.exec.Run("git", "worktree", "repair")
+func (c *client) MoveWorktree(src, dst string) error {
+	return c.exec.Run("git", "worktree", "move", "--", src, dst)
+}
+
+// RepairWorktrees runs `git worktree repair`. Paths are the new locations
+// of linked worktrees that were moved without `git worktree move`.
+func (c *client) RepairWorktrees(paths ...string) error {
+	args := []string{"worktree", "repair"}
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+	return c.exec.Run("git", args...)
 }
 
 // parseWorktreeList parses the porcelain output of `git worktree list --porcelain`.
@@ -297,6 +297,16 @@ func TestClientRemoveWorktree(t *testing.T) {
 	require.NoError(t, c.RemoveWorktree("/path"))
 }
 
+func TestClientMoveWorktree(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"worktree", "move", "--", "/old", "/new"}, args)
+		return nil
+	}
+	c := NewClient(e)
+	require.NoError(t, c.MoveWorktree("/old", "/new"))
+}
+
 func TestClientRepairWorktrees(t *testing.T) {
 	e := mockExec()
 	e.RunFunc = func(name string, args ...string) error {
@@ -307,6 +317,336 @@ func TestClientRepairWorktrees(t *testing.T) {
 	require.NoError(t, c.RepairWorktrees())
 }
 
+func TestClientRepairWorktrees_WithPaths(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"worktree", "repair", "--", "/new/a", "/new/b"}, args)
+		return nil
+	}
+	c := NewClient(e)
+	require.NoError(t, c.RepairWorktrees("/new/a", "/new/b"))
+}
+
+func TestClientGitPath(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"rev-parse", "--path-format=absolute", "--git-path", "hooks"}, args)
+		return "/repo/.git/hooks", nil
+	}
+	c := NewClient(e)
+	p, err := c.GitPath("hooks")
+	require.NoError(t, err)
+	assert.Equal(t, "/repo/.git/hooks", p)
+}
+
+func TestClientOperationInProgress(t *testing.T) {
+	dir := t.TempDir()
+	paths := dir + "/rebase-merge\n" + dir + "/rebase-apply\n" + dir + "/MERGE_HEAD\n" + dir + "/BISECT_LOG"
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"-C", "/repo", "rev-parse", "--path-format=absolute",
+			"--git-path", "rebase-merge", "--git-path", "rebase-apply", "--git-path", "MERGE_HEAD", "--git-path", "BISECT_LOG"}, args)
+		return paths, nil
+	}
+	c := NewClient(e)
+
+	op, err := c.OperationInProgress("/repo")
+	require.NoError(t, err)
+	assert.Empty(t, op)
+
+	require.NoError(t, os.WriteFile(dir+"/MERGE_HEAD", nil, 0o644))
+	op, err = c.OperationInProgress("/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "merge", op)
+
+	require.NoError(t, os.Mkdir(dir+"/rebase-apply", 0o755))
+	op, err = c.OperationInProgress("/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "rebase", op)
+
+	e.OutputFunc = func(name string, args ...string) (string, error) { return "", fmt.Errorf("git error") }
+	_, err = c.OperationInProgress("/repo")
+	assert.Error(t, err)
+}
+
+func TestClientShowToplevel(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"-C", "/repo/sub", "rev-parse", "--show-toplevel"}, args)
+		return "/repo", nil
+	}
+	c := NewClient(e)
+	p, err := c.ShowToplevel("/repo/sub")
+	require.NoError(t, err)
+	assert.Equal(t, "/repo", p)
+}
+
+func TestClientConfigGet(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"config", "--get", "worktree.useRelativePaths"}, args)
+			return "true", nil
+		}
+		c := NewClient(e)
+		v, err := c.ConfigGet("worktree.useRelativePaths")
+		require.NoError(t, err)
+		assert.Equal(t, "true", v)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", &osexec.ExitError{ProcessState: newExitCodeState(1)}
+		}
+		c := NewClient(e)
+		v, err := c.ConfigGet("worktree.useRelativePaths")
+		require.NoError(t, err)
+		assert.Empty(t, v)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("git error")
+		}
+		c := NewClient(e)
+		_, err := c.ConfigGet("worktree.useRelativePaths")
+		assert.Error(t, err)
+	})
+}
+
+func TestClientConfigGetBool(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"config", "--type=bool", "--get", "worktree.useRelativePaths"}, args)
+			return "true", nil
+		}
+		v, err := NewClient(e).ConfigGetBool("worktree.useRelativePaths")
+		require.NoError(t, err)
+		assert.True(t, v)
+	})
+
+	t.Run("false", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) { return "false", nil }
+		v, err := NewClient(e).ConfigGetBool("worktree.useRelativePaths")
+		require.NoError(t, err)
+		assert.False(t, v)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", &osexec.ExitError{ProcessState: newExitCodeState(1)}
+		}
+		v, err := NewClient(e).ConfigGetBool("worktree.useRelativePaths")
+		require.NoError(t, err)
+		assert.False(t, v)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("fatal: bad boolean config value")
+		}
+		_, err := NewClient(e).ConfigGetBool("worktree.useRelativePaths")
+		assert.Error(t, err)
+	})
+}
+
+func TestClientConfigSet(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"config", "worktree.useRelativePaths", "true"}, args)
+		return nil
+	}
+	c := NewClient(e)
+	require.NoError(t, c.ConfigSet("worktree.useRelativePaths", "true"))
+}
+
+func TestClientBranchTip(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"log", "-1", "--format=%H%x09%s", "refs/heads/feat", "--"}, args)
+			return "abc123\tAdd feature", nil
+		}
+		c := NewClient(e)
+		hash, subject, err := c.BranchTip("feat")
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", hash)
+		assert.Equal(t, "Add feature", subject)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("git error")
+		}
+		c := NewClient(e)
+		_, _, err := c.BranchTip("feat")
+		assert.Error(t, err)
+	})
+}
+
+func TestClientRecentCommits(t *testing.T) {
+	t.Run("entries", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"log", "-n", "2", "--format=%h %s", "refs/heads/feat", "--"}, args)
+			return "abc1234 Add feature\ndef5678 Initial commit", nil
+		}
+		c := NewClient(e)
+		commits, err := c.RecentCommits("feat", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"abc1234 Add feature", "def5678 Initial commit"}, commits)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("git error")
+		}
+		c := NewClient(e)
+		_, err := c.RecentCommits("feat", 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestClientUpstream(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"for-each-ref", "--format=%(upstream:short)", "refs/heads/feat"}, args)
+		return "origin/feat", nil
+	}
+	c := NewClient(e)
+	up, err := c.Upstream("feat")
+	require.NoError(t, err)
+	assert.Equal(t, "origin/feat", up)
+}
+
+func TestClientAheadBehind(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"rev-list", "--left-right", "--count", "feat...origin/feat", "--"}, args)
+			return "2\t5", nil
+		}
+		c := NewClient(e)
+		ahead, behind, err := c.AheadBehind("feat", "origin/feat")
+		require.NoError(t, err)
+		assert.Equal(t, 2, ahead)
+		assert.Equal(t, 5, behind)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("git error")
+		}
+		c := NewClient(e)
+		_, _, err := c.AheadBehind("feat", "origin/feat")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseAheadBehind(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		ahead   int
+		behind  int
+		wantErr bool
+	}{
+		{name: "tab separated", input: "1\t0", ahead: 1, behind: 0},
+		{name: "malformed", input: "1", wantErr: true},
+		{name: "bad ahead", input: "x\t0", wantErr: true},
+		{name: "bad behind", input: "0\tx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ahead, behind, err := parseAheadBehind(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.ahead, ahead)
+			assert.Equal(t, tt.behind, behind)
+		})
+	}
+}
+
+func TestClientWorktreeStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"-C", "/wt", "status", "--porcelain", "--"}, args)
+			return " M main.go\nA  new.go\n?? scratch.txt", nil
+		}
+		c := NewClient(e)
+		st, err := c.WorktreeStatus("/wt")
+		require.NoError(t, err)
+		assert.Equal(t, []string{" M main.go", "A  new.go"}, st.Dirty)
+		assert.Equal(t, []string{"scratch.txt"}, st.Untracked)
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) { return "", nil }
+		c := NewClient(e)
+		st, err := c.WorktreeStatus("/wt")
+		require.NoError(t, err)
+		assert.Empty(t, st.Dirty)
+		assert.Empty(t, st.Untracked)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("git error")
+		}
+		c := NewClient(e)
+		_, err := c.WorktreeStatus("/wt")
+		assert.Error(t, err)
+	})
+}
+
+func TestClientListStashes(t *testing.T) {
+	t.Run("entries", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"stash", "list", "--format=%gs"}, args)
+			return "WIP on feat: abc msg\nOn main: save", nil
+		}
+		c := NewClient(e)
+		stashes, err := c.ListStashes()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"WIP on feat: abc msg", "On main: save"}, stashes)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) { return "", nil }
+		c := NewClient(e)
+		stashes, err := c.ListStashes()
+		require.NoError(t, err)
+		assert.Nil(t, stashes)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", fmt.Errorf("git error")
+		}
+		c := NewClient(e)
+		_, err := c.ListStashes()
+		assert.Error(t, err)
+	})
+}
+
 func TestParseWorktreeList(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -7,6 +7,11 @@ type Querier interface {
 	GitCommonDir() (string, error)
 	SymbolicRef(ref string) (string, error)
 	RemoteGetURL(remote string) (string, error)
+	ConfigGet(key string) (string, error)
+	ConfigGetBool(key string) (bool, error)
+	GitPath(name string) (string, error)
+	ShowToplevel(dir string) (string, error)
+	OperationInProgress(dir string) (string, error)
 }
 
 // BranchReader abstracts read-only branch operations.
@@ -16,6 +21,12 @@ type BranchReader interface {
 	ListBranches() ([]string, error)
 	IsMerged(branch, base string) (bool, error)
 	HasUncommittedChanges(worktreePath string) (bool, error)
+	BranchTip(branch string) (hash, subject string, err error)
+	RecentCommits(branch string, n int) ([]string, error)
+	Upstream(branch string) (string, error)
+	AheadBehind(branch, upstream string) (ahead, behind int, err error)
+	WorktreeStatus(worktreePath string) (WorktreeStatus, error)
+	ListStashes() ([]string, error)
 }
 
 // BranchWriter abstracts write branch operations.
@@ -32,7 +43,13 @@ type WorktreeManager interface {
 	AddWorktree(path, branch string) error
 	AddWorktreeNewBranch(path, branch, base string) error
 	RemoveWorktree(path string) error
-	RepairWorktrees() error
+	MoveWorktree(src, dst string) error
+	RepairWorktrees(paths ...string) error
+}
+
+// ConfigWriter abstracts writes to the repository's git config.
+type ConfigWriter interface {
+	ConfigSet(key, value string) error
 }
 
 // Client abstracts git operations for testing.
@@ -41,6 +58,7 @@ type Client interface {
 	BranchReader
 	BranchWriter
 	WorktreeManager
+	ConfigWriter
 }
 
 // Worktree represents a git worktree entry.
@@ -53,3 +71,11 @@ type Worktree struct {
 	// Detached is true when the worktree has a detached HEAD (no branch).
 	Detached bool
 }
+
+// WorktreeStatus holds the changed files of a worktree, as reported by `git status --porcelain`.
+type WorktreeStatus struct {
+	// Dirty lists tracked files with staged or unstaged changes, with their two-letter status code (e.g. " M file").
+	Dirty []string
+	// Untracked lists files not known to git.
+	Untracked []string
+}
@@ -23,9 +23,24 @@ var _ Client = &ClientMock{}
 //			AddWorktreeNewBranchFunc: func(path string, branch string, base string) error {
 //				panic("mock out the AddWorktreeNewBranch method")
 //			},
+//			AheadBehindFunc: func(branch string, upstream string) (int, int, error) {
+//				panic("mock out the AheadBehind method")
+//			},
 //			BranchExistsFunc: func(name string) (bool, error) {
 //				panic("mock out the BranchExists method")
 //			},
+//			BranchTipFunc: func(branch string) (string, string, error) {
+//				panic("mock out the BranchTip method")
+//			},
+//			ConfigGetFunc: func(key string) (string, error) {
+//				panic("mock out the ConfigGet method")
+//			},
+//			ConfigGetBoolFunc: func(key string) (bool, error) {
+//				panic("mock out the ConfigGetBool method")
+//			},
+//			ConfigSetFunc: func(key string, value string) error {
+//				panic("mock out the ConfigSet method")
+//			},
 //			CurrentBranchFunc: func(dir string) (string, error) {
 //				panic("mock out the CurrentBranch method")
 //			},
@@ -38,6 +53,9 @@ var _ Client = &ClientMock{}
 //			GitCommonDirFunc: func() (string, error) {
 //				panic("mock out the GitCommonDir method")
 //			},
+//			GitPathFunc: func(name string) (string, error) {
+//				panic("mock out the GitPath method")
+//			},
 //			HasUncommittedChangesFunc: func(worktreePath string) (bool, error) {
 //				panic("mock out the HasUncommittedChanges method")
 //			},
@@ -47,9 +65,21 @@ var _ Client = &ClientMock{}
 //			ListBranchesFunc: func() ([]string, error) {
 //				panic("mock out the ListBranches method")
 //			},
+//			ListStashesFunc: func() ([]string, error) {
+//				panic("mock out the ListStashes method")
+//			},
 //			ListWorktreesFunc: func() ([]Worktree, error) {
 //				panic("mock out the ListWorktrees method")
 //			},
+//			MoveWorktreeFunc: func(src string, dst string) error {
+//				panic("mock out the MoveWorktree method")
+//			},
+//			OperationInProgressFunc: func(dir string) (string, error) {
+//				panic("mock out the OperationInProgress method")
+//			},
+//			RecentCommitsFunc: func(branch string, n int) ([]string, error) {
+//				panic("mock out the RecentCommits method")
+//			},
 //			RemoteGetURLFunc: func(remote string) (string, error) {
 //				panic("mock out the RemoteGetURL method")
 //			},
@@ -59,15 +89,24 @@ var _ Client = &ClientMock{}
 //			RenameBranchFunc: func(old string, new string) error {
 //				panic("mock out the RenameBranch method")
 //			},
-//			RepairWorktreesFunc: func() error {
+//			RepairWorktreesFunc: func(paths ...string) error {
 //				panic("mock out the RepairWorktrees method")
 //			},
+//			ShowToplevelFunc: func(dir string) (string, error) {
+//				panic("mock out the ShowToplevel method")
+//			},
 //			SwitchBranchFunc: func(dir string, branch string) error {
 //				panic("mock out the SwitchBranch method")
 //			},
 //			SymbolicRefFunc: func(ref string) (string, error) {
 //				panic("mock out the SymbolicRef method")
 //			},
+//			UpstreamFunc: func(branch string) (string, error) {
+//				panic("mock out the Upstream method")
+//			},
+//			WorktreeStatusFunc: func(worktreePath string) (WorktreeStatus, error) {
+//				panic("mock out the WorktreeStatus method")
+//			},
 //		}
 //
 //		// use mockedClient in code that requires Client
@@ -81,9 +120,24 @@ type ClientMock struct {
 	// AddWorktreeNewBranchFunc mocks the AddWorktreeNewBranch method.
 	AddWorktreeNewBranchFunc func(path string, branch string, base string) error
 
+	// AheadBehindFunc mocks the AheadBehind method.
+	AheadBehindFunc func(branch string, upstream string) (int, int, error)
+
 	// BranchExistsFunc mocks the BranchExists method.
 	BranchExistsFunc func(name string) (bool, error)
 
+	// BranchTipFunc mocks the BranchTip method.
+	BranchTipFunc func(branch string) (string, string, error)
+
+	// ConfigGetFunc mocks the ConfigGet method.
+	ConfigGetFunc func(key string) (string, error)
+
+	// ConfigGetBoolFunc mocks the ConfigGetBool method.
+	ConfigGetBoolFunc func(key string) (bool, error)
+
+	// ConfigSetFunc mocks the ConfigSet method.
+	ConfigSetFunc func(key string, value string) error
+
 	// CurrentBranchFunc mocks the CurrentBranch method.
 	CurrentBranchFunc func(dir string) (string, error)
 
@@ -96,6 +150,9 @@ type ClientMock struct {
 	// GitCommonDirFunc mocks the GitCommonDir method.
 	GitCommonDirFunc func() (string, error)
 
+	// GitPathFunc mocks the GitPath method.
+	GitPathFunc func(name string) (string, error)
+
 	// HasUncommittedChangesFunc mocks the HasUncommittedChanges method.
 	HasUncommittedChangesFunc func(worktreePath string) (bool, error)
 
@@ -105,9 +162,21 @@ type ClientMock struct {
 	// ListBranchesFunc mocks the ListBranches method.
 	ListBranchesFunc func() ([]string, error)
 
+	// ListStashesFunc mocks the ListStashes method.
+	ListStashesFunc func() ([]string, error)
+
 	// ListWorktreesFunc mocks the ListWorktrees method.
 	ListWorktreesFunc func() ([]Worktree, error)
 
+	// MoveWorktreeFunc mocks the MoveWorktree method.
+	MoveWorktreeFunc func(src string, dst string) error
+
+	// OperationInProgressFunc mocks the OperationInProgress method.
+	OperationInProgressFunc func(dir string) (string, error)
+
+	// RecentCommitsFunc mocks the RecentCommits method.
+	RecentCommitsFunc func(branch string, n int) ([]string, error)
+
 	// RemoteGetURLFunc mocks the RemoteGetURL method.
 	RemoteGetURLFunc func(remote string) (string, error)
 
@@ -118,7 +187,10 @@ type ClientMock struct {
 	RenameBranchFunc func(old string, new string) error
 
 	// RepairWorktreesFunc mocks the RepairWorktrees method.
-	RepairWorktreesFunc func() error
+	RepairWorktreesFunc func(paths ...string) error
+
+	// ShowToplevelFunc mocks the ShowToplevel method.
+	ShowToplevelFunc func(dir string) (string, error)
 
 	// SwitchBranchFunc mocks the SwitchBranch method.
 	SwitchBranchFunc func(dir string, branch string) error
@@ -126,6 +198,12 @@ type ClientMock struct {
 	// SymbolicRefFunc mocks the SymbolicRef method.
 	SymbolicRefFunc func(ref string) (string, error)
 
+	// UpstreamFunc mocks the Upstream method.
+	UpstreamFunc func(branch string) (string, error)
+
+	// WorktreeStatusFunc mocks the WorktreeStatus method.
+	WorktreeStatusFunc func(worktreePath string) (WorktreeStatus, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// AddWorktree holds details about calls to the AddWorktree method.
@@ -144,11 +222,40 @@ type ClientMock struct {
 			// Base is the base argument value.
 			Base string
 		}
+		// AheadBehind holds details about calls to the AheadBehind method.
+		AheadBehind []struct {
+			// Branch is the branch argument value.
+			Branch string
+			// Upstream is the upstream argument value.
+			Upstream string
+		}
 		// BranchExists holds details about calls to the BranchExists method.
 		BranchExists []struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// BranchTip holds details about calls to the BranchTip method.
+		BranchTip []struct {
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// ConfigGet holds details about calls to the ConfigGet method.
+		ConfigGet []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// ConfigGetBool holds details about calls to the ConfigGetBool method.
+		ConfigGetBool []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// ConfigSet holds details about calls to the ConfigSet method.
+		ConfigSet []struct {
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value string
+		}
 		// CurrentBranch holds details about calls to the CurrentBranch method.
 		CurrentBranch []struct {
 			// Dir is the dir argument value.
@@ -169,6 +276,11 @@ type ClientMock struct {
 		// GitCommonDir holds details about calls to the GitCommonDir method.
 		GitCommonDir []struct {
 		}
+		// GitPath holds details about calls to the GitPath method.
+		GitPath []struct {
+			// Name is the name argument value.
+			Name string
+		}
 		// HasUncommittedChanges holds details about calls to the HasUncommittedChanges method.
 		HasUncommittedChanges []struct {
 			// WorktreePath is the worktreePath argument value.
@@ -184,9 +296,31 @@ type ClientMock struct {
 		// ListBranches holds details about calls to the ListBranches method.
 		ListBranches []struct {
 		}
+		// ListStashes holds details about calls to the ListStashes method.
+		ListStashes []struct {
+		}
 		// ListWorktrees holds details about calls to the ListWorktrees method.
 		ListWorktrees []struct {
 		}
+		// MoveWorktree holds details about calls to the MoveWorktree method.
+		MoveWorktree []struct {
+			// Src is the src argument value.
+			Src string
+			// Dst is the dst argument value.
+			Dst string
+		}
+		// OperationInProgress holds details about calls to the OperationInProgress method.
+		OperationInProgress []struct {
+			// Dir is the dir argument value.
+			Dir string
+		}
+		// RecentCommits holds details about calls to the RecentCommits method.
+		RecentCommits []struct {
+			// Branch is the branch argument value.
+			Branch string
+			// N is the n argument value.
+			N int
+		}
 		// RemoteGetURL holds details about calls to the RemoteGetURL method.
 		RemoteGetURL []struct {
 			// Remote is the remote argument value.
@@ -206,6 +340,13 @@ type ClientMock struct {
 		}
 		// RepairWorktrees holds details about calls to the RepairWorktrees method.
 		RepairWorktrees []struct {
+			// Paths is the paths argument value.
+			Paths []string
+		}
+		// ShowToplevel holds details about calls to the ShowToplevel method.
+		ShowToplevel []struct {
+			// Dir is the dir argument value.
+			Dir string
 		}
 		// SwitchBranch holds details about calls to the SwitchBranch method.
 		SwitchBranch []struct {
@@ -219,24 +360,47 @@ type ClientMock struct {
 			// Ref is the ref argument value.
 			Ref string
 		}
+		// Upstream holds details about calls to the Upstream method.
+		Upstream []struct {
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// WorktreeStatus holds details about calls to the WorktreeStatus method.
+		WorktreeStatus []struct {
+			// WorktreePath is the worktreePath argument value.
+			WorktreePath string
+		}
 	}
 	lockAddWorktree           sync.RWMutex
 	lockAddWorktreeNewBranch  sync.RWMutex
+	lockAheadBehind           sync.RWMutex
 	lockBranchExists          sync.RWMutex
+	lockBranchTip             sync.RWMutex
+	lockConfigGet             sync.RWMutex
+	lockConfigGetBool         sync.RWMutex
+	lockConfigSet             sync.RWMutex
 	lockCurrentBranch         sync.RWMutex
 	lockDeleteBranch          sync.RWMutex
 	lockDeleteBranchFrom      sync.RWMutex
 	lockGitCommonDir          sync.RWMutex
+	lockGitPath               sync.RWMutex
 	lockHasUncommittedChanges sync.RWMutex
 	lockIsMerged              sync.RWMutex
 	lockListBranches          sync.RWMutex
+	lockListStashes           sync.RWMutex
 	lockListWorktrees         sync.RWMutex
+	lockMoveWorktree          sync.RWMutex
+	lockOperationInProgress   sync.RWMutex
+	lockRecentCommits         sync.RWMutex
 	lockRemoteGetURL          sync.RWMutex
 	lockRemoveWorktree        sync.RWMutex
 	lockRenameBranch          sync.RWMutex
 	lockRepairWorktrees       sync.RWMutex
+	lockShowToplevel          sync.RWMutex
 	lockSwitchBranch          sync.RWMutex
 	lockSymbolicRef           sync.RWMutex
+	lockUpstream              sync.RWMutex
+	lockWorktreeStatus        sync.RWMutex
 }
 
 // AddWorktree calls AddWorktreeFunc.
@@ -315,6 +479,42 @@ func (mock *ClientMock) AddWorktreeNewBranchCalls() []struct {
 	return calls
 }
 
+// AheadBehind calls AheadBehindFunc.
+func (mock *ClientMock) AheadBehind(branch string, upstream string) (int, int, error) {
+	if mock.AheadBehindFunc == nil {
+		panic("ClientMock.AheadBehindFunc: method is nil but Client.AheadBehind was just called")
+	}
+	callInfo := struct {
+		Branch   string
+		Upstream string
+	}{
+		Branch:   branch,
+		Upstream: upstream,
+	}
+	mock.lockAheadBehind.Lock()
+	mock.calls.AheadBehind = append(mock.calls.AheadBehind, callInfo)
+	mock.lockAheadBehind.Unlock()
+	return mock.AheadBehindFunc(branch, upstream)
+}
+
+// AheadBehindCalls gets all the calls that were made to AheadBehind.
+// Check the length with:
+//
+//	len(mockedClient.AheadBehindCalls())
+func (mock *ClientMock) AheadBehindCalls() []struct {
+	Branch   string
+	Upstream string
+} {
+	var calls []struct {
+		Branch   string
+		Upstream string
+	}
+	mock.lockAheadBehind.RLock()
+	calls = mock.calls.AheadBehind
+	mock.lockAheadBehind.RUnlock()
+	return calls
+}
+
 // BranchExists calls BranchExistsFunc.
 func (mock *ClientMock) BranchExists(name string) (bool, error) {
 	if mock.BranchExistsFunc == nil {
@@ -347,6 +547,138 @@ func (mock *ClientMock) BranchExistsCalls() []struct {
 	return calls
 }
 
+// BranchTip calls BranchTipFunc.
+func (mock *ClientMock) BranchTip(branch string) (string, string, error) {
+	if mock.BranchTipFunc == nil {
+		panic("ClientMock.BranchTipFunc: method is nil but Client.BranchTip was just called")
+	}
+	callInfo := struct {
+		Branch string
+	}{
+		Branch: branch,
+	}
+	mock.lockBranchTip.Lock()
+	mock.calls.BranchTip = append(mock.calls.BranchTip, callInfo)
+	mock.lockBranchTip.Unlock()
+	return mock.BranchTipFunc(branch)
+}
+
+// BranchTipCalls gets all the calls that were made to BranchTip.
+// Check the length with:
+//
+//	len(mockedClient.BranchTipCalls())
+func (mock *ClientMock) BranchTipCalls() []struct {
+	Branch string
+} {
+	var calls []struct {
+		Branch string
+	}
+	mock.lockBranchTip.RLock()
+	calls = mock.calls.BranchTip
+	mock.lockBranchTip.RUnlock()
+	return calls
+}
+
+// ConfigGet calls ConfigGetFunc.
+func (mock *ClientMock) ConfigGet(key string) (string, error) {
+	if mock.ConfigGetFunc == nil {
+		panic("ClientMock.ConfigGetFunc: method is nil but Client.ConfigGet was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockConfigGet.Lock()
+	mock.calls.ConfigGet = append(mock.calls.ConfigGet, callInfo)
+	mock.lockConfigGet.Unlock()
+	return mock.ConfigGetFunc(key)
+}
+
+// ConfigGetCalls gets all the calls that were made to ConfigGet.
+// Check the length with:
+//
+//	len(mockedClient.ConfigGetCalls())
+func (mock *ClientMock) ConfigGetCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockConfigGet.RLock()
+	calls = mock.calls.ConfigGet
+	mock.lockConfigGet.RUnlock()
+	return calls
+}
+
+// ConfigGetBool calls ConfigGetBoolFunc.
+func (mock *ClientMock) ConfigGetBool(key string) (bool, error) {
+	if mock.ConfigGetBoolFunc == nil {
+		panic("ClientMock.ConfigGetBoolFunc: method is nil but Client.ConfigGetBool was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockConfigGetBool.Lock()
+	mock.calls.ConfigGetBool = append(mock.calls.ConfigGetBool, callInfo)
+	mock.lockConfigGetBool.Unlock()
+	return mock.ConfigGetBoolFunc(key)
+}
+
+// ConfigGetBoolCalls gets all the calls that were made to ConfigGetBool.
+// Check the length with:
+//
+//	len(mockedClient.ConfigGetBoolCalls())
+func (mock *ClientMock) ConfigGetBoolCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockConfigGetBool.RLock()
+	calls = mock.calls.ConfigGetBool
+	mock.lockConfigGetBool.RUnlock()
+	return calls
+}
+
+// ConfigSet calls ConfigSetFunc.
+func (mock *ClientMock) ConfigSet(key string, value string) error {
+	if mock.ConfigSetFunc == nil {
+		panic("ClientMock.ConfigSetFunc: method is nil but Client.ConfigSet was just called")
+	}
+	callInfo := struct {
+		Key   string
+		Value string
+	}{
+		Key:   key,
+		Value: value,
+	}
+	mock.lockConfigSet.Lock()
+	mock.calls.ConfigSet = append(mock.calls.ConfigSet, callInfo)
+	mock.lockConfigSet.Unlock()
+	return mock.ConfigSetFunc(key, value)
+}
+
+// ConfigSetCalls gets all the calls that were made to ConfigSet.
+// Check the length with:
+//
+//	len(mockedClient.ConfigSetCalls())
+func (mock *ClientMock) ConfigSetCalls() []struct {
+	Key   string
+	Value string
+} {
+	var calls []struct {
+		Key   string
+		Value string
+	}
+	mock.lockConfigSet.RLock()
+	calls = mock.calls.ConfigSet
+	mock.lockConfigSet.RUnlock()
+	return calls
+}
+
 // CurrentBranch calls CurrentBranchFunc.
 func (mock *ClientMock) CurrentBranch(dir string) (string, error) {
 	if mock.CurrentBranchFunc == nil {
@@ -474,6 +806,38 @@ func (mock *ClientMock) GitCommonDirCalls() []struct {
 	return calls
 }
 
+// GitPath calls GitPathFunc.
+func (mock *ClientMock) GitPath(name string) (string, error) {
+	if mock.GitPathFunc == nil {
+		panic("ClientMock.GitPathFunc: method is nil but Client.GitPath was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGitPath.Lock()
+	mock.calls.GitPath = append(mock.calls.GitPath, callInfo)
+	mock.lockGitPath.Unlock()
+	return mock.GitPathFunc(name)
+}
+
+// GitPathCalls gets all the calls that were made to GitPath.
+// Check the length with:
+//
+//	len(mockedClient.GitPathCalls())
+func (mock *ClientMock) GitPathCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGitPath.RLock()
+	calls = mock.calls.GitPath
+	mock.lockGitPath.RUnlock()
+	return calls
+}
+
 // HasUncommittedChanges calls HasUncommittedChangesFunc.
 func (mock *ClientMock) HasUncommittedChanges(worktreePath string) (bool, error) {
 	if mock.HasUncommittedChangesFunc == nil {
@@ -569,6 +933,33 @@ func (mock *ClientMock) ListBranchesCalls() []struct {
 	return calls
 }
 
+// ListStashes calls ListStashesFunc.
+func (mock *ClientMock) ListStashes() ([]string, error) {
+	if mock.ListStashesFunc == nil {
+		panic("ClientMock.ListStashesFunc: method is nil but Client.ListStashes was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListStashes.Lock()
+	mock.calls.ListStashes = append(mock.calls.ListStashes, callInfo)
+	mock.lockListStashes.Unlock()
+	return mock.ListStashesFunc()
+}
+
+// ListStashesCalls gets all the calls that were made to ListStashes.
+// Check the length with:
+//
+//	len(mockedClient.ListStashesCalls())
+func (mock *ClientMock) ListStashesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListStashes.RLock()
+	calls = mock.calls.ListStashes
+	mock.lockListStashes.RUnlock()
+	return calls
+}
+
 // ListWorktrees calls ListWorktreesFunc.
 func (mock *ClientMock) ListWorktrees() ([]Worktree, error) {
 	if mock.ListWorktreesFunc == nil {
@@ -596,6 +987,110 @@ func (mock *ClientMock) ListWorktreesCalls() []struct {
 	return calls
 }
 
+// MoveWorktree calls MoveWorktreeFunc.
+func (mock *ClientMock) MoveWorktree(src string, dst string) error {
+	if mock.MoveWorktreeFunc == nil {
+		panic("ClientMock.MoveWorktreeFunc: method is nil but Client.MoveWorktree was just called")
+	}
+	callInfo := struct {
+		Src string
+		Dst string
+	}{
+		Src: src,
+		Dst: dst,
+	}
+	mock.lockMoveWorktree.Lock()
+	mock.calls.MoveWorktree = append(mock.calls.MoveWorktree, callInfo)
+	mock.lockMoveWorktree.Unlock()
+	return mock.MoveWorktreeFunc(src, dst)
+}
+
+// MoveWorktreeCalls gets all the calls that were made to MoveWorktree.
+// Check the length with:
+//
+//	len(mockedClient.MoveWorktreeCalls())
+func (mock *ClientMock) MoveWorktreeCalls() []struct {
+	Src string
+	Dst string
+} {
+	var calls []struct {
+		Src string
+		Dst string
+	}
+	mock.lockMoveWorktree.RLock()
+	calls = mock.calls.MoveWorktree
+	mock.lockMoveWorktree.RUnlock()
+	return calls
+}
+
+// OperationInProgress calls OperationInProgressFunc.
+func (mock *ClientMock) OperationInProgress(dir string) (string, error) {
+	if mock.OperationInProgressFunc == nil {
+		panic("ClientMock.OperationInProgressFunc: method is nil but Client.OperationInProgress was just called")
+	}
+	callInfo := struct {
+		Dir string
+	}{
+		Dir: dir,
+	}
+	mock.lockOperationInProgress.Lock()
+	mock.calls.OperationInProgress = append(mock.calls.OperationInProgress, callInfo)
+	mock.lockOperationInProgress.Unlock()
+	return mock.OperationInProgressFunc(dir)
+}
+
+// OperationInProgressCalls gets all the calls that were made to OperationInProgress.
+// Check the length with:
+//
+//	len(mockedClient.OperationInProgressCalls())
+func (mock *ClientMock) OperationInProgressCalls() []struct {
+	Dir string
+} {
+	var calls []struct {
+		Dir string
+	}
+	mock.lockOperationInProgress.RLock()
+	calls = mock.calls.OperationInProgress
+	mock.lockOperationInProgress.RUnlock()
+	return calls
+}
+
+// RecentCommits calls RecentCommitsFunc.
+func (mock *ClientMock) RecentCommits(branch string, n int) ([]string, error) {
+	if mock.RecentCommitsFunc == nil {
+		panic("ClientMock.RecentCommitsFunc: method is nil but Client.RecentCommits was just called")
+	}
+	callInfo := struct {
+		Branch string
+		N      int
+	}{
+		Branch: branch,
+		N:      n,
+	}
+	mock.lockRecentCommits.Lock()
+	mock.calls.RecentCommits = append(mock.calls.RecentCommits, callInfo)
+	mock.lockRecentCommits.Unlock()
+	return mock.RecentCommitsFunc(branch, n)
+}
+
+// RecentCommitsCalls gets all the calls that were made to RecentCommits.
+// Check the length with:
+//
+//	len(mockedClient.RecentCommitsCalls())
+func (mock *ClientMock) RecentCommitsCalls() []struct {
+	Branch string
+	N      int
+} {
+	var calls []struct {
+		Branch string
+		N      int
+	}
+	mock.lockRecentCommits.RLock()
+	calls = mock.calls.RecentCommits
+	mock.lockRecentCommits.RUnlock()
+	return calls
+}
+
 // RemoteGetURL calls RemoteGetURLFunc.
 func (mock *ClientMock) RemoteGetURL(remote string) (string, error) {
 	if mock.RemoteGetURLFunc == nil {
@@ -697,16 +1192,19 @@ func (mock *ClientMock) RenameBranchCalls() []struct {
 }
 
 // RepairWorktrees calls RepairWorktreesFunc.
-func (mock *ClientMock) RepairWorktrees() error {
+func (mock *ClientMock) RepairWorktrees(paths ...string) error {
 	if mock.RepairWorktreesFunc == nil {
 		panic("ClientMock.RepairWorktreesFunc: method is nil but Client.RepairWorktrees was just called")
 	}
 	callInfo := struct {
-	}{}
+		Paths []string
+	}{
+		Paths: paths,
+	}
 	mock.lockRepairWorktrees.Lock()
 	mock.calls.RepairWorktrees = append(mock.calls.RepairWorktrees, callInfo)
 	mock.lockRepairWorktrees.Unlock()
-	return mock.RepairWorktreesFunc()
+	return mock.RepairWorktreesFunc(paths...)
 }
 
 // RepairWorktreesCalls gets all the calls that were made to RepairWorktrees.
@@ -714,8 +1212,10 @@ func (mock *ClientMock) RepairWorktrees() error {
 //
 //	len(mockedClient.RepairWorktreesCalls())
 func (mock *ClientMock) RepairWorktreesCalls() []struct {
+	Paths []string
 } {
 	var calls []struct {
+		Paths []string
 	}
 	mock.lockRepairWorktrees.RLock()
 	calls = mock.calls.RepairWorktrees
@@ -723,6 +1223,38 @@ func (mock *ClientMock) RepairWorktreesCalls() []struct {
 	return calls
 }
 
+// ShowToplevel calls ShowToplevelFunc.
+func (mock *ClientMock) ShowToplevel(dir string) (string, error) {
+	if mock.ShowToplevelFunc == nil {
+		panic("ClientMock.ShowToplevelFunc: method is nil but Client.ShowToplevel was just called")
+	}
+	callInfo := struct {
+		Dir string
+	}{
+		Dir: dir,
+	}
+	mock.lockShowToplevel.Lock()
+	mock.calls.ShowToplevel = append(mock.calls.ShowToplevel, callInfo)
+	mock.lockShowToplevel.Unlock()
+	return mock.ShowToplevelFunc(dir)
+}
+
+// ShowToplevelCalls gets all the calls that were made to ShowToplevel.
+// Check the length with:
+//
+//	len(mockedClient.ShowToplevelCalls())
+func (mock *ClientMock) ShowToplevelCalls() []struct {
+	Dir string
+} {
+	var calls []struct {
+		Dir string
+	}
+	mock.lockShowToplevel.RLock()
+	calls = mock.calls.ShowToplevel
+	mock.lockShowToplevel.RUnlock()
+	return calls
+}
+
 // SwitchBranch calls SwitchBranchFunc.
 func (mock *ClientMock) SwitchBranch(dir string, branch string) error {
 	if mock.SwitchBranchFunc == nil {
@@ -790,3 +1322,67 @@ func (mock *ClientMock) SymbolicRefCalls() []struct {
 	mock.lockSymbolicRef.RUnlock()
 	return calls
 }
+
+// Upstream calls UpstreamFunc.
+func (mock *ClientMock) Upstream(branch string) (string, error) {
+	if mock.UpstreamFunc == nil {
+		panic("ClientMock.UpstreamFunc: method is nil but Client.Upstream was just called")
+	}
+	callInfo := struct {
+		Branch string
+	}{
+		Branch: branch,
+	}
+	mock.lockUpstream.Lock()
+	mock.calls.Upstream = append(mock.calls.Upstream, callInfo)
+	mock.lockUpstream.Unlock()
+	return mock.UpstreamFunc(branch)
+}
+
+// UpstreamCalls gets all the calls that were made to Upstream.
+// Check the length with:
+//
+//	len(mockedClient.UpstreamCalls())
+func (mock *ClientMock) UpstreamCalls() []struct {
+	Branch string
+} {
+	var calls []struct {
+		Branch string
+	}
+	mock.lockUpstream.RLock()
+	calls = mock.calls.Upstream
+	mock.lockUpstream.RUnlock()
+	return calls
+}
+
+// WorktreeStatus calls WorktreeStatusFunc.
+func (mock *ClientMock) WorktreeStatus(worktreePath string) (WorktreeStatus, error) {
+	if mock.WorktreeStatusFunc == nil {
+		panic("ClientMock.WorktreeStatusFunc: method is nil but Client.WorktreeStatus was just called")
+	}
+	callInfo := struct {
+		WorktreePath string
+	}{
+		WorktreePath: worktreePath,
+	}
+	mock.lockWorktreeStatus.Lock()
+	mock.calls.WorktreeStatus = append(mock.calls.WorktreeStatus, callInfo)
+	mock.lockWorktreeStatus.Unlock()
+	return mock.WorktreeStatusFunc(worktreePath)
+}
+
+// WorktreeStatusCalls gets all the calls that were made to WorktreeStatus.
+// Check the length with:
+//
+//	len(mockedClient.WorktreeStatusCalls())
+func (mock *ClientMock) WorktreeStatusCalls() []struct {
+	WorktreePath string
+} {
+	var calls []struct {
+		WorktreePath string
+	}
+	mock.lockWorktreeStatus.RLock()
+	calls = mock.calls.WorktreeStatus
+	mock.lockWorktreeStatus.RUnlock()
+	return calls
+}
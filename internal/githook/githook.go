@@ -0,0 +1,162 @@
+package githook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	beginMarker = "# >>> hashi >>>"
+	endMarker   = "# <<< hashi <<<"
+	shebang     = "#!/bin/sh"
+)
+
+// shells lists the interpreters that can run hashi's block, which is POSIX sh.
+var shells = map[string]bool{"sh": true, "bash": true, "dash": true, "ash": true, "ksh": true, "mksh": true, "zsh": true}
+
+// Install adds body to the hook script at path between hashi's markers.
+// The block is appended so that existing hooks (including hook managers) run first.
+// A previously installed block is replaced; other content is preserved.
+// The file is created (with a /bin/sh shebang) if it does not exist, and is
+// always left executable. An existing hook that is not a shell script, or
+// that ends with exec or exit so that an appended block would never run, is
+// refused: such a hook has to call hashi itself.
+func Install(path, body string) error {
+	content, err := readHook(path)
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		content = shebang + "\n"
+	}
+	content, _ = removeBlock(content)
+	if interp := interpreter(content); interp != "" && !shells[interp] {
+		return fmt.Errorf("hook %s is a %s script, not a shell script; call hashi from it instead", path, interp)
+	}
+	if cmd := lastCommand(content); cmd == "exec" || cmd == "exit" {
+		return fmt.Errorf("hook %s ends with '%s', so a block appended to it would never run; call hashi from it before that line instead", path, cmd)
+	}
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += beginMarker + "\n" + strings.TrimRight(body, "\n") + "\n" + endMarker + "\n"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		return fmt.Errorf("writing hook %s: %w", path, err)
+	}
+	// WriteFile keeps the mode of existing files; make sure the hook can run.
+	return os.Chmod(path, 0755) //nolint:gosec // hooks must be executable
+}
+
+// Uninstall removes hashi's block from the hook script at path.
+// The file is deleted if nothing but a shebang remains.
+// Reports whether a block was found.
+func Uninstall(path string) (bool, error) {
+	content, err := readHook(path)
+	if err != nil {
+		return false, err
+	}
+	rest, found := removeBlock(content)
+	if !found {
+		return false, nil
+	}
+	if strings.TrimSpace(rest) == "" || strings.TrimSpace(rest) == shebang {
+		return true, os.Remove(path)
+	}
+	return true, os.WriteFile(path, []byte(rest), 0755)
+}
+
+// IsInstalled reports whether the hook script at path contains hashi's block.
+func IsInstalled(path string) (bool, error) {
+	content, err := readHook(path)
+	if err != nil {
+		return false, err
+	}
+	_, found := removeBlock(content)
+	return found, nil
+}
+
+// readHook returns the content of the hook at path, or "" if it does not exist.
+func readHook(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading hook %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// interpreter returns the base name of the program in the script's shebang,
+// looking through env, or "" if there is no shebang: git runs such hooks
+// with sh.
+func interpreter(content string) string {
+	line, _, _ := strings.Cut(content, "\n")
+	rest, ok := strings.CutPrefix(line, "#!")
+	if !ok {
+		return ""
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return ""
+	}
+	prog := filepath.Base(fields[0])
+	if prog == "env" {
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") {
+				return filepath.Base(f)
+			}
+		}
+	}
+	return prog
+}
+
+// lastCommand returns "exec" or "exit" if the script's last command is one
+// that ends it, or "" otherwise. An exec with only redirections does not end
+// the script.
+func lastCommand(content string) string {
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimSuffix(line, ";"))
+		switch fields[0] {
+		case "exit":
+			return "exit"
+		case "exec":
+			for _, f := range fields[1:] {
+				if !strings.ContainsAny(f, "<>") {
+					return "exec"
+				}
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// removeBlock returns content without hashi's marked block and whether one was present.
+func removeBlock(content string) (string, bool) {
+	start := strings.Index(content, beginMarker)
+	if start < 0 {
+		return content, false
+	}
+	end := strings.Index(content[start:], endMarker)
+	if end < 0 {
+		return content, false
+	}
+	end += start + len(endMarker)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:], true
+}
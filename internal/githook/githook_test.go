@@ -0,0 +1,145 @@
+package githook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestInstall(t *testing.T) {
+	t.Run("creates a new executable hook", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hooks", "post-checkout")
+		require.NoError(t, Install(path, "echo hi"))
+
+		assert.Equal(t, "#!/bin/sh\n# >>> hashi >>>\necho hi\n# <<< hashi <<<\n", readFile(t, path))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	})
+
+	t.Run("appends to an existing hook", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/bash\nlefthook run post-checkout"), 0644))
+		require.NoError(t, Install(path, "echo hi"))
+
+		assert.Equal(t, "#!/bin/bash\nlefthook run post-checkout\n# >>> hashi >>>\necho hi\n# <<< hashi <<<\n", readFile(t, path))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	})
+
+	t.Run("replaces a previous block", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		require.NoError(t, Install(path, "echo old"))
+		require.NoError(t, Install(path, "echo new"))
+
+		assert.Equal(t, "#!/bin/sh\n# >>> hashi >>>\necho new\n# <<< hashi <<<\n", readFile(t, path))
+	})
+
+	t.Run("accepts sh-compatible interpreters", func(t *testing.T) {
+		for _, head := range []string{"#!/usr/bin/env bash", "#!/bin/zsh", "echo no shebang"} {
+			path := filepath.Join(t.TempDir(), "post-checkout")
+			require.NoError(t, os.WriteFile(path, []byte(head+"\n"), 0755))
+			assert.NoError(t, Install(path, "echo hi"), head)
+		}
+	})
+
+	t.Run("refuses a non-shell hook", func(t *testing.T) {
+		for _, head := range []string{"#!/usr/bin/env python3", "#!/usr/bin/env -S node --no-warnings", "#!/usr/bin/perl"} {
+			path := filepath.Join(t.TempDir(), "post-checkout")
+			original := head + "\nprint('hi')\n"
+			require.NoError(t, os.WriteFile(path, []byte(original), 0755))
+
+			err := Install(path, "echo hi")
+			assert.ErrorContains(t, err, "not a shell script", head)
+			assert.Equal(t, original, readFile(t, path), "the hook is left untouched")
+		}
+	})
+
+	t.Run("refuses a hook that ends with exec or exit", func(t *testing.T) {
+		for script, cmd := range map[string]string{
+			"#!/bin/sh\nexec lefthook run post-checkout \"$@\"\n": "exec",
+			"#!/bin/sh\nrun-checks\nexit 0\n\n# done\n":           "exit",
+			"#!/bin/sh\nexit;\n":                                  "exit",
+		} {
+			path := filepath.Join(t.TempDir(), "post-checkout")
+			require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+
+			err := Install(path, "echo hi")
+			assert.ErrorContains(t, err, "ends with '"+cmd+"'", script)
+			assert.Equal(t, script, readFile(t, path), "the hook is left untouched")
+		}
+	})
+
+	t.Run("accepts a trailing exec with only redirections", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nexec 1>&2\n"), 0755))
+		assert.NoError(t, Install(path, "echo hi"))
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Error(t, Install(dir, "echo hi"))
+	})
+}
+
+func TestUninstall(t *testing.T) {
+	t.Run("removes hook that only contains the block", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		require.NoError(t, Install(path, "echo hi"))
+
+		found, err := Uninstall(path)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("keeps other content", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nother\n"), 0755))
+		require.NoError(t, Install(path, "echo hi"))
+
+		found, err := Uninstall(path)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "#!/bin/sh\nother\n", readFile(t, path))
+	})
+
+	t.Run("no block", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		found, err := Uninstall(path)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("unterminated block is left alone", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "post-checkout")
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n# >>> hashi >>>\necho hi\n"), 0755))
+		found, err := Uninstall(path)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestIsInstalled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post-checkout")
+
+	ok, err := IsInstalled(path)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, Install(path, "echo hi"))
+	ok, err = IsInstalled(path)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+)
+
+// AdoptCandidate describes a worktree created outside hashi that can be brought under management.
+type AdoptCandidate struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path"`
+	Target string `json:"target"`
+}
+
+// FindAdoptable returns the worktrees whose path does not follow hashi's layout.
+// The main worktree, detached worktrees, the default branch, worktrees
+// whose branch no longer exists (orphaned), and branches that already have a
+// window (such as worktrees adopted in place) are excluded.
+func (s *Service) FindAdoptable(ctx context.Context) ([]AdoptCandidate, error) {
+	worktrees, err := s.git.ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+	branches, err := s.git.ListBranches()
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	branchSet := toSet(branches)
+	windowSet := make(map[string]struct{})
+	for _, w := range s.mapping().windows() {
+		windowSet[w.Name] = struct{}{}
+	}
+
+	var candidates []AdoptCandidate
+	for _, wt := range worktrees {
+		if wt.IsMain || wt.Detached || wt.Branch == s.cp.DefaultBranch {
+			continue
+		}
+		if _, ok := branchSet[wt.Branch]; !ok {
+			continue
+		}
+		if _, ok := windowSet[wt.Branch]; ok {
+			continue
+		}
+		target := s.cp.WorktreePath(wt.Branch)
+		if wt.Path == target {
+			continue
+		}
+		candidates = append(candidates, AdoptCandidate{Branch: wt.Branch, Path: wt.Path, Target: target})
+	}
+	return candidates, nil
+}
+
+// AdoptParams holds parameters for the Adopt operation.
+type AdoptParams struct {
+	Branch string
+	// Candidate is the branch's entry from FindAdoptable, if the caller
+	// already has it; Adopt looks the branch up otherwise.
+	Candidate *AdoptCandidate
+	// InPlace leaves the worktree at its current path instead of moving it into worktree_dir.
+	InPlace bool
+	// CopyFiles runs the copy_files hook against the adopted worktree.
+	CopyFiles bool
+}
+
+// Adopt brings an externally created worktree under management.
+// It moves the worktree into worktree_dir (unless InPlace is set) and creates its tmux window.
+// post_new hooks are never run, and the client is not connected to the window.
+func (s *Service) Adopt(ctx context.Context, p AdoptParams) (*OperationResult, error) {
+	if err := ValidateBranchName(p.Branch); err != nil {
+		return nil, err
+	}
+
+	c := p.Candidate
+	if c == nil {
+		candidates, err := s.FindAdoptable(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c = findBy(candidates, func(c AdoptCandidate) string { return c.Branch }, p.Branch)
+		if c == nil {
+			return nil, &NotAdoptableError{Branch: p.Branch}
+		}
+	}
+
+	rb := newRollback(s)
+	defer rb.execute()
+
+	wtPath := c.Path
+	if !p.InPlace {
+		if err := ensureParentDir(c.Target); err != nil {
+			return nil, fmt.Errorf("creating directory: %w", err)
+		}
+		if err := s.git.MoveWorktree(c.Path, c.Target); err != nil {
+			return nil, fmt.Errorf("moving worktree: %w", err)
+		}
+		rb.add("MoveWorktree", func() error { return s.git.MoveWorktree(c.Target, c.Path) })
+		wtPath = c.Target
+	}
+
+	if p.CopyFiles {
+		if err := s.copyFiles(wtPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.ensureTmux(s.mapping().session(p.Branch), p.Branch, wtPath, ""); err != nil {
+		return nil, fmt.Errorf("ensuring tmux: %w", err)
+	}
+
+	rb.disarm()
+	return &OperationResult{Operation: OpAdopt, Branch: p.Branch, WorktreePath: wtPath}, nil
+}
@@ -0,0 +1,215 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func adoptWorktrees() func() ([]git.Worktree, error) {
+	return func() ([]git.Worktree, error) {
+		return []git.Worktree{
+			{Path: "/repo", Branch: "main", IsMain: true},
+			{Path: "/repo/.worktrees/managed", Branch: "managed"},
+			{Path: "/src/repo-feature", Branch: "feature"},
+			{Path: "/src/repo-detached", Detached: true},
+			{Path: "/src/repo-orphan", Branch: "orphan"},
+		}, nil
+	}
+}
+
+func TestFindAdoptable(t *testing.T) {
+	t.Run("returns worktrees outside the layout", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  mockListBranches("main", "managed", "feature"),
+		}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+		got, err := svc.FindAdoptable(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []AdoptCandidate{
+			{Branch: "feature", Path: "/src/repo-feature", Target: "/repo/.worktrees/feature"},
+		}, got)
+	})
+
+	t.Run("skips branches that already have a window", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  mockListBranches("main", "managed", "feature"),
+		}
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "feature"}}, nil
+		}
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		got, err := svc.FindAdoptable(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, got, "a worktree adopted in place is not offered again")
+	})
+
+	t.Run("ListWorktrees error", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, fmt.Errorf("git error") },
+		}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.FindAdoptable(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("ListBranches error", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  func() ([]string, error) { return nil, fmt.Errorf("git error") },
+		}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.FindAdoptable(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestAdopt(t *testing.T) {
+	t.Run("moves worktree and creates window", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		var movedSrc, movedDst string
+		g := &git.ClientMock{
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{
+					{Path: repoRoot, Branch: "main", IsMain: true},
+					{Path: "/src/repo-feature", Branch: "feature"},
+				}, nil
+			},
+			ListBranchesFunc: mockListBranches("main", "feature"),
+			MoveWorktreeFunc: func(src, dst string) error {
+				movedSrc, movedDst = src, dst
+				return nil
+			},
+		}
+		tm := stubTmuxInside()
+		var sessionWindow, sessionDir string
+		tm.NewSessionFunc = func(name, windowName, dir, initCmd string) (string, error) {
+			sessionWindow, sessionDir = windowName, dir
+			assert.Empty(t, initCmd)
+			return "@1", nil
+		}
+
+		cp := CommonParams{RepoRoot: repoRoot, WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+		res, err := svc.Adopt(context.Background(), AdoptParams{Branch: "feature"})
+		require.NoError(t, err)
+
+		target := filepath.Join(repoRoot, ".worktrees", "feature")
+		assert.Equal(t, "/src/repo-feature", movedSrc)
+		assert.Equal(t, target, movedDst)
+		assert.Equal(t, "feature", sessionWindow)
+		assert.Equal(t, target, sessionDir)
+		assert.Equal(t, OpAdopt, res.Operation)
+		assert.Equal(t, target, res.WorktreePath)
+		assert.Empty(t, tm.SwitchClientCalls(), "adopt must not connect")
+	})
+
+	t.Run("in place keeps the worktree path", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  mockListBranches("main", "feature"),
+		}
+		tm := stubTmuxInside()
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		res, err := svc.Adopt(context.Background(), AdoptParams{Branch: "feature", InPlace: true})
+		require.NoError(t, err)
+		assert.Equal(t, "/src/repo-feature", res.WorktreePath)
+		assert.Empty(t, g.MoveWorktreeCalls())
+		require.Len(t, tm.NewSessionCalls(), 1)
+		assert.Equal(t, "/src/repo-feature", tm.NewSessionCalls()[0].Dir)
+	})
+
+	t.Run("uses the given candidate", func(t *testing.T) {
+		g := &git.ClientMock{}
+		tm := stubTmuxInside()
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		c := &AdoptCandidate{Branch: "feature", Path: "/src/repo-feature", Target: "/repo/.worktrees/feature"}
+		res, err := svc.Adopt(context.Background(), AdoptParams{Branch: "feature", Candidate: c, InPlace: true})
+		require.NoError(t, err)
+		assert.Equal(t, "/src/repo-feature", res.WorktreePath)
+		assert.Empty(t, g.ListWorktreesCalls())
+	})
+
+	t.Run("copies files when requested", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		wtPath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("X=1"), 0644))
+		g := &git.ClientMock{
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{{Path: repoRoot, Branch: "main", IsMain: true}, {Path: wtPath, Branch: "feature"}}, nil
+			},
+			ListBranchesFunc: mockListBranches("main", "feature"),
+		}
+		cp := CommonParams{RepoRoot: repoRoot, WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo", CopyFiles: []string{".env"}}
+		svc := newTestSvc(g, stubTmuxInside(), WithCommonParams(cp))
+
+		_, err := svc.Adopt(context.Background(), AdoptParams{Branch: "feature", InPlace: true, CopyFiles: true})
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(wtPath, ".env"))
+	})
+
+	t.Run("errors for branch that is not adoptable", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  mockListBranches("main", "managed", "feature"),
+		}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.Adopt(context.Background(), AdoptParams{Branch: "managed"})
+		var notAdoptable *NotAdoptableError
+		require.ErrorAs(t, err, &notAdoptable)
+		assert.Equal(t, "managed", notAdoptable.Branch)
+	})
+
+	t.Run("invalid branch name", func(t *testing.T) {
+		svc := newTestSvc(&git.ClientMock{}, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.Adopt(context.Background(), AdoptParams{Branch: ""})
+		assert.Error(t, err)
+	})
+
+	t.Run("move error", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  mockListBranches("main", "feature"),
+			MoveWorktreeFunc:  func(src, dst string) error { return fmt.Errorf("move failed") },
+		}
+		cp := defaultCP()
+		cp.RepoRoot = t.TempDir()
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(cp))
+		_, err := svc.Adopt(context.Background(), AdoptParams{Branch: "feature"})
+		assert.ErrorContains(t, err, "moving worktree")
+	})
+
+	t.Run("moves worktree back on tmux failure", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: adoptWorktrees(),
+			ListBranchesFunc:  mockListBranches("main", "feature"),
+			MoveWorktreeFunc:  func(src, dst string) error { return nil },
+		}
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return false, fmt.Errorf("tmux error") }
+		cp := defaultCP()
+		cp.RepoRoot = t.TempDir()
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+
+		_, err := svc.Adopt(context.Background(), AdoptParams{Branch: "feature"})
+		assert.ErrorContains(t, err, "ensuring tmux")
+		calls := g.MoveWorktreeCalls()
+		require.Len(t, calls, 2)
+		assert.Equal(t, "/src/repo-feature", calls[1].Dst)
+	})
+}
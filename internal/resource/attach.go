@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Attach connects the terminal to the branch's window through a session
+// grouped with the branch's session, so that several terminals can show
+// different windows of the same session. An empty branch attaches to the
+// default branch's session without changing its current window. The session
+// must be running, and the branch, if given, must have a window.
+func (s *Service) Attach(ctx context.Context, branch string) error {
+	session := s.mapping().session(s.cp.DefaultBranch)
+	if branch != "" {
+		session = s.mapping().session(branch)
+	}
+	ok, err := s.tmux.HasSession(session)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("tmux session '%s' is not running", session)
+	}
+	if branch != "" && !s.hasWindow(branch) {
+		return fmt.Errorf("'%s' has no window; open it with 'hashi switch %s'", branch, branch)
+	}
+	return s.connectGrouped(session, branch)
+}
+
+// hasWindow reports whether the branch window exists.
+func (s *Service) hasWindow(branch string) bool {
+	for _, w := range s.mapping().windows() {
+		if w.Name == branch {
+			return true
+		}
+	}
+	return false
+}
+
+// connectGrouped connects through a new session grouped with sessionName.
+// A client already in one of its grouped sessions just switches windows
+// there. tmux destroys a grouped session once its client leaves it.
+func (s *Service) connectGrouped(sessionName, windowName string) error {
+	if !s.tmux.IsInsideTmux() {
+		name, err := s.groupedName(sessionName)
+		if err != nil {
+			return err
+		}
+		return s.tmux.AttachGrouped(sessionName, name, windowName)
+	}
+	if cur, err := s.tmux.CurrentSession(); err == nil && isGroupedName(cur, sessionName) {
+		if windowName == "" {
+			return nil
+		}
+		return s.tmux.SwitchClient(cur, windowName)
+	}
+	name, err := s.groupedName(sessionName)
+	if err != nil {
+		return err
+	}
+	return s.tmux.SwitchClientGrouped(sessionName, name, windowName)
+}
+
+// groupedName returns the first free name of the form <session>~<n>. Branch
+// names cannot contain '~', so the name never collides with a branch session.
+func (s *Service) groupedName(session string) (string, error) {
+	for n := 1; ; n++ {
+		name := session + "~" + strconv.Itoa(n)
+		ok, err := s.tmux.HasSession(name)
+		if err != nil {
+			return "", fmt.Errorf("checking session: %w", err)
+		}
+		if !ok {
+			return name, nil
+		}
+	}
+}
+
+// killGrouped kills the sessions grouped with session, which would otherwise
+// keep its windows alive once it is killed.
+func (s *Service) killGrouped(session string) {
+	all, err := s.tmux.ListAllWindows()
+	s.bestEffort("ListAllWindows", err)
+	killed := make(map[string]bool)
+	for _, w := range all {
+		if isGroupedName(w.Session, session) && !killed[w.Session] {
+			killed[w.Session] = true
+			s.bestEffort("KillSession", s.tmux.KillSession(w.Session))
+		}
+	}
+}
+
+// isGroupedName reports whether name is a grouped session of session.
+func isGroupedName(name, session string) bool {
+	n, ok := strings.CutPrefix(name, session+"~")
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(n)
+	return err == nil
+}
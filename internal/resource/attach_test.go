@@ -0,0 +1,179 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestAttach(t *testing.T) {
+	newTmux := func(inside bool) *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) {
+				return name == "org/repo" || name == "org/repo~1", nil
+			},
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+			IsInsideTmuxFunc:        func() bool { return inside },
+			CurrentSessionFunc:      func() (string, error) { return "org/repo", nil },
+			AttachGroupedFunc:       func(session, name, window string) error { return nil },
+			SwitchClientGroupedFunc: func(session, name, window string) error { return nil },
+			SwitchClientFunc:        func(session, window string) error { return nil },
+		}
+	}
+
+	t.Run("outside tmux", func(t *testing.T) {
+		tm := newTmux(false)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		require.NoError(t, svc.Attach(context.Background(), "feature"))
+		require.Len(t, tm.AttachGroupedCalls(), 1)
+		assert.Equal(t, "org/repo", tm.AttachGroupedCalls()[0].Session)
+		assert.Equal(t, "org/repo~2", tm.AttachGroupedCalls()[0].Name, "the first free name")
+		assert.Equal(t, "feature", tm.AttachGroupedCalls()[0].Window)
+	})
+
+	t.Run("without a branch", func(t *testing.T) {
+		tm := newTmux(false)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		require.NoError(t, svc.Attach(context.Background(), ""))
+		require.Len(t, tm.AttachGroupedCalls(), 1)
+		assert.Empty(t, tm.AttachGroupedCalls()[0].Window)
+		assert.Empty(t, tm.ListWindowsCalls())
+	})
+
+	t.Run("inside tmux", func(t *testing.T) {
+		tm := newTmux(true)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		require.NoError(t, svc.Attach(context.Background(), "feature"))
+		require.Len(t, tm.SwitchClientGroupedCalls(), 1)
+		assert.Equal(t, "org/repo~2", tm.SwitchClientGroupedCalls()[0].Name)
+		assert.Equal(t, "feature", tm.SwitchClientGroupedCalls()[0].Window)
+	})
+
+	t.Run("inside a grouped session", func(t *testing.T) {
+		tm := newTmux(true)
+		tm.CurrentSessionFunc = func() (string, error) { return "org/repo~1", nil }
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		require.NoError(t, svc.Attach(context.Background(), "feature"))
+		assert.Empty(t, tm.SwitchClientGroupedCalls())
+		require.Len(t, tm.SwitchClientCalls(), 1)
+		assert.Equal(t, "org/repo~1", tm.SwitchClientCalls()[0].Session)
+		assert.Equal(t, "feature", tm.SwitchClientCalls()[0].Window)
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		tm := newTmux(false)
+		tm.HasSessionFunc = func(name string) (bool, error) { return name == "org/repo/feature", nil }
+		tm.ListAllWindowsFunc = func() ([]tmux.Window, error) {
+			return []tmux.Window{{ID: "@2", Name: "feature", Session: "org/repo/feature"}}, nil
+		}
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(sessionCP()))
+
+		require.NoError(t, svc.Attach(context.Background(), "feature"))
+		require.Len(t, tm.AttachGroupedCalls(), 1)
+		assert.Equal(t, "org/repo/feature", tm.AttachGroupedCalls()[0].Session)
+		assert.Equal(t, "org/repo/feature~1", tm.AttachGroupedCalls()[0].Name)
+	})
+
+	t.Run("session not running", func(t *testing.T) {
+		tm := newTmux(false)
+		tm.HasSessionFunc = func(name string) (bool, error) { return false, nil }
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		err := svc.Attach(context.Background(), "feature")
+		assert.ErrorContains(t, err, "tmux session 'org/repo' is not running")
+		assert.Empty(t, tm.AttachGroupedCalls())
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		tm := newTmux(false)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		err := svc.Attach(context.Background(), "other")
+		assert.ErrorContains(t, err, "'other' has no window; open it with 'hashi switch other'")
+		assert.Empty(t, tm.AttachGroupedCalls())
+	})
+}
+
+func TestSwitch_GroupedSessions(t *testing.T) {
+	tm := stubTmuxInside()
+	tm.HasSessionFunc = func(name string) (bool, error) { return name == "org/repo", nil }
+	tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) { return nil, nil }
+	tm.CurrentSessionFunc = func() (string, error) { return "org/repo", nil }
+	tm.SwitchClientGroupedFunc = func(session, name, window string) error { return nil }
+	batch, queued := newBatchMock(nil)
+	g := &git.ClientMock{
+		BranchExistsFunc: mockBranchExists("main", "feature"),
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feature", Branch: "feature"},
+			}, nil
+		},
+	}
+	cp := defaultCP()
+	cp.GroupedSessions = true
+	svc := newTestSvc(g, batchingTmux{tm, batch}, WithCommonParams(cp))
+
+	_, err := svc.Switch(context.Background(), SwitchParams{Branch: "feature"})
+	require.NoError(t, err)
+	assert.NotContains(t, *queued, "switch-client")
+	require.Len(t, tm.SwitchClientGroupedCalls(), 1)
+	assert.Equal(t, "org/repo", tm.SwitchClientGroupedCalls()[0].Session)
+	assert.Equal(t, "org/repo~1", tm.SwitchClientGroupedCalls()[0].Name)
+	assert.Equal(t, "feature", tm.SwitchClientGroupedCalls()[0].Window)
+}
+
+func TestExecuteRemove_GroupedSessions(t *testing.T) {
+	newTmux := func(current string) *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return name == "org/repo" || name == current, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}}, nil
+			},
+			ListPanesFunc:           func(session, window string) ([]tmux.Pane, error) { return nil, nil },
+			IsInsideTmuxFunc:        func() bool { return true },
+			CurrentSessionFunc:      func() (string, error) { return current, nil },
+			SwitchClientFunc:        func(session, window string) error { return nil },
+			SwitchClientGroupedFunc: func(session, name, window string) error { return nil },
+			KillWindowFunc:          func(session, window string) error { return nil },
+		}
+	}
+	cp := defaultCP()
+	cp.GroupedSessions = true
+	check := RemoveCheck{Branch: "feature", HasWindow: true, IsActive: true}
+
+	t.Run("inside a grouped session", func(t *testing.T) {
+		tm := newTmux("org/repo~1")
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(cp))
+
+		_, err := svc.ExecuteRemove(context.Background(), check)
+		require.NoError(t, err)
+		require.Len(t, tm.SwitchClientCalls(), 1)
+		assert.Equal(t, "org/repo~1", tm.SwitchClientCalls()[0].Session, "the client stays in its grouped session")
+		assert.Equal(t, "main", tm.SwitchClientCalls()[0].Window)
+		assert.Empty(t, tm.SwitchClientGroupedCalls())
+	})
+
+	t.Run("inside the branch session", func(t *testing.T) {
+		tm := newTmux("org/repo")
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(cp))
+
+		_, err := svc.ExecuteRemove(context.Background(), check)
+		require.NoError(t, err)
+		assert.Empty(t, tm.SwitchClientCalls())
+		require.Len(t, tm.SwitchClientGroupedCalls(), 1)
+		assert.Equal(t, "org/repo", tm.SwitchClientGroupedCalls()[0].Session)
+		assert.Equal(t, "org/repo~1", tm.SwitchClientGroupedCalls()[0].Name)
+		assert.Equal(t, "main", tm.SwitchClientGroupedCalls()[0].Window)
+	})
+}
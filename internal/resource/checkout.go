@@ -0,0 +1,76 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CheckoutCheck describes whether a worktree still has the branch hashi maps to it checked out.
+type CheckoutCheck struct {
+	Worktree string
+	// Expected is the branch hashi maps to Worktree, or "" if the worktree is not managed.
+	Expected string
+	// Actual is the branch now checked out ("HEAD" when detached).
+	Actual string
+	// Operation is the rebase, merge or bisect the worktree is in the middle of, if any.
+	Operation string
+}
+
+// Mismatch reports whether a managed worktree has a different branch checked out.
+// A detached HEAD or an operation in progress is not a mismatch: rebase and
+// bisect detach HEAD on purpose and move back when they finish.
+func (c CheckoutCheck) Mismatch() bool {
+	return c.Expected != "" && c.Actual != c.Expected && c.Actual != "HEAD" && c.Operation == ""
+}
+
+// CheckCheckout checks the worktree containing dir against hashi's branch↔worktree mapping.
+// The repository root maps to the default branch; <worktree_dir>/<branch> maps to <branch>.
+func (s *Service) CheckCheckout(ctx context.Context, dir string) (CheckoutCheck, error) {
+	top, err := s.git.ShowToplevel(dir)
+	if err != nil {
+		return CheckoutCheck{}, fmt.Errorf("resolving worktree: %w", err)
+	}
+	check := CheckoutCheck{Worktree: top, Expected: s.expectedBranch(top)}
+	if check.Expected == "" {
+		return check, nil
+	}
+	check.Actual, err = s.git.CurrentBranch(top)
+	if err != nil {
+		return CheckoutCheck{}, fmt.Errorf("checking current branch: %w", err)
+	}
+	if check.Actual == check.Expected || check.Actual == "HEAD" {
+		return check, nil
+	}
+	check.Operation, err = s.git.OperationInProgress(top)
+	if err != nil {
+		return CheckoutCheck{}, fmt.Errorf("checking for an operation in progress: %w", err)
+	}
+	return check, nil
+}
+
+// expectedBranch returns the branch hashi maps to the worktree at path, or "" if it is not managed.
+func (s *Service) expectedBranch(path string) string {
+	if path == s.cp.RepoRoot {
+		return s.cp.DefaultBranch
+	}
+	rel, err := filepath.Rel(filepath.Join(s.cp.RepoRoot, s.cp.WorktreeDir), path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// RestoreCheckout switches the worktree back to the branch hashi maps to it.
+// It does nothing unless c is a mismatch, so that it never switches branches
+// in the middle of a rebase, merge or bisect.
+func (s *Service) RestoreCheckout(ctx context.Context, c CheckoutCheck) error {
+	if !c.Mismatch() {
+		return nil
+	}
+	if err := s.git.SwitchBranch(c.Worktree, c.Expected); err != nil {
+		return fmt.Errorf("switching %s back to %s: %w", c.Worktree, c.Expected, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+)
+
+func TestCheckCheckout(t *testing.T) {
+	tests := []struct {
+		name     string
+		toplevel string
+		current  string
+		op       string
+		expected string
+		mismatch bool
+	}{
+		{name: "managed worktree on its branch", toplevel: "/repo/.worktrees/feat/auth", current: "feat/auth", expected: "feat/auth"},
+		{name: "managed worktree switched away", toplevel: "/repo/.worktrees/feature", current: "other", expected: "feature", mismatch: true},
+		{name: "detached HEAD", toplevel: "/repo/.worktrees/feature", current: "HEAD", expected: "feature"},
+		{name: "rebase in progress", toplevel: "/repo/.worktrees/feature", current: "other", op: "rebase", expected: "feature"},
+		{name: "merge in progress", toplevel: "/repo", current: "feature", op: "merge", expected: "main"},
+		{name: "bisect in progress", toplevel: "/repo/.worktrees/feature", current: "other", op: "bisect", expected: "feature"},
+		{name: "repo root maps to default branch", toplevel: "/repo", current: "feature", expected: "main", mismatch: true},
+		{name: "worktree outside layout", toplevel: "/elsewhere/repo-x", current: "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &git.ClientMock{
+				ShowToplevelFunc:        func(dir string) (string, error) { return tt.toplevel, nil },
+				CurrentBranchFunc:       func(dir string) (string, error) { return tt.current, nil },
+				OperationInProgressFunc: func(dir string) (string, error) { return tt.op, nil },
+			}
+			svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+			check, err := svc.CheckCheckout(context.Background(), tt.toplevel)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, check.Expected)
+			assert.Equal(t, tt.mismatch, check.Mismatch())
+		})
+	}
+
+	t.Run("ShowToplevel error", func(t *testing.T) {
+		g := &git.ClientMock{ShowToplevelFunc: func(dir string) (string, error) { return "", fmt.Errorf("git error") }}
+		_, err := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP())).CheckCheckout(context.Background(), "/x")
+		assert.Error(t, err)
+	})
+
+	t.Run("OperationInProgress error", func(t *testing.T) {
+		g := &git.ClientMock{
+			ShowToplevelFunc:        func(dir string) (string, error) { return "/repo", nil },
+			CurrentBranchFunc:       func(dir string) (string, error) { return "feature", nil },
+			OperationInProgressFunc: func(dir string) (string, error) { return "", fmt.Errorf("git error") },
+		}
+		_, err := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP())).CheckCheckout(context.Background(), "/repo")
+		assert.ErrorContains(t, err, "checking for an operation in progress")
+	})
+
+	t.Run("CurrentBranch error", func(t *testing.T) {
+		g := &git.ClientMock{
+			ShowToplevelFunc:  func(dir string) (string, error) { return "/repo", nil },
+			CurrentBranchFunc: func(dir string) (string, error) { return "", fmt.Errorf("git error") },
+		}
+		_, err := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP())).CheckCheckout(context.Background(), "/repo")
+		assert.Error(t, err)
+	})
+}
+
+func TestRestoreCheckout(t *testing.T) {
+	t.Run("switches back", func(t *testing.T) {
+		g := &git.ClientMock{SwitchBranchFunc: func(dir, branch string) error { return nil }}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		err := svc.RestoreCheckout(context.Background(), CheckoutCheck{Worktree: "/repo/.worktrees/feature", Expected: "feature", Actual: "other"})
+		require.NoError(t, err)
+		require.Len(t, g.SwitchBranchCalls(), 1)
+		assert.Equal(t, "feature", g.SwitchBranchCalls()[0].Branch)
+	})
+
+	t.Run("never switches during an operation", func(t *testing.T) {
+		g := &git.ClientMock{}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		for _, c := range []CheckoutCheck{
+			{Worktree: "/w", Expected: "feature", Actual: "other", Operation: "rebase"},
+			{Worktree: "/w", Expected: "feature", Actual: "HEAD"},
+		} {
+			require.NoError(t, svc.RestoreCheckout(context.Background(), c))
+		}
+		assert.Empty(t, g.SwitchBranchCalls())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		g := &git.ClientMock{SwitchBranchFunc: func(dir, branch string) error { return fmt.Errorf("conflict") }}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		err := svc.RestoreCheckout(context.Background(), CheckoutCheck{Worktree: "/w", Expected: "feature", Actual: "other"})
+		assert.ErrorContains(t, err, "switching /w back to feature")
+	})
+}
@@ -2,6 +2,8 @@ package resource
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/wasabi0522/hashi/internal/git"
 	"github.com/wasabi0522/hashi/internal/tmux"
@@ -30,7 +32,13 @@ func classifyWindowOnlyStatus(name string, branchSet map[string]struct{}) Status
 // It assumes that the main worktree always has a branch (never detached HEAD)
 // and that its branch appears in the branch list. Tmux session/window lookup
 // is best-effort: if the session does not exist, all windows are treated as absent.
+// Extra windows are grouped under their branch.
 func (s *Service) CollectState(ctx context.Context) ([]State, error) {
+	return s.collectState(s.mapping().windows())
+}
+
+// collectState is CollectState for the given branch windows.
+func (s *Service) collectState(windows []tmux.Window) ([]State, error) {
 	worktrees, err := s.git.ListWorktrees()
 	if err != nil {
 		return nil, err
@@ -42,8 +50,18 @@ func (s *Service) CollectState(ctx context.Context) ([]State, error) {
 	}
 	branchSet := toSet(branches)
 
-	windows := s.listWindowsSafe(s.cp.SessionName)
-	winMap := toMap(windows, func(w tmux.Window) string { return w.Name })
+	winMap := make(map[string]tmux.Window)
+	extras := make(map[string][]string)
+	active := make(map[string]bool)
+	for _, w := range windows {
+		branch, extra := splitWindowName(w.Name)
+		if extra == "" {
+			winMap[branch] = w
+		} else {
+			extras[branch] = append(extras[branch], extra)
+		}
+		active[branch] = active[branch] || w.Active
+	}
 
 	seen := make(map[string]struct{})
 	states := make([]State, 0, len(worktrees))
@@ -56,13 +74,14 @@ func (s *Service) CollectState(ctx context.Context) ([]State, error) {
 		name := wt.Branch
 		seen[name] = struct{}{}
 
-		win, hasWin := winMap[name]
+		_, hasWin := winMap[name]
 
 		states = append(states, State{
 			Branch:    name,
 			Worktree:  wt.Path,
 			Window:    hasWin,
-			Active:    hasWin && win.Active,
+			Windows:   extras[name],
+			Active:    active[name],
 			IsDefault: name == s.cp.DefaultBranch,
 			Status:    classifyWorktreeStatus(wt, branchSet),
 		})
@@ -70,17 +89,68 @@ func (s *Service) CollectState(ctx context.Context) ([]State, error) {
 
 	// Process windows without worktrees
 	for _, w := range windows {
-		if _, ok := seen[w.Name]; ok {
+		name, _ := splitWindowName(w.Name)
+		if _, ok := seen[name]; ok {
 			continue
 		}
+		seen[name] = struct{}{}
 
+		_, hasWin := winMap[name]
 		states = append(states, State{
-			Branch: w.Name,
-			Window: true,
-			Active: w.Active,
-			Status: classifyWindowOnlyStatus(w.Name, branchSet),
+			Branch:  name,
+			Window:  hasWin,
+			Windows: extras[name],
+			Active:  active[name],
+			Status:  classifyWindowOnlyStatus(name, branchSet),
 		})
 	}
 
 	return states, nil
 }
+
+// Candidates returns CollectState followed by the local branches that have
+// neither a worktree nor a window, for choosing a branch to work on.
+func (s *Service) Candidates(ctx context.Context) ([]State, error) {
+	states, err := s.CollectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := s.git.ListBranches()
+	if err != nil {
+		return nil, err
+	}
+	seen := toSet(branchesOf(states))
+	for _, b := range branches {
+		if _, ok := seen[b]; ok {
+			continue
+		}
+		states = append(states, State{Branch: b, IsDefault: b == s.cp.DefaultBranch, Status: StatusOK})
+	}
+	return states, nil
+}
+
+// branchesOf returns the branch names of states.
+func branchesOf(states []State) []string {
+	names := make([]string, len(states))
+	for i, st := range states {
+		names[i] = st.Branch
+	}
+	return names
+}
+
+// CurrentBranch returns the branch of the current tmux window, resolved from
+// the window's name the same way as CollectState. An extra window resolves to
+// its branch.
+func (s *Service) CurrentBranch(ctx context.Context) (string, error) {
+	id, err := s.tmux.CurrentWindow()
+	if err != nil {
+		return "", fmt.Errorf("resolving current window: %w", err)
+	}
+	for _, w := range s.mapping().windows() {
+		if w.ID == id {
+			branch, _ := splitWindowName(w.Name)
+			return branch, nil
+		}
+	}
+	return "", errors.New("the current window is not managed by hashi")
+}
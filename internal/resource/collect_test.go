@@ -202,6 +202,47 @@ func TestCollectState(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("groups extra windows under their branch", func(t *testing.T) {
+		svc := newTestSvc(
+			&git.ClientMock{
+				ListWorktreesFunc: func() ([]git.Worktree, error) {
+					return []git.Worktree{
+						{Path: "/repo", Branch: "main", IsMain: true},
+						{Path: "/repo/.worktrees/feature", Branch: "feature"},
+					}, nil
+				},
+				ListBranchesFunc: mockListBranches("main", "feature", "gone"),
+			},
+			&tmux.ClientMock{
+				HasSessionFunc: func(name string) (bool, error) { return true, nil },
+				ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+					return []tmux.Window{
+						{Name: "main"},
+						{Name: "feature"},
+						{Name: "feature:server", Active: true},
+						{Name: "feature:logs"},
+						{Name: "gone:server"},
+					}, nil
+				},
+			},
+			WithCommonParams(CommonParams{SessionName: "org/repo"}),
+		)
+
+		states, err := svc.CollectState(context.Background())
+		require.NoError(t, err)
+		require.Len(t, states, 3)
+
+		assert.Equal(t, "feature", states[1].Branch)
+		assert.True(t, states[1].Window)
+		assert.Equal(t, []string{"server", "logs"}, states[1].Windows)
+		assert.True(t, states[1].Active, "active extra window makes the branch active")
+
+		assert.Equal(t, "gone", states[2].Branch)
+		assert.False(t, states[2].Window)
+		assert.Equal(t, []string{"server"}, states[2].Windows)
+		assert.Equal(t, StatusWorktreeMissing, states[2].Status)
+	})
+
 	t.Run("detached HEAD worktree skipped", func(t *testing.T) {
 		svc := newTestSvc(
 			&git.ClientMock{
@@ -227,3 +268,65 @@ func TestCollectState(t *testing.T) {
 		assert.Equal(t, "main", states[0].Branch)
 	})
 }
+
+func TestCandidates(t *testing.T) {
+	svc := newTestSvc(
+		&git.ClientMock{
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{
+					{Path: "/repo", Branch: "main", IsMain: true},
+					{Path: "/repo/.worktrees/feature", Branch: "feature"},
+				}, nil
+			},
+			ListBranchesFunc: mockListBranches("main", "feature", "idle"),
+		},
+		&tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return false, nil },
+		},
+		WithCommonParams(defaultCP()),
+	)
+
+	states, err := svc.Candidates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	assert.Equal(t, []string{"main", "feature", "idle"}, branchesOf(states))
+	assert.Equal(t, State{Branch: "idle", Status: StatusOK}, states[2])
+}
+
+func TestCurrentBranch(t *testing.T) {
+	newSvc := func(current string, err error) *Service {
+		return newTestSvc(&git.ClientMock{}, &tmux.ClientMock{
+			CurrentWindowFunc: func() (string, error) { return current, err },
+			HasSessionFunc:    func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{
+					{ID: "@1", Name: "main"},
+					{ID: "@2", Name: "feature"},
+					{ID: "@3", Name: "feature:server"},
+				}, nil
+			},
+		}, WithCommonParams(defaultCP()))
+	}
+
+	t.Run("branch window", func(t *testing.T) {
+		branch, err := newSvc("@2", nil).CurrentBranch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "feature", branch)
+	})
+
+	t.Run("extra window", func(t *testing.T) {
+		branch, err := newSvc("@3", nil).CurrentBranch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "feature", branch)
+	})
+
+	t.Run("unmanaged window", func(t *testing.T) {
+		_, err := newSvc("@9", nil).CurrentBranch(context.Background())
+		assert.ErrorContains(t, err, "not managed by hashi")
+	})
+
+	t.Run("outside tmux", func(t *testing.T) {
+		_, err := newSvc("", fmt.Errorf("not inside tmux")).CurrentBranch(context.Background())
+		assert.ErrorContains(t, err, "not inside tmux")
+	})
+}
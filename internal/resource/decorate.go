@@ -0,0 +1,89 @@
+package resource
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// Decorate sets the OptionDirty, OptionAhead, and OptionStatus window options
+// on every managed window, extra windows included, so that window-status-format
+// can render the state of each branch. With branches, only the windows of
+// those branches are decorated; the name of an extra window selects its
+// branch. The options are set in one batch.
+// Git queries are best-effort: a failing query leaves its option at "0".
+func (s *Service) Decorate(ctx context.Context, branches ...string) error {
+	m := s.mapping()
+	windows := m.windows()
+	if len(branches) > 0 {
+		selected := make(map[string]bool, len(branches))
+		for _, b := range branches {
+			branch, _ := splitWindowName(b)
+			selected[branch] = true
+		}
+		windows = slices.DeleteFunc(windows, func(w tmux.Window) bool {
+			branch, _ := splitWindowName(w.Name)
+			return !selected[branch]
+		})
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	states, err := s.collectState(windows)
+	if err != nil {
+		return err
+	}
+	byBranch := toMap(states, func(st State) string { return st.Branch })
+	var windowed []State
+	seen := make(map[string]bool)
+	for _, w := range windows {
+		if branch, _ := splitWindowName(w.Name); !seen[branch] {
+			seen[branch] = true
+			windowed = append(windowed, byBranch[branch])
+		}
+	}
+	dirty := s.dirtyWorktrees(windowed)
+
+	b := tmux.NewBatch(s.tmux)
+	done := make(map[string]decoration, len(states))
+	for _, w := range windows {
+		branch, _ := splitWindowName(w.Name)
+		d, ok := done[branch]
+		if !ok {
+			st := byBranch[branch]
+			d = s.decorate(st, dirty[st.Worktree])
+			done[branch] = d
+		}
+		session := m.session(branch)
+		b.SetWindowOption(session, w.ID, tmux.OptionDirty, d.dirty)
+		b.SetWindowOption(session, w.ID, tmux.OptionAhead, d.ahead)
+		b.SetWindowOption(session, w.ID, tmux.OptionStatus, d.status)
+	}
+	return b.Run()
+}
+
+// decoration holds the values of OptionDirty, OptionAhead, and OptionStatus.
+type decoration struct {
+	dirty, ahead, status string
+}
+
+// decorate returns the decoration of the branch described by st, whose
+// worktree has uncommitted changes if dirty is set.
+func (s *Service) decorate(st State, dirty bool) decoration {
+	d := "0"
+	if dirty {
+		d = "1"
+	}
+	ahead := 0
+	if st.Status != StatusOrphanedWindow && st.Status != StatusOrphanedWorktree {
+		upstream, err := s.git.Upstream(st.Branch)
+		s.bestEffort("Upstream", err)
+		if upstream != "" {
+			ahead, _, err = s.git.AheadBehind(st.Branch, upstream)
+			s.bestEffort("AheadBehind", err)
+		}
+	}
+	return decoration{dirty: d, ahead: strconv.Itoa(ahead), status: st.Status.String()}
+}
@@ -0,0 +1,124 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestDecorate(t *testing.T) {
+	decorateTmux := func(windows ...tmux.Window) (batchingTmux, *[]string) {
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) { return windows, nil }
+		batch, _ := newBatchMock(nil)
+		var options []string
+		batch.SetWindowOptionFunc = func(session, window, key, value string) {
+			options = append(options, fmt.Sprintf("%s %s %s=%s", session, window, key, value))
+		}
+		return batchingTmux{tm, batch}, &options
+	}
+
+	t.Run("sets options on every window of a branch", func(t *testing.T) {
+		tm, options := decorateTmux(
+			tmux.Window{ID: "@1", Name: "main"},
+			tmux.Window{ID: "@2", Name: "feature"},
+			tmux.Window{ID: "@3", Name: "feature:server"},
+			tmux.Window{ID: "@4", Name: "stale"},
+		)
+		g := summaryGitMock()
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		require.NoError(t, svc.Decorate(context.Background()))
+		assert.Equal(t, []string{
+			"org/repo @1 @hashi_dirty=0",
+			"org/repo @1 @hashi_ahead=2",
+			"org/repo @1 @hashi_status=ok",
+			"org/repo @2 @hashi_dirty=1",
+			"org/repo @2 @hashi_ahead=2",
+			"org/repo @2 @hashi_status=ok",
+			"org/repo @3 @hashi_dirty=1",
+			"org/repo @3 @hashi_ahead=2",
+			"org/repo @3 @hashi_status=ok",
+			"org/repo @4 @hashi_dirty=0",
+			"org/repo @4 @hashi_ahead=0",
+			"org/repo @4 @hashi_status=orphaned_window",
+		}, *options)
+		assert.Len(t, g.HasUncommittedChangesCalls(), 2, "each branch is queried once")
+		assert.Len(t, tm.batch.RunCalls(), 1)
+	})
+
+	t.Run("only the given branches", func(t *testing.T) {
+		tm, options := decorateTmux(
+			tmux.Window{ID: "@1", Name: "main"},
+			tmux.Window{ID: "@2", Name: "feature"},
+			tmux.Window{ID: "@3", Name: "feature:server"},
+		)
+		g := summaryGitMock()
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		require.NoError(t, svc.Decorate(context.Background(), "feature:server"))
+		assert.Equal(t, []string{
+			"org/repo @2 @hashi_dirty=1",
+			"org/repo @2 @hashi_ahead=2",
+			"org/repo @2 @hashi_status=ok",
+			"org/repo @3 @hashi_dirty=1",
+			"org/repo @3 @hashi_ahead=2",
+			"org/repo @3 @hashi_status=ok",
+		}, *options)
+		require.Len(t, g.HasUncommittedChangesCalls(), 1)
+		assert.Equal(t, "/repo/.worktrees/feature", g.HasUncommittedChangesCalls()[0].WorktreePath)
+	})
+
+	t.Run("given branch without a window", func(t *testing.T) {
+		tm, options := decorateTmux(tmux.Window{ID: "@1", Name: "main"})
+		g := summaryGitMock()
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+		require.NoError(t, svc.Decorate(context.Background(), "gone"))
+		assert.Empty(t, *options)
+		assert.Empty(t, g.ListWorktreesCalls())
+	})
+
+	t.Run("no windows", func(t *testing.T) {
+		g := &git.ClientMock{}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		require.NoError(t, svc.Decorate(context.Background()))
+		assert.Empty(t, g.ListWorktreesCalls())
+	})
+
+	t.Run("no upstream", func(t *testing.T) {
+		tm, options := decorateTmux(tmux.Window{ID: "@1", Name: "main"})
+		g := summaryGitMock()
+		g.UpstreamFunc = func(branch string) (string, error) { return "", nil }
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+		require.NoError(t, svc.Decorate(context.Background()))
+		assert.Contains(t, *options, "org/repo @1 @hashi_ahead=0")
+		assert.Empty(t, g.AheadBehindCalls())
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			ListAllWindowsFunc: func() ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@5", Name: "feature", Session: "org/repo/feature"}}, nil
+			},
+		}
+		batch, _ := newBatchMock(nil)
+		var sessions []string
+		batch.SetWindowOptionFunc = func(session, window, key, value string) { sessions = append(sessions, session) }
+		svc := newTestSvc(summaryGitMock(), batchingTmux{tm, batch}, WithCommonParams(sessionCP()))
+		require.NoError(t, svc.Decorate(context.Background()))
+		assert.Equal(t, []string{"org/repo/feature", "org/repo/feature", "org/repo/feature"}, sessions)
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		tm, _ := decorateTmux(tmux.Window{ID: "@1", Name: "main"})
+		tm.batch.RunFunc = func() error { return fmt.Errorf("tmux failed") }
+		svc := newTestSvc(summaryGitMock(), tm, WithCommonParams(defaultCP()))
+		assert.ErrorContains(t, svc.Decorate(context.Background()), "tmux failed")
+	})
+}
@@ -39,3 +39,12 @@ type RepoRootBranchMismatchError struct {
 func (e *RepoRootBranchMismatchError) Error() string {
 	return fmt.Sprintf("repository root has '%s' checked out instead of '%s'; commit or stash changes and run: git -C <repo-root> switch %s", e.Actual, e.Expected, e.Expected)
 }
+
+// NotAdoptableError indicates the branch has no worktree that can be adopted.
+type NotAdoptableError struct {
+	Branch string
+}
+
+func (e *NotAdoptableError) Error() string {
+	return fmt.Sprintf("branch '%s' has no worktree outside hashi's layout", e.Branch)
+}
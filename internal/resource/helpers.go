@@ -22,6 +22,23 @@ func findWindow(windows []tmux.Window, name string) *tmux.Window {
 	return findBy(windows, func(w tmux.Window) string { return w.Name }, name)
 }
 
+// extraWindowSep separates the branch from the name of an extra window, as in
+// "feature-x:server". git does not allow ':' in branch names, so a window name
+// splits unambiguously.
+const extraWindowSep = ":"
+
+// extraWindowName returns the window name of the branch's extra window.
+func extraWindowName(branch, name string) string {
+	return branch + extraWindowSep + name
+}
+
+// splitWindowName splits a window name into its branch and extra window name.
+// extra is "" for the branch window itself.
+func splitWindowName(name string) (branch, extra string) {
+	branch, extra, _ = strings.Cut(name, extraWindowSep)
+	return branch, extra
+}
+
 // findWorktree returns the worktree matching the given branch, or nil.
 func findWorktree(worktrees []git.Worktree, branch string) *git.Worktree {
 	return findBy(worktrees, func(wt git.Worktree) string { return wt.Branch }, branch)
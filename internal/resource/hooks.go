@@ -0,0 +1,34 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// RunHooks types the post_new hooks into the branch window, as if its worktree
+// had just been created. They run in the layout's post_new pane if it runs a
+// shell, otherwise in the first pane that does.
+func (s *Service) RunHooks(ctx context.Context, branch string) error {
+	if len(s.cp.PostNewHooks) == 0 {
+		return errors.New("no post_new hooks configured")
+	}
+	session := s.mapping().session(branch)
+	panes, err := s.tmux.ListPanes(session, branch)
+	if err != nil {
+		return fmt.Errorf("listing panes of '%s': %w", branch, err)
+	}
+	target := ""
+	for i, p := range panes {
+		if !s.isShellCommand(p.Command) {
+			continue
+		}
+		if target == "" || i == s.postNewPane() {
+			target = p.ID
+		}
+	}
+	if target == "" {
+		return fmt.Errorf("no pane of '%s' is running a shell", branch)
+	}
+	return s.tmux.SendKeys(session, target, "C-u", chainCmds(s.cp.PostNewHooks), "Enter")
+}
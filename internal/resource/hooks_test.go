@@ -0,0 +1,61 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestRunHooks(t *testing.T) {
+	hooksCP := func(layout ...PaneSpec) CommonParams {
+		cp := defaultCP()
+		cp.PostNewHooks = []string{"npm install", "make"}
+		cp.Layout = layout
+		return cp
+	}
+	newTmux := func(panes ...tmux.Pane) *tmux.ClientMock {
+		tm := stubTmux()
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) { return panes, nil }
+		return tm
+	}
+
+	t.Run("first shell pane", func(t *testing.T) {
+		tm := newTmux(tmux.Pane{ID: "%1", Command: "vim"}, tmux.Pane{ID: "%2", Command: "zsh"})
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(hooksCP()))
+
+		require.NoError(t, svc.RunHooks(context.Background(), "feature"))
+		require.Len(t, tm.SendKeysCalls(), 1)
+		call := tm.SendKeysCalls()[0]
+		assert.Equal(t, "org/repo", call.Session)
+		assert.Equal(t, "%2", call.Window)
+		assert.Equal(t, []string{"C-u", "sh -c 'npm install' && sh -c 'make'", "Enter"}, call.Keys)
+	})
+
+	t.Run("post_new pane", func(t *testing.T) {
+		tm := newTmux(tmux.Pane{ID: "%1", Command: "bash"}, tmux.Pane{ID: "%2", Command: "bash"})
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(hooksCP(PaneSpec{}, PaneSpec{PostNew: true})))
+
+		require.NoError(t, svc.RunHooks(context.Background(), "feature"))
+		assert.Equal(t, "%2", tm.SendKeysCalls()[0].Window)
+	})
+
+	t.Run("no shell pane", func(t *testing.T) {
+		tm := newTmux(tmux.Pane{ID: "%1", Command: "vim"})
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(hooksCP()))
+
+		assert.ErrorContains(t, svc.RunHooks(context.Background(), "feature"), "running a shell")
+		assert.Empty(t, tm.SendKeysCalls())
+	})
+
+	t.Run("no hooks", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		assert.ErrorContains(t, svc.RunHooks(context.Background(), "feature"), "no post_new hooks")
+		assert.Empty(t, tm.ListPanesCalls())
+	})
+}
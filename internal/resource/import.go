@@ -0,0 +1,142 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// ImportCandidate describes a window opened outside hashi that belongs to a branch.
+type ImportCandidate struct {
+	Window   tmux.Window
+	Branch   string
+	Worktree string
+	// ByName is set when the window is named after the branch; otherwise its
+	// active pane is in the branch's worktree.
+	ByName bool
+}
+
+// FindImportable returns the unmanaged windows of the tmux session, named as
+// tmux names it, that belong to a branch: windows named after the branch, or
+// whose active pane is in the branch's worktree, the deepest worktree
+// winning. Only branches with a healthy worktree and no window are matched,
+// each by a single window; a window matched by name takes precedence.
+func (s *Service) FindImportable(ctx context.Context, session string) ([]ImportCandidate, error) {
+	if s.cp.Mapping == MappingSession {
+		return nil, errors.New("import requires the window mapping: every branch has its own session")
+	}
+	states, err := s.CollectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	worktrees := make(map[string]string)
+	for _, st := range states {
+		if !st.Window && st.Worktree != "" && st.Status.IsHealthy() {
+			worktrees[st.Branch] = st.Worktree
+		}
+	}
+	if len(worktrees) == 0 {
+		return nil, nil
+	}
+	windows, err := s.tmux.ListUnmanagedWindows(session)
+	if err != nil {
+		return nil, fmt.Errorf("listing windows of '%s': %w", session, err)
+	}
+
+	byBranch := make(map[string]ImportCandidate)
+	var order []string
+	for _, w := range windows {
+		c, ok := matchWindow(w, worktrees)
+		if !ok {
+			continue
+		}
+		prev, seen := byBranch[c.Branch]
+		if !seen {
+			order = append(order, c.Branch)
+		}
+		if !seen || c.ByName && !prev.ByName {
+			byBranch[c.Branch] = c
+		}
+	}
+	candidates := make([]ImportCandidate, 0, len(order))
+	for _, b := range order {
+		candidates = append(candidates, byBranch[b])
+	}
+	return candidates, nil
+}
+
+// matchWindow returns the branch the window belongs to, by its name or the
+// directory of its active pane.
+func matchWindow(w tmux.Window, worktrees map[string]string) (ImportCandidate, bool) {
+	if wt, ok := worktrees[w.Name]; ok {
+		return ImportCandidate{Window: w, Branch: w.Name, Worktree: wt, ByName: true}, true
+	}
+	var best ImportCandidate
+	for branch, wt := range worktrees {
+		if isWithin(w.Dir, wt) && len(wt) > len(best.Worktree) {
+			best = ImportCandidate{Window: w, Branch: branch, Worktree: wt}
+		}
+	}
+	return best, best.Branch != ""
+}
+
+// isWithin reports whether path is dir or inside it.
+func isWithin(path, dir string) bool {
+	if path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Import brings the windows under management: each is moved into the
+// repository session, named after its branch and tagged with the branch
+// and worktree. The windows keep their panes and running programs. It
+// returns the branches whose windows were imported; a window that fails
+// does not stop the others.
+func (s *Service) Import(ctx context.Context, candidates []ImportCandidate) ([]string, error) {
+	var imported []string
+	var errs []error
+	for _, c := range candidates {
+		if err := s.importWindow(c); err != nil {
+			errs = append(errs, fmt.Errorf("importing '%s': %w", c.Window.Name, err))
+			continue
+		}
+		imported = append(imported, c.Branch)
+	}
+	if len(imported) > 0 {
+		s.keepOrder()
+	}
+	return imported, errors.Join(errs...)
+}
+
+// importWindow moves the window into the session. A session that is not
+// running is created for it, with a placeholder window killed once the
+// window has moved in.
+func (s *Service) importWindow(c ImportCandidate) error {
+	session := s.mapping().session(c.Branch)
+	ok, err := s.tmux.HasSession(session)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	placeholder := ""
+	if !ok {
+		if placeholder, err = s.tmux.NewSession(session, c.Branch, c.Worktree, ""); err != nil {
+			return err
+		}
+	}
+	if err := s.tmux.MoveWindow(c.Window.ID, session); err != nil {
+		return fmt.Errorf("moving window: %w", err)
+	}
+	if placeholder != "" {
+		s.bestEffort("KillWindow", s.tmux.KillWindow(session, placeholder))
+	}
+	if err := s.tmux.RenameWindow(session, c.Window.ID, c.Branch); err != nil {
+		return fmt.Errorf("renaming window: %w", err)
+	}
+	return s.tmux.SetWindowOption(session, c.Window.ID, tmux.OptionWorktree, c.Worktree)
+}
@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestFindImportable(t *testing.T) {
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}}, nil
+			},
+			ListUnmanagedWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{
+					{ID: "@8", Name: "vim", Dir: "/repo/.worktrees/feature/src"},
+					{ID: "@7", Name: "feature", Dir: "/home/me"},
+					{ID: "@9", Name: "zsh", Dir: "/repo/.worktrees/other"},
+					{ID: "@10", Name: "htop", Dir: "/tmp"},
+					{ID: "@11", Name: "main", Dir: "/repo"},
+					{ID: "@12", Name: "zsh", Dir: "/repo/.worktrees/otherwise"},
+				}, nil
+			},
+		}
+	}
+
+	t.Run("matches by name and directory", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		candidates, err := svc.FindImportable(context.Background(), "work")
+		require.NoError(t, err)
+		require.Len(t, candidates, 2, "branches with a window, and windows outside any worktree, are not matched")
+		assert.Equal(t, "feature", candidates[0].Branch)
+		assert.Equal(t, "@7", candidates[0].Window.ID, "a window named after the branch takes precedence")
+		assert.True(t, candidates[0].ByName)
+		assert.Equal(t, "/repo/.worktrees/feature", candidates[0].Worktree)
+		assert.Equal(t, "other", candidates[1].Branch)
+		assert.Equal(t, "@9", candidates[1].Window.ID)
+		assert.False(t, candidates[1].ByName)
+		assert.Equal(t, "work", tm.ListUnmanagedWindowsCalls()[0].Session)
+	})
+
+	t.Run("list-windows fails", func(t *testing.T) {
+		tm := newTmux()
+		tm.ListUnmanagedWindowsFunc = func(session string) ([]tmux.Window, error) { return nil, fmt.Errorf("can't find session: work") }
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		_, err := svc.FindImportable(context.Background(), "work")
+		assert.ErrorContains(t, err, "listing windows of 'work': can't find session: work")
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		svc := newTestSvc(upDownGitMock(), newTmux(), WithCommonParams(sessionCP()))
+		_, err := svc.FindImportable(context.Background(), "work")
+		assert.ErrorContains(t, err, "import requires the window mapping")
+	})
+}
+
+func TestImport(t *testing.T) {
+	candidates := []ImportCandidate{
+		{Window: tmux.Window{ID: "@7", Name: "feature"}, Branch: "feature", Worktree: "/repo/.worktrees/feature", ByName: true},
+		{Window: tmux.Window{ID: "@9", Name: "zsh"}, Branch: "other", Worktree: "/repo/.worktrees/other"},
+	}
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc:      func(name string) (bool, error) { return true, nil },
+			MoveWindowFunc:      func(window, session string) error { return nil },
+			RenameWindowFunc:    func(session, old, new string) error { return nil },
+			SetWindowOptionFunc: func(session, window, key, value string) error { return nil },
+		}
+	}
+
+	t.Run("moves, renames and tags the windows", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		imported, err := svc.Import(context.Background(), candidates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature", "other"}, imported)
+		require.Len(t, tm.MoveWindowCalls(), 2)
+		assert.Equal(t, "@9", tm.MoveWindowCalls()[1].Window)
+		assert.Equal(t, "org/repo", tm.MoveWindowCalls()[1].Session)
+		require.Len(t, tm.RenameWindowCalls(), 2)
+		assert.Equal(t, "@9", tm.RenameWindowCalls()[1].Old)
+		assert.Equal(t, "other", tm.RenameWindowCalls()[1].New)
+		require.Len(t, tm.SetWindowOptionCalls(), 2)
+		assert.Equal(t, tmux.OptionWorktree, tm.SetWindowOptionCalls()[1].Key)
+		assert.Equal(t, "/repo/.worktrees/other", tm.SetWindowOptionCalls()[1].Value)
+	})
+
+	t.Run("starts the session", func(t *testing.T) {
+		tm := newTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return len(tm.NewSessionCalls()) > 0, nil }
+		tm.NewSessionFunc = func(name, windowName, dir, initCmd string) (string, error) { return "@20", nil }
+		tm.KillWindowFunc = func(session, window string) error { return nil }
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		_, err := svc.Import(context.Background(), candidates)
+		require.NoError(t, err)
+		require.Len(t, tm.NewSessionCalls(), 1)
+		require.Len(t, tm.KillWindowCalls(), 1)
+		assert.Equal(t, "@20", tm.KillWindowCalls()[0].Window, "the placeholder window is killed")
+	})
+
+	t.Run("a failure does not stop the others", func(t *testing.T) {
+		tm := newTmux()
+		tm.MoveWindowFunc = func(window, session string) error {
+			if window == "@7" {
+				return fmt.Errorf("can't find window: @7")
+			}
+			return nil
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		imported, err := svc.Import(context.Background(), candidates)
+		assert.ErrorContains(t, err, "importing 'feature': moving window: can't find window: @7")
+		assert.Equal(t, []string{"other"}, imported)
+	})
+}
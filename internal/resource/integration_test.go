@@ -2,11 +2,13 @@ package resource_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,9 +26,27 @@ func skipIfNoTmux(t *testing.T) {
 	}
 }
 
+// testSocket is the tmux server the integration tests run on, so they neither
+// see nor touch the sessions of the user's default server.
+var testSocket = fmt.Sprintf("hashi-test-%d", os.Getpid())
+
+// tmuxCmd returns a tmux command for the test server.
+func tmuxCmd(args ...string) *exec.Cmd {
+	return exec.Command("tmux", append([]string{"-L", testSocket}, args...)...)
+}
+
 func tmuxKillSession(t *testing.T, session string) {
 	t.Helper()
-	_ = exec.Command("tmux", "kill-session", "-t", session).Run()
+	_ = tmuxCmd("kill-session", "-t", session).Run()
+	// Killing the last session makes the server exit asynchronously; wait for it
+	// so the next test does not connect to a server that is shutting down.
+	for range 50 {
+		out, err := tmuxCmd("list-sessions").CombinedOutput()
+		if err == nil || strings.Contains(string(out), "no server running") {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
 }
 
 func gitCmd(t *testing.T, dir string, args ...string) {
@@ -43,7 +63,7 @@ func newTestService(t *testing.T, cp resource.CommonParams) (*resource.Service,
 	t.Helper()
 	e := hashiexec.NewDefaultExecutor()
 	g := git.NewClient(e)
-	return resource.NewService(g, tmux.NewClient(e), resource.WithCommonParams(cp)), g
+	return resource.NewService(g, tmux.NewClient(e, tmux.WithSocket(testSocket)), resource.WithCommonParams(cp)), g
 }
 
 // logNonConnectError logs an error from New/Switch/Rename if it is not the
@@ -114,6 +134,30 @@ func TestIntegration_NewAndList(t *testing.T) {
 	assert.True(t, found, "feature-test should appear in state list")
 }
 
+func TestIntegration_NoAttach(t *testing.T) {
+	session := setupTmuxTest(t, "noattach")
+
+	repoRoot := testutil.GitRepoWithBranch(t, "existing-branch")
+	t.Chdir(repoRoot)
+
+	e := hashiexec.NewDefaultExecutor()
+	svc := resource.NewService(git.NewClient(e), tmux.NewClient(e, tmux.WithSocket(testSocket)),
+		resource.WithCommonParams(testCommonParams(repoRoot, session)), resource.WithNoAttach())
+
+	// Without a terminal, attaching would fail; with WithNoAttach every
+	// operation succeeds.
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feature-test"})
+	require.NoError(t, err)
+	_, err = svc.Switch(context.Background(), resource.SwitchParams{Branch: "existing-branch"})
+	require.NoError(t, err)
+	_, err = svc.Rename(context.Background(), resource.RenameParams{Old: "feature-test", New: "renamed"})
+	require.NoError(t, err)
+
+	out, err := tmuxCmd("list-windows", "-t", session, "-F", "#{window_name}").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "renamed\nexisting-branch\n", string(out))
+}
+
 func TestIntegration_SwitchExistingBranch(t *testing.T) {
 	session := setupTmuxTest(t, "sw")
 
@@ -783,3 +827,419 @@ func TestIntegration_CollectStateMainAlwaysPresent(t *testing.T) {
 	assert.Equal(t, "main", states[0].Branch)
 	assert.Equal(t, resource.StatusOK, states[0].Status)
 }
+
+func TestIntegration_AdoptExternalWorktree(t *testing.T) {
+	session := setupTmuxTest(t, "adopt")
+
+	repoRoot := testutil.GitRepoWithBranch(t, "external")
+	t.Chdir(repoRoot)
+	external := filepath.Join(t.TempDir(), "repo-external")
+	gitCmd(t, repoRoot, "worktree", "add", external, "external")
+
+	cp := testCommonParams(repoRoot, session)
+	svc, g := newTestService(t, cp)
+
+	res, err := svc.Adopt(context.Background(), resource.AdoptParams{Branch: "external"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repoRoot, ".worktrees", "external"), res.WorktreePath)
+
+	worktrees, err := g.ListWorktrees()
+	require.NoError(t, err)
+	var found bool
+	for _, wt := range worktrees {
+		if wt.Branch == "external" {
+			found = true
+			assert.Equal(t, res.WorktreePath, wt.Path)
+		}
+	}
+	assert.True(t, found, "adopted worktree should be listed by git")
+	assert.NoDirExists(t, external)
+}
+
+func TestIntegration_RelocateMovedRepository(t *testing.T) {
+	repoRoot := testutil.GitRepoWithWorktree(t, "feature")
+	moved := filepath.Join(t.TempDir(), "moved")
+	require.NoError(t, os.Rename(repoRoot, moved))
+	t.Chdir(moved)
+
+	cp := testCommonParams(moved, "test-integration-relocate-"+t.Name())
+	svc, g := newTestService(t, cp)
+
+	res, err := svc.Relocate(context.Background())
+	require.NoError(t, err)
+	wtPath := filepath.Join(moved, ".worktrees", "feature")
+	assert.Equal(t, []string{wtPath}, res.Repaired)
+
+	worktrees, err := g.ListWorktrees()
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	assert.Equal(t, wtPath, worktrees[1].Path)
+
+	branch, err := g.CurrentBranch(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", branch)
+}
+
+func TestIntegration_WindowTrackedByTag(t *testing.T) {
+	session := setupTmuxTest(t, "tag")
+	tmuxKillSession(t, "hs/"+session)
+	t.Cleanup(func() { tmuxKillSession(t, "hs/"+session) })
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	e := hashiexec.NewDefaultExecutor()
+	g := git.NewClient(e)
+	tm := tmux.NewPrefixedClient(tmux.NewClient(e, tmux.WithSocket(testSocket)), "hs/")
+	svc := resource.NewService(g, tm, resource.WithCommonParams(testCommonParams(repoRoot, session)))
+
+	// A '.' in the name would be parsed as a pane separator if targeted by name.
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "release/v1.2"})
+	logNonConnectError(t, "New", err)
+
+	windows, err := tm.ListWindows(session)
+	require.NoError(t, err)
+	require.Len(t, windows, 1)
+	assert.Equal(t, "release/v1.2", windows[0].Name)
+	assert.Equal(t, filepath.Join(repoRoot, ".worktrees", "release", "v1.2"), windows[0].Worktree)
+
+	// A window renamed by the user is still found through its tag.
+	out, err := tmuxCmd("rename-window", "-t", "hs/"+session+":"+windows[0].ID, "editor").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	states, err := svc.CollectState(context.Background())
+	require.NoError(t, err)
+	var found bool
+	for _, s := range states {
+		if s.Branch == "release/v1.2" {
+			found = true
+			assert.Equal(t, resource.StatusOK, s.Status)
+			assert.True(t, s.Window)
+		}
+	}
+	assert.True(t, found)
+
+	check, err := svc.PrepareRemove(context.Background(), "release/v1.2")
+	require.NoError(t, err)
+	_, err = svc.ExecuteRemove(context.Background(), check)
+	require.NoError(t, err)
+
+	windows, _ = tm.ListWindows(session)
+	for _, w := range windows {
+		assert.NotEqual(t, "release/v1.2", w.Name, "window should be killed")
+	}
+}
+
+// tmuxCallCounter counts tmux processes started per call.
+type tmuxCallCounter struct {
+	hashiexec.Executor
+	calls int
+}
+
+func (c *tmuxCallCounter) Output(name string, args ...string) (string, error) {
+	if name == "tmux" {
+		c.calls++
+	}
+	return c.Executor.Output(name, args...)
+}
+
+func (c *tmuxCallCounter) Run(name string, args ...string) error {
+	if name == "tmux" {
+		c.calls++
+	}
+	return c.Executor.Run(name, args...)
+}
+
+func TestIntegration_ControlModeClient(t *testing.T) {
+	session := setupTmuxTest(t, "ctl")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	e := &tmuxCallCounter{Executor: hashiexec.NewDefaultExecutor()}
+	g := git.NewClient(e)
+	tm := tmux.NewControlClient(e, tmux.WithSocket(testSocket))
+	svc := resource.NewService(g, tm, resource.WithCommonParams(testCommonParams(repoRoot, session)))
+
+	// No session yet: commands fall back to separate processes until one exists.
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "ctl-a"})
+	logNonConnectError(t, "New", err)
+	_, err = svc.New(context.Background(), resource.NewParams{Branch: "ctl-b"})
+	logNonConnectError(t, "New", err)
+
+	e.calls = 0
+	windows, err := tm.ListWindows(session)
+	require.NoError(t, err)
+	assert.Zero(t, e.calls, "commands should go over the control connection once a session exists")
+	var names []string
+	for _, w := range windows {
+		names = append(names, w.Name)
+	}
+	assert.Contains(t, names, "ctl-a")
+	assert.Contains(t, names, "ctl-b")
+
+	ok, err := tm.HasSession(session + "-missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	check, err := svc.PrepareRemove(context.Background(), "ctl-a")
+	require.NoError(t, err)
+	_, err = svc.ExecuteRemove(context.Background(), check)
+	require.NoError(t, err)
+
+	ok, err = tm.HasSession(session)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestIntegration_SessionMapping(t *testing.T) {
+	session := setupTmuxTest(t, "map")
+	t.Cleanup(func() {
+		tmuxKillSession(t, session+"/feat")
+		tmuxKillSession(t, session+"/renamed")
+	})
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	cp.Mapping = resource.MappingSession
+	svc, _ := newTestService(t, cp)
+
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feat"})
+	logNonConnectError(t, "New", err)
+
+	out, err := tmuxCmd("list-windows", "-t", session+"/feat", "-F", "#{window_name}").Output()
+	require.NoError(t, err, "branch session should exist")
+	assert.Equal(t, "feat\n", string(out))
+	assert.Error(t, tmuxCmd("has-session", "-t", "="+session).Run(), "no repository session")
+
+	_, err = svc.Rename(context.Background(), resource.RenameParams{Old: "feat", New: "renamed"})
+	require.NoError(t, err)
+	out, err = tmuxCmd("list-windows", "-t", session+"/renamed", "-F", "#{window_name}").Output()
+	require.NoError(t, err, "session should be renamed")
+	assert.Equal(t, "renamed\n", string(out))
+
+	states, err := svc.CollectState(context.Background())
+	require.NoError(t, err)
+	var found bool
+	for _, s := range states {
+		if s.Branch == "renamed" {
+			found = true
+			assert.True(t, s.Window)
+		}
+	}
+	assert.True(t, found)
+
+	check, err := svc.PrepareRemove(context.Background(), "renamed")
+	require.NoError(t, err)
+	result, err := svc.ExecuteRemove(context.Background(), check)
+	require.NoError(t, err)
+	assert.True(t, result.SessionKilled)
+	assert.Error(t, tmuxCmd("has-session", "-t", "="+session+"/renamed").Run())
+}
+
+func TestIntegration_ExtraWindows(t *testing.T) {
+	session := setupTmuxTest(t, "extra")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	cp.Windows = []resource.WindowSpec{{Name: "server", Command: "sleep 60"}, {Name: "logs"}}
+	svc, _ := newTestService(t, cp)
+
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feat"})
+	logNonConnectError(t, "New", err)
+
+	out, err := tmuxCmd("list-windows", "-t", session, "-F", "#{window_name} #{window_active}").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "feat:server 0\nfeat:logs 0\nfeat 1\n", string(out))
+
+	states, err := svc.CollectState(context.Background())
+	require.NoError(t, err)
+	for _, s := range states {
+		if s.Branch == "feat" {
+			assert.Equal(t, []string{"server", "logs"}, s.Windows)
+		}
+	}
+
+	check, err := svc.PrepareRemove(context.Background(), "feat")
+	require.NoError(t, err)
+	_, err = svc.ExecuteRemove(context.Background(), check)
+	require.NoError(t, err)
+	// feat was the current window, so remove switched to main first.
+	out, err = tmuxCmd("list-windows", "-t", session, "-F", "#{window_name}").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "main\n", string(out), "extra windows should be killed with the branch")
+}
+
+func TestIntegration_Layout(t *testing.T) {
+	session := setupTmuxTest(t, "layout")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	cp.Layout = []resource.PaneSpec{
+		{},
+		{Target: 0, Horizontal: true, Size: "30%", Command: "sleep 60"},
+		{Target: 1, Focus: true},
+	}
+	svc, _ := newTestService(t, cp)
+
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feat"})
+	logNonConnectError(t, "New", err)
+
+	out, err := tmuxCmd("list-panes", "-t", session+":feat", "-F", "#{pane_active} #{pane_current_path}").Output()
+	require.NoError(t, err)
+	wtPath, err := filepath.EvalSymlinks(filepath.Join(repoRoot, ".worktrees", "feat"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 3)
+	for i, l := range lines {
+		active := "0"
+		if i == 2 {
+			active = "1"
+		}
+		assert.Equal(t, active+" "+wtPath, l)
+	}
+}
+
+func TestIntegration_WindowOrder(t *testing.T) {
+	session := setupTmuxTest(t, "order")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	cp.WindowOrder = resource.WindowOrderAlpha
+	svc, _ := newTestService(t, cp)
+
+	for _, b := range []string{"zeta", "alpha"} {
+		_, err := svc.New(context.Background(), resource.NewParams{Branch: b})
+		logNonConnectError(t, "New", err)
+	}
+	windows := func() string {
+		out, err := tmuxCmd("list-windows", "-t", session, "-F", "#{window_name} #{window_active}").Output()
+		require.NoError(t, err)
+		return string(out)
+	}
+	assert.Equal(t, "alpha 1\nzeta 0\n", windows(), "new window sorted and still current")
+
+	_, err := svc.Switch(context.Background(), resource.SwitchParams{Branch: "main"})
+	logNonConnectError(t, "Switch", err)
+	assert.Equal(t, "main 1\nalpha 0\nzeta 0\n", windows(), "default branch pinned first")
+
+	moved, err := svc.Tidy(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+
+	_, err = svc.Rename(context.Background(), resource.RenameParams{Old: "alpha", New: "zz"})
+	logNonConnectError(t, "Rename", err)
+	assert.Equal(t, "main 0\nzeta 0\nzz 1\n", windows())
+}
+
+func TestIntegration_Decorate(t *testing.T) {
+	session := setupTmuxTest(t, "decorate")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	svc, _ := newTestService(t, cp)
+
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feat"})
+	logNonConnectError(t, "New", err)
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".worktrees", "feat", "new.txt"), []byte("x"), 0644))
+
+	require.NoError(t, svc.Decorate(context.Background()))
+	out, err := tmuxCmd("display-message", "-p", "-t", session+":feat", "#{@hashi_dirty} #{@hashi_ahead} #{@hashi_status}").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "1 0 ok\n", string(out))
+}
+
+func TestIntegration_DownUp(t *testing.T) {
+	session := setupTmuxTest(t, "downup")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	cp.Windows = []resource.WindowSpec{{Name: "logs"}}
+	svc, _ := newTestService(t, cp)
+
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feat"})
+	logNonConnectError(t, "New", err)
+	require.NoError(t, tmuxCmd("new-window", "-d", "-t", session, "-n", "scratch").Run())
+	windows := func() string {
+		out, err := tmuxCmd("list-windows", "-t", session, "-F", "#{window_name} #{pane_current_path}").Output()
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	closed, err := svc.Down(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat"}, closed)
+	assert.Equal(t, "scratch "+repoRoot+"\n", windows(), "unmanaged windows stay")
+
+	states, err := svc.CollectState(context.Background())
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	assert.Equal(t, "feat", states[1].Branch)
+	assert.False(t, states[1].Window)
+	assert.Equal(t, resource.StatusOK, states[1].Status, "the worktree is kept")
+
+	opened, err := svc.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main", "feat"}, opened)
+	wt := filepath.Join(repoRoot, ".worktrees", "feat")
+	assert.Equal(t, "scratch "+repoRoot+"\nmain:logs "+repoRoot+"\nmain "+repoRoot+"\nfeat:logs "+wt+"\nfeat "+wt+"\n", windows())
+}
+
+func TestIntegration_SnapshotRestore(t *testing.T) {
+	session := setupTmuxTest(t, "snapshot")
+
+	repoRoot := testutil.GitRepo(t)
+	t.Chdir(repoRoot)
+
+	cp := testCommonParams(repoRoot, session)
+	svc, _ := newTestService(t, cp)
+
+	_, err := svc.New(context.Background(), resource.NewParams{Branch: "feat"})
+	logNonConnectError(t, "New", err)
+	wt := filepath.Join(repoRoot, ".worktrees", "feat")
+	require.NoError(t, tmuxCmd("split-window", "-h", "-t", session+":feat", "-c", wt, "sleep 300").Run())
+	require.NoError(t, tmuxCmd("new-window", "-d", "-t", session, "-n", "scratch").Run())
+	panes := func() string {
+		out, err := tmuxCmd("list-panes", "-t", session+":feat", "-F", "#{pane_current_path} #{pane_current_command}").Output()
+		require.NoError(t, err)
+		return string(out)
+	}
+	require.Eventually(t, func() bool { return strings.Contains(panes(), "sleep") }, 5*time.Second, 50*time.Millisecond)
+
+	snap, err := svc.Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Len(t, snap.Windows, 1, "orphaned windows are not saved")
+	require.Len(t, snap.Windows[0].Panes, 2)
+	assert.Equal(t, "sleep 300", snap.Windows[0].Panes[1].Command)
+
+	_, err = svc.Down(context.Background())
+	require.NoError(t, err)
+
+	restored, err := svc.Restore(context.Background(), snap, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat"}, restored)
+	lines := strings.Split(strings.TrimSpace(panes()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[1], wt+" "))
+	// The command is typed into the new shell, which may not have started it yet.
+	require.Eventually(t, func() bool {
+		out, err := tmuxCmd("capture-pane", "-p", "-t", session+":feat.1").Output()
+		return err == nil && strings.Contains(string(out), "sleep 300")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	restored, err = svc.Restore(context.Background(), snap, true)
+	require.NoError(t, err)
+	assert.Empty(t, restored, "open windows are left alone")
+}
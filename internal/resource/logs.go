@@ -0,0 +1,169 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// LogsParams selects the pane to read and how much of its output.
+type LogsParams struct {
+	Branch string
+	// Window is the name of one of the branch's extra windows; empty for the
+	// branch window.
+	Window string
+	// Pane is the index of the pane in the window; -1 for the active pane.
+	Pane int
+	// Lines is the number of lines to return from the end of the pane's
+	// history; 0 for the visible part of the pane.
+	Lines int
+}
+
+// FollowLogs checks for new output every followInterval, and whether the
+// pane still exists, which takes a tmux command, every paneCheckInterval.
+const (
+	followInterval    = 100 * time.Millisecond
+	paneCheckInterval = time.Second
+)
+
+// Logs returns the text of the pane, wrapped lines joined and trailing blank
+// lines removed.
+func (s *Service) Logs(ctx context.Context, p LogsParams) (string, error) {
+	pane, _, err := s.logsPane(p)
+	if err != nil {
+		return "", err
+	}
+	return s.capture(pane.ID, p.Lines)
+}
+
+// FollowLogs writes the text of the pane to w like Logs, then streams the
+// pane's output to w as the pane prints it, until ctx is done or the pane
+// exits. The output is piped with pipe-pane, which a pane can only have one
+// of, so a pane that is already piped is refused rather than taken over; the
+// pipe is closed on return. Streamed output is raw, terminal escape
+// sequences included.
+func (s *Service) FollowLogs(ctx context.Context, p LogsParams, w io.Writer) error {
+	pane, window, err := s.logsPane(p)
+	if err != nil {
+		return err
+	}
+	if pane.Piped {
+		return fmt.Errorf("pane %s is already piped with pipe-pane; close that pipe to follow it, or read the output where it goes", pane.ID)
+	}
+	text, err := s.capture(pane.ID, p.Lines)
+	if err != nil {
+		return err
+	}
+	if text != "" {
+		if _, err := fmt.Fprintln(w, text); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.CreateTemp("", "hashi-logs-*")
+	if err != nil {
+		return fmt.Errorf("creating pipe file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	if err := s.tmux.PipePane(pane.ID, "cat >> "+shellQuote(f.Name())); err != nil {
+		return fmt.Errorf("piping pane: %w", err)
+	}
+	defer func() { s.bestEffort("PipePane", s.tmux.PipePane(pane.ID, "")) }()
+
+	session := s.mapping().session(p.Branch)
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	check := time.NewTicker(paneCheckInterval)
+	defer check.Stop()
+	for {
+		if _, err := io.Copy(w, f); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-check.C:
+			if !s.paneExists(session, window, pane.ID) {
+				_, err := io.Copy(w, f)
+				return err
+			}
+		}
+	}
+}
+
+// logsPane returns the pane selected by p and the ID of its window.
+func (s *Service) logsPane(p LogsParams) (pane tmux.Pane, window string, err error) {
+	name := p.Branch
+	if p.Window != "" {
+		name = extraWindowName(p.Branch, p.Window)
+	}
+	w := findWindow(s.mapping().windows(), name)
+	if w == nil {
+		if p.Window != "" {
+			return tmux.Pane{}, "", fmt.Errorf("'%s' has no window '%s'", p.Branch, p.Window)
+		}
+		return tmux.Pane{}, "", fmt.Errorf("'%s' has no window; open it with 'hashi switch %s'", p.Branch, p.Branch)
+	}
+	panes, err := s.tmux.ListPanes(s.mapping().session(p.Branch), w.ID)
+	if err != nil {
+		return tmux.Pane{}, "", fmt.Errorf("listing panes of '%s': %w", name, err)
+	}
+	if p.Pane >= 0 {
+		if p.Pane >= len(panes) {
+			return tmux.Pane{}, "", fmt.Errorf("'%s' has no pane %d: it has %d pane(s)", name, p.Pane, len(panes))
+		}
+		return panes[p.Pane], w.ID, nil
+	}
+	for _, pn := range panes {
+		if pn.Active {
+			return pn, w.ID, nil
+		}
+	}
+	if len(panes) == 0 {
+		return tmux.Pane{}, "", errors.New("no pane to read")
+	}
+	return panes[0], w.ID, nil
+}
+
+// capture returns the pane's text without trailing blank lines, cut to the
+// last lines lines when lines is positive: tmux adds the visible part of the
+// pane to the history it returns.
+func (s *Service) capture(pane string, lines int) (string, error) {
+	out, err := s.tmux.CapturePane(pane, lines)
+	if err != nil {
+		return "", fmt.Errorf("capturing pane: %w", err)
+	}
+	out = strings.TrimRight(out, "\n")
+	if lines > 0 {
+		all := strings.Split(out, "\n")
+		if len(all) > lines {
+			out = strings.Join(all[len(all)-lines:], "\n")
+		}
+	}
+	return out, nil
+}
+
+// paneExists reports whether the pane is still in the window. A failure to
+// list the panes counts as gone, since the window may have been closed.
+func (s *Service) paneExists(session, window, pane string) bool {
+	panes, err := s.tmux.ListPanes(session, window)
+	if err != nil {
+		return false
+	}
+	for _, p := range panes {
+		if p.ID == pane {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestLogs(t *testing.T) {
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "feature"}, {ID: "@3", Name: "feature:server"}}, nil
+			},
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				if window == "@3" {
+					return []tmux.Pane{{ID: "%5"}}, nil
+				}
+				return []tmux.Pane{{ID: "%2"}, {ID: "%3", Active: true}}, nil
+			},
+			CapturePaneFunc: func(pane string, lines int) (string, error) {
+				return "one\ntwo\nthree\n\n\n", nil
+			},
+		}
+	}
+
+	t.Run("active pane", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		out, err := svc.Logs(context.Background(), LogsParams{Branch: "feature", Pane: -1})
+		require.NoError(t, err)
+		assert.Equal(t, "one\ntwo\nthree", out, "trailing blank lines are removed")
+		assert.Equal(t, "%3", tm.CapturePaneCalls()[0].Pane)
+		assert.Equal(t, "@2", tm.ListPanesCalls()[0].Window)
+	})
+
+	t.Run("last lines of a pane of an extra window", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		out, err := svc.Logs(context.Background(), LogsParams{Branch: "feature", Window: "server", Pane: 0, Lines: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "two\nthree", out)
+		assert.Equal(t, "%5", tm.CapturePaneCalls()[0].Pane)
+		assert.Equal(t, 2, tm.CapturePaneCalls()[0].Lines)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			p    LogsParams
+			want string
+		}{
+			{LogsParams{Branch: "other", Pane: -1}, "'other' has no window; open it with 'hashi switch other'"},
+			{LogsParams{Branch: "feature", Window: "test", Pane: -1}, "'feature' has no window 'test'"},
+			{LogsParams{Branch: "feature", Pane: 2}, "'feature' has no pane 2: it has 2 pane(s)"},
+		} {
+			svc := newTestSvc(&git.ClientMock{}, newTmux(), WithCommonParams(defaultCP()))
+			_, err := svc.Logs(context.Background(), tt.p)
+			assert.ErrorContains(t, err, tt.want)
+		}
+	})
+}
+
+// syncBuffer is a bytes.Buffer safe for FollowLogs writing while the test reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFollowLogs(t *testing.T) {
+	// pipeTo appends to the file named in the pipe-pane command, as cat would.
+	pipeTo := func(command, text string) error {
+		path := strings.Trim(strings.TrimPrefix(command, "cat >> "), "'")
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = f.WriteString(text)
+		return err
+	}
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@2", Name: "feature"}}, nil
+			},
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%3", Active: true}}, nil
+			},
+			CapturePaneFunc: func(pane string, lines int) (string, error) { return "$ make", nil },
+		}
+	}
+
+	t.Run("until interrupted", func(t *testing.T) {
+		tm := newTmux()
+		tm.PipePaneFunc = func(pane, command string) error {
+			if command == "" {
+				return nil
+			}
+			return pipeTo(command, "building\n")
+		}
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var out syncBuffer
+		done := make(chan error)
+		go func() { done <- svc.FollowLogs(ctx, LogsParams{Branch: "feature", Pane: -1}, &out) }()
+		assert.Eventually(t, func() bool { return strings.Contains(out.String(), "building") }, time.Second, 10*time.Millisecond)
+		cancel()
+		require.NoError(t, <-done)
+
+		assert.Equal(t, "$ make\nbuilding\n", out.String())
+		require.Len(t, tm.PipePaneCalls(), 2)
+		assert.Equal(t, "%3", tm.PipePaneCalls()[0].Pane)
+		assert.Empty(t, tm.PipePaneCalls()[1].Command, "the pipe is closed")
+	})
+
+	t.Run("until the pane exits", func(t *testing.T) {
+		tm := newTmux()
+		tm.PipePaneFunc = func(pane, command string) error {
+			if command == "" {
+				return nil
+			}
+			return pipeTo(command, "done\n")
+		}
+		calls := 0
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) {
+			calls++
+			if calls > 1 {
+				return nil, fmt.Errorf("can't find window: @2")
+			}
+			return []tmux.Pane{{ID: "%3", Active: true}}, nil
+		}
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		var out bytes.Buffer
+		require.NoError(t, svc.FollowLogs(context.Background(), LogsParams{Branch: "feature", Pane: -1}, &out))
+		assert.Equal(t, "$ make\ndone\n", out.String())
+	})
+
+	t.Run("pane already piped", func(t *testing.T) {
+		tm := newTmux()
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) {
+			return []tmux.Pane{{ID: "%3", Active: true, Piped: true}}, nil
+		}
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		var out bytes.Buffer
+		err := svc.FollowLogs(context.Background(), LogsParams{Branch: "feature", Pane: -1}, &out)
+		assert.ErrorContains(t, err, "pane %3 is already piped with pipe-pane")
+		assert.Empty(t, tm.PipePaneCalls(), "the existing pipe is left alone")
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("pipe-pane fails", func(t *testing.T) {
+		tm := newTmux()
+		tm.PipePaneFunc = func(pane, command string) error { return fmt.Errorf("can't find pane: %%3") }
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+		err := svc.FollowLogs(context.Background(), LogsParams{Branch: "feature", Pane: -1}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "piping pane: can't find pane: %3")
+		assert.Len(t, tm.PipePaneCalls(), 1)
+	})
+}
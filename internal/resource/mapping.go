@@ -0,0 +1,139 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// Mapping selects how branches are laid out in tmux.
+type Mapping string
+
+const (
+	// MappingWindow places every branch in a window of one repository session.
+	MappingWindow Mapping = "window"
+	// MappingSession gives every branch its own session named <session>/<branch>.
+	MappingSession Mapping = "session"
+)
+
+// tmuxMapping locates the tmux session and window that hold a branch.
+// Branch windows are always named after the branch; mappings differ in
+// which session they live in and what removing them tears down.
+type tmuxMapping interface {
+	// session returns the tmux session holding the branch's window.
+	session(branch string) string
+	// windows returns the existing branch windows, named by branch.
+	// Returns nil when there is nothing to list or tmux fails.
+	windows() []tmux.Window
+	// create creates the branch's window, assuming it does not exist yet.
+	create(branch, dir, initCmd string) error
+	// rename renames the existing branch window from old to new.
+	rename(old, new string) error
+	// kill kills the branch's extra windows and, if window is set, the branch
+	// window itself, and reports whether its session went with them.
+	kill(branch string, window bool, extras []string) (sessionKilled bool, err error)
+	// cleanup kills sessions left without windows and reports whether it did.
+	cleanup() bool
+}
+
+// mapping returns the tmuxMapping configured for the service.
+func (s *Service) mapping() tmuxMapping {
+	if s.cp.Mapping == MappingSession {
+		return sessionMapping{s}
+	}
+	return windowMapping{s}
+}
+
+// windowMapping keeps every branch as a window in cp.SessionName.
+type windowMapping struct{ s *Service }
+
+func (m windowMapping) session(string) string { return m.s.cp.SessionName }
+
+func (m windowMapping) windows() []tmux.Window {
+	return m.s.listWindowsSafe(m.s.cp.SessionName)
+}
+
+func (m windowMapping) create(branch, dir, initCmd string) error {
+	_, err := m.s.tmux.NewWindow(m.s.cp.SessionName, branch, dir, initCmd)
+	return err
+}
+
+func (m windowMapping) rename(old, new string) error {
+	return m.s.tmux.RenameWindow(m.s.cp.SessionName, old, new)
+}
+
+// kill kills the extra windows first, best-effort, so that the branch window,
+// which may be running hashi itself, goes last.
+func (m windowMapping) kill(branch string, window bool, extras []string) (bool, error) {
+	for _, e := range extras {
+		m.s.bestEffort("KillWindow", m.s.tmux.KillWindow(m.s.cp.SessionName, extraWindowName(branch, e)))
+	}
+	if !window {
+		return false, nil
+	}
+	return false, m.s.tmux.KillWindow(m.s.cp.SessionName, branch)
+}
+
+func (m windowMapping) cleanup() bool {
+	ok, _ := m.s.tmux.HasSession(m.s.cp.SessionName)
+	if !ok {
+		return false
+	}
+	windows, err := m.s.tmux.ListWindows(m.s.cp.SessionName)
+	m.s.bestEffort("ListWindows", err)
+	if len(windows) > 0 {
+		return false
+	}
+	return m.s.tmux.KillSession(m.s.cp.SessionName) == nil
+}
+
+// sessionMapping gives every branch its own session, holding a window
+// named after the branch plus whatever windows the user adds to it.
+type sessionMapping struct{ s *Service }
+
+// session joins the repository session and the branch. tmux rewrites '.'
+// in session names to '_', so the name is built the way tmux stores it.
+func (m sessionMapping) session(branch string) string {
+	return m.s.cp.SessionName + "/" + strings.ReplaceAll(branch, ".", "_")
+}
+
+// windows lists the branch windows across all branch sessions. A window
+// counts as active when it is the current window of an attached session.
+func (m sessionMapping) windows() []tmux.Window {
+	all, err := m.s.tmux.ListAllWindows()
+	m.s.bestEffort("ListAllWindows", err)
+	var windows []tmux.Window
+	for _, w := range all {
+		if branch, _ := splitWindowName(w.Name); w.Session != m.session(branch) {
+			continue
+		}
+		w.Active = w.Active && w.SessionAttached
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+func (m sessionMapping) create(branch, dir, initCmd string) error {
+	_, err := m.s.tmux.NewSession(m.session(branch), branch, dir, initCmd)
+	return err
+}
+
+func (m sessionMapping) rename(old, new string) error {
+	if err := m.s.tmux.RenameSession(m.session(old), m.session(new)); err != nil {
+		return err
+	}
+	return m.s.tmux.RenameWindow(m.session(new), old, new)
+}
+
+// kill kills the branch's session, which holds its extra windows too, and
+// the sessions grouped with it.
+func (m sessionMapping) kill(branch string, _ bool, _ []string) (bool, error) {
+	m.s.killGrouped(m.session(branch))
+	if err := m.s.tmux.KillSession(m.session(branch)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// cleanup is a no-op: kill already removes the branch's whole session.
+func (m sessionMapping) cleanup() bool { return false }
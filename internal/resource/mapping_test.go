@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func sessionCP() CommonParams {
+	cp := defaultCP()
+	cp.Mapping = MappingSession
+	return cp
+}
+
+func TestSessionMapping(t *testing.T) {
+	t.Run("session names", func(t *testing.T) {
+		m := newTestSvc(&git.ClientMock{}, &tmux.ClientMock{}, WithCommonParams(sessionCP())).mapping()
+		assert.Equal(t, "org/repo/feature/x", m.session("feature/x"))
+		assert.Equal(t, "org/repo/v1_2", m.session("v1.2"), "tmux stores '.' as '_'")
+	})
+
+	t.Run("window mapping uses the repository session", func(t *testing.T) {
+		m := newTestSvc(&git.ClientMock{}, &tmux.ClientMock{}, WithCommonParams(defaultCP())).mapping()
+		assert.Equal(t, "org/repo", m.session("feature/x"))
+	})
+
+	t.Run("windows keeps branch windows of branch sessions", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			ListAllWindowsFunc: func() ([]tmux.Window, error) {
+				return []tmux.Window{
+					{Name: "main", Session: "org/repo/main", Active: true, SessionAttached: true},
+					{Name: "feature", Session: "org/repo/feature", Active: true},
+					{Name: "feature", Session: "org/other/feature", Active: true, SessionAttached: true},
+					{Name: "v1.2", Session: "org/repo/v1_2"},
+				}, nil
+			},
+		}
+		windows := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(sessionCP())).mapping().windows()
+		require.Len(t, windows, 3)
+		assert.Equal(t, "main", windows[0].Name)
+		assert.True(t, windows[0].Active)
+		assert.Equal(t, "feature", windows[1].Name)
+		assert.False(t, windows[1].Active, "detached session is not active")
+		assert.Equal(t, "v1.2", windows[2].Name)
+	})
+
+	t.Run("rename renames session then window", func(t *testing.T) {
+		var calls []string
+		tm := &tmux.ClientMock{
+			RenameSessionFunc: func(old, new string) error {
+				calls = append(calls, "session "+old+" "+new)
+				return nil
+			},
+			RenameWindowFunc: func(session, old, new string) error {
+				calls = append(calls, "window "+session+" "+old+" "+new)
+				return nil
+			},
+		}
+		m := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(sessionCP())).mapping()
+		require.NoError(t, m.rename("a", "b"))
+		assert.Equal(t, []string{"session org/repo/a org/repo/b", "window org/repo/b a b"}, calls)
+	})
+}
+
+func TestExecuteRemove_SessionMapping(t *testing.T) {
+	var killed []string
+	tm := &tmux.ClientMock{
+		ListAllWindowsFunc: func() ([]tmux.Window, error) {
+			return []tmux.Window{
+				{ID: "@1", Name: "feature", Session: "org/repo/feature"},
+				{ID: "@1", Name: "feature", Session: "org/repo/feature~2"},
+				{ID: "@2", Name: "feature_x", Session: "org/repo/feature_x~1"},
+			}, nil
+		},
+		KillSessionFunc: func(name string) error {
+			killed = append(killed, name)
+			return nil
+		},
+	}
+	g := &git.ClientMock{
+		RemoveWorktreeFunc:   func(path string) error { return nil },
+		DeleteBranchFromFunc: func(dir, name string) error { return nil },
+	}
+	svc := newTestSvc(g, tm, WithCommonParams(sessionCP()))
+
+	result, err := svc.ExecuteRemove(context.Background(), RemoveCheck{
+		Branch:    "feature",
+		HasBranch: true,
+		HasWindow: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.WindowKilled)
+	assert.True(t, result.SessionKilled)
+	assert.Equal(t, []string{"org/repo/feature~2", "org/repo/feature"}, killed, "grouped sessions would keep the windows alive")
+	assert.Empty(t, tm.KillWindowCalls())
+	assert.Empty(t, tm.HasSessionCalls(), "no empty-session cleanup in session mode")
+}
+
+func TestSwitch_SessionMapping(t *testing.T) {
+	tm := stubTmuxInside()
+	batch, queued := newBatchMock(nil)
+	var sessions []string
+	batch.NewSessionFunc = func(name, windowName, dir, initCmd string) {
+		sessions = append(sessions, name+":"+windowName)
+		*queued = append(*queued, "new-session")
+	}
+	var switched string
+	batch.SwitchClientFunc = func(session, window string) {
+		switched = session + ":" + window
+		*queued = append(*queued, "switch-client")
+	}
+	g := &git.ClientMock{
+		BranchExistsFunc: mockBranchExists("main", "feature"),
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feature", Branch: "feature"},
+			}, nil
+		},
+	}
+	svc := newTestSvc(g, batchingTmux{tm, batch}, WithCommonParams(sessionCP()))
+
+	_, err := svc.Switch(context.Background(), SwitchParams{Branch: "feature"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/repo/feature:feature"}, sessions)
+	assert.Equal(t, "org/repo/feature:feature", switched)
+	assert.Equal(t, "org/repo/feature", tm.HasSessionCalls()[0].Name)
+}
@@ -69,12 +69,10 @@ func (s *Service) New(ctx context.Context, p NewParams) (*OperationResult, error
 
 	// Ensure tmux (best-effort rollback on failure)
 	initCmd := s.buildInitCmd(wtCreated)
-	if err := s.ensureTmux(s.cp.SessionName, p.Branch, wtPath, initCmd); err != nil {
+	return s.finalizeOperation(OpNew, p.Branch, wtPath, wtCreated, initCmd, func(err error) error {
 		s.rollbackNew(wtCreated, branchCreated, wtPath, p.Branch)
-		return nil, err
-	}
-
-	return s.finalizeOperation(OpNew, p.Branch, wtPath, wtCreated)
+		return err
+	})
 }
 
 // rollbackNew performs best-effort cleanup of newly created resources.
@@ -126,9 +126,10 @@ func TestNew(t *testing.T) {
 			HasSessionFunc: func(name string) (bool, error) {
 				return false, nil
 			},
-			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
-				return fmt.Errorf("tmux error")
+			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
+				return "", fmt.Errorf("tmux error")
 			},
+			IsInsideTmuxFunc: func() bool { return true },
 		}
 
 		cp := CommonParams{RepoRoot: repoRoot, WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
@@ -157,8 +158,8 @@ func TestNew(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "feature", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "zsh", nil
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				return nil
@@ -195,9 +196,10 @@ func TestNew(t *testing.T) {
 			HasSessionFunc: func(name string) (bool, error) {
 				return false, nil
 			},
-			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
-				return fmt.Errorf("tmux error")
+			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
+				return "", fmt.Errorf("tmux error")
 			},
+			IsInsideTmuxFunc: func() bool { return true },
 		}
 
 		cp := CommonParams{RepoRoot: repoRoot, WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
@@ -221,9 +223,9 @@ func TestNew(t *testing.T) {
 		var capturedInitCmd string
 		tm := &tmux.ClientMock{
 			HasSessionFunc: func(name string) (bool, error) { return false, nil },
-			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
+			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
 				capturedInitCmd = initCmd
-				return nil
+				return "@1", nil
 			},
 			IsInsideTmuxFunc: func() bool { return true },
 			SwitchClientFunc: func(session string, window string) error { return nil },
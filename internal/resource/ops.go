@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -101,26 +102,224 @@ func (s *Service) findOrCreateWorktree(branch string) (string, bool, error) {
 // ensureTmux ensures the tmux session and window exist.
 // Creates session if missing, creates window if missing, updates directory if window exists.
 // initCmd, if non-empty, is passed to tmux new-session/new-window as the initial shell command.
+// A newly created window is split into the configured layout and moved into
+// the configured window order.
 func (s *Service) ensureTmux(sessionName, windowName, dir, initCmd string) error {
+	return s.ensureTmuxWindows(sessionName, windowName, dir, initCmd, nil)
+}
+
+// ensureTmuxWindows is ensureTmux that also creates the given extra windows
+// of windowName if they are missing.
+func (s *Service) ensureTmuxWindows(sessionName, windowName, dir, initCmd string, extras []WindowSpec) error {
+	b := tmux.NewBatch(s.tmux)
+	q, err := s.queueEnsureTmux(b, sessionName, windowName, dir, initCmd, extras)
+	if err != nil {
+		return err
+	}
+	err = b.Run()
+	var be *tmux.BatchError
+	if errors.As(err, &be) && q.isCd(be.Index) {
+		s.bestEffort("SendKeys", be.Err)
+		return nil
+	}
+	if err == nil && q.created {
+		s.applyLayout(sessionName, windowName, dir, initCmd)
+		s.keepOrder()
+	}
+	return err
+}
+
+// ensureTmuxAndConnect ensures the tmux session and window like ensureTmux, along
+// with any missing extra windows configured in CommonParams.Windows, then
+// attaches or switches to the window unless the Service was created
+// WithNoAttach. Inside tmux, the switch-client is sent in
+// the same batch, so a switch to an existing window is a single tmux invocation.
+// ensureErr and connectErr are reported separately so callers can roll back
+// only when the window could not be created.
+func (s *Service) ensureTmuxAndConnect(sessionName, windowName, dir, initCmd string) (ensureErr, connectErr error) {
+	b := tmux.NewBatch(s.tmux)
+	q, err := s.queueEnsureTmux(b, sessionName, windowName, dir, initCmd, s.cp.Windows)
+	if err != nil {
+		return err, nil
+	}
+	attach := !s.noAttach
+	inside := attach && s.tmux.IsInsideTmux()
+	// A grouped session is looked up before switching, outside the batch.
+	batchSwitch := inside && !s.cp.GroupedSessions
+	sw := -1
+	if batchSwitch {
+		sw = b.Len()
+		b.SwitchClient(sessionName, windowName)
+	}
+
+	err = b.Run()
+	var be *tmux.BatchError
+	switch {
+	case err == nil:
+	case !errors.As(err, &be):
+		return err, nil
+	case q.isCd(be.Index):
+		// The cd is best-effort; tmux skipped the rest of the batch.
+		s.bestEffort("SendKeys", be.Err)
+		if batchSwitch {
+			return nil, s.tmux.SwitchClient(sessionName, windowName)
+		}
+	case be.Index == sw:
+		if q.created {
+			s.applyLayout(sessionName, windowName, dir, initCmd)
+			s.keepOrder()
+		}
+		return nil, be.Err
+	default:
+		return err, nil
+	}
+
+	if q.created {
+		s.applyLayout(sessionName, windowName, dir, initCmd)
+		s.keepOrder()
+	}
+	if attach && !batchSwitch {
+		return nil, s.connect(sessionName, windowName)
+	}
+	return nil, nil
+}
+
+// ensureQueue describes the commands queueEnsureTmux queued into a batch.
+type ensureQueue struct {
+	// cdFrom and cdTo delimit the indices [cdFrom, cdTo) of the queued cds.
+	cdFrom, cdTo int
+	// created reports whether the batch creates the window.
+	created bool
+}
+
+// isCd reports whether the command at index i of the batch is a queued cd.
+func (q ensureQueue) isCd(i int) bool {
+	return i >= q.cdFrom && i < q.cdTo
+}
+
+// queueEnsureTmux queues the commands that create the session or window into b,
+// or a cd into each shell pane of the existing window.
+// Missing extra windows of windowName are queued first, so that a newly created
+// branch window ends up as the session's current window.
+func (s *Service) queueEnsureTmux(b tmux.Batch, sessionName, windowName, dir, initCmd string, extras []WindowSpec) (ensureQueue, error) {
 	ok, err := s.tmux.HasSession(sessionName)
 	if err != nil {
-		return fmt.Errorf("checking session: %w", err)
+		return ensureQueue{}, fmt.Errorf("checking session: %w", err)
 	}
+	windowCmd := s.layoutPaneCmd(0, initCmd)
 	if !ok {
-		return s.tmux.NewSession(sessionName, windowName, dir, initCmd)
+		if len(extras) == 0 {
+			b.NewSession(sessionName, windowName, dir, windowCmd)
+			return ensureQueue{created: true}, nil
+		}
+		b.NewSession(sessionName, extraWindowName(windowName, extras[0].Name), dir, s.extraWindowCmd(extras[0].Command))
+		s.queueExtraWindows(b, sessionName, windowName, dir, nil, extras[1:])
+		b.NewWindow(sessionName, windowName, dir, windowCmd)
+		return ensureQueue{created: true}, nil
 	}
 
 	windows, err := s.tmux.ListWindows(sessionName)
 	if err != nil {
-		return fmt.Errorf("listing windows: %w", err)
+		return ensureQueue{}, fmt.Errorf("listing windows: %w", err)
 	}
+	s.queueExtraWindows(b, sessionName, windowName, dir, windows, extras)
 
 	if w := findWindow(windows, windowName); w != nil {
-		s.sendCd(sessionName, windowName, dir)
-		return nil
+		q := ensureQueue{cdFrom: b.Len()}
+		for _, pane := range s.shellPanes(sessionName, windowName) {
+			b.SendKeys(sessionName, pane, cdKeys(dir)...)
+		}
+		q.cdTo = b.Len()
+		return q, nil
+	}
+
+	b.NewWindow(sessionName, windowName, dir, windowCmd)
+	return ensureQueue{created: true}, nil
+}
+
+// queueExtraWindows queues a new-window for each extra window of branch that
+// is not among windows.
+func (s *Service) queueExtraWindows(b tmux.Batch, sessionName, branch, dir string, windows []tmux.Window, extras []WindowSpec) {
+	for _, e := range extras {
+		name := extraWindowName(branch, e.Name)
+		if findWindow(windows, name) == nil {
+			b.NewWindow(sessionName, name, dir, s.extraWindowCmd(e.Command))
+		}
+	}
+}
+
+// extraWindowCmd wraps the command of an extra window so that the pane falls
+// back to the user's shell when the command exits, keeping the window open.
+// Returns "" for an empty command.
+func (s *Service) extraWindowCmd(command string) string {
+	if command == "" {
+		return ""
+	}
+	return s.shellCmd([]string{command})
+}
+
+// layoutPaneCmd returns the initial command of pane i of the layout: the
+// pane's command, preceded by the post_new hooks if initCmd is set (the
+// worktree was just created) and the pane is the one designated for them.
+// Without a layout, the window's only pane gets initCmd.
+func (s *Service) layoutPaneCmd(i int, initCmd string) string {
+	if len(s.cp.Layout) == 0 {
+		return initCmd
 	}
+	p := s.cp.Layout[i]
+	var cmds []string
+	if initCmd != "" && i == s.postNewPane() {
+		cmds = append(cmds, s.cp.PostNewHooks...)
+	}
+	if p.Command != "" {
+		cmds = append(cmds, p.Command)
+	}
+	return s.shellCmd(cmds)
+}
+
+// postNewPane returns the index of the layout pane that runs the post_new
+// hooks: the one marked PostNew, or the first pane.
+func (s *Service) postNewPane() int {
+	for i, p := range s.cp.Layout {
+		if p.PostNew {
+			return i
+		}
+	}
+	return 0
+}
 
-	return s.tmux.NewWindow(sessionName, windowName, dir, initCmd)
+// applyLayout splits a newly created window into the panes of the layout and
+// focuses the designated pane. Best-effort: a failed split stops the layout,
+// leaving the panes created so far.
+func (s *Service) applyLayout(session, window, dir, initCmd string) {
+	if len(s.cp.Layout) < 2 {
+		return
+	}
+	panes, err := s.tmux.ListPanes(session, window)
+	if err != nil || len(panes) == 0 {
+		s.bestEffort("ListPanes", err)
+		return
+	}
+	ids := []string{panes[0].ID}
+	for i, p := range s.cp.Layout[1:] {
+		id, err := s.tmux.SplitPane(ids[p.Target], tmux.SplitOptions{
+			Horizontal: p.Horizontal,
+			Size:       p.Size,
+			Dir:        dir,
+			Command:    s.layoutPaneCmd(i+1, initCmd),
+		})
+		if err != nil {
+			s.bestEffort("SplitPane", err)
+			return
+		}
+		ids = append(ids, id)
+	}
+	// Splits do not take the focus, so the first pane keeps it by default.
+	for i, p := range s.cp.Layout[1:] {
+		if p.Focus {
+			s.bestEffort("SelectPane", s.tmux.SelectPane(ids[i+1]))
+		}
+	}
 }
 
 // listWindowsSafe returns the tmux windows for the given session.
@@ -136,18 +335,30 @@ func (s *Service) listWindowsSafe(sessionName string) []tmux.Window {
 	return windows
 }
 
-// sendCd sends a cd command to the tmux pane if it is running a shell.
-// Skips if the pane is running a non-shell process (e.g. vim).
+// sendCd sends a cd command to every pane of the window that is running a shell.
+// Panes running a non-shell process (e.g. vim) are skipped.
 func (s *Service) sendCd(session, window, dir string) {
-	cmd, err := s.tmux.PaneCurrentCommand(session, window)
-	if err != nil {
-		s.bestEffort("PaneCurrentCommand", err)
-		return
+	for _, pane := range s.shellPanes(session, window) {
+		s.bestEffort("SendKeys", s.tmux.SendKeys(session, pane, cdKeys(dir)...))
 	}
-	if !s.isShellCommand(cmd) {
-		return
+}
+
+// shellPanes returns the IDs of the window's panes that are running a shell.
+func (s *Service) shellPanes(session, window string) []string {
+	panes, err := s.tmux.ListPanes(session, window)
+	s.bestEffort("ListPanes", err)
+	var ids []string
+	for _, p := range panes {
+		if s.isShellCommand(p.Command) {
+			ids = append(ids, p.ID)
+		}
 	}
-	s.bestEffort("SendKeys", s.tmux.SendKeys(session, window, "C-u", "cd "+shellQuote(dir), "Enter"))
+	return ids
+}
+
+// cdKeys returns the send-keys arguments that clear the prompt and cd into dir.
+func cdKeys(dir string) []string {
+	return []string{"C-u", "cd " + shellQuote(dir), "Enter"}
 }
 
 // DefaultShellCommands is the default set of commands recognized as interactive shells.
@@ -161,18 +372,40 @@ func (s *Service) isShellCommand(cmd string) bool {
 	return ok
 }
 
-// connect attaches or switches to the tmux session/window.
+// connect attaches or switches to the tmux session/window, unless the
+// Service was created WithNoAttach.
 func (s *Service) connect(sessionName, windowName string) error {
+	if s.noAttach {
+		return nil
+	}
 	if s.tmux.IsInsideTmux() {
-		return s.tmux.SwitchClient(sessionName, windowName)
+		return s.switchClient(sessionName, windowName)
+	}
+	if s.cp.GroupedSessions {
+		return s.connectGrouped(sessionName, windowName)
 	}
 	return s.tmux.AttachSession(sessionName, windowName)
 }
 
-// finalizeOperation connects to tmux and returns the result.
-func (s *Service) finalizeOperation(op OperationType, branch, wtPath string, wtCreated bool) (*OperationResult, error) {
-	if err := s.connect(s.cp.SessionName, branch); err != nil {
-		return nil, err
+// switchClient moves the client, which is inside tmux, to the window,
+// through a grouped session if GroupedSessions is set.
+func (s *Service) switchClient(sessionName, windowName string) error {
+	if s.cp.GroupedSessions {
+		return s.connectGrouped(sessionName, windowName)
+	}
+	return s.tmux.SwitchClient(sessionName, windowName)
+}
+
+// finalizeOperation ensures the tmux window, connects to it and returns the result.
+// onEnsureErr is called with an error that prevented the window from being
+// created and returns the error to report; connect errors are returned as is.
+func (s *Service) finalizeOperation(op OperationType, branch, wtPath string, wtCreated bool, initCmd string, onEnsureErr func(error) error) (*OperationResult, error) {
+	ensureErr, connectErr := s.ensureTmuxAndConnect(s.mapping().session(branch), branch, wtPath, initCmd)
+	if ensureErr != nil {
+		return nil, onEnsureErr(ensureErr)
+	}
+	if connectErr != nil {
+		return nil, connectErr
 	}
 	return &OperationResult{Operation: op, Branch: branch, WorktreePath: wtPath, Created: wtCreated}, nil
 }
@@ -185,15 +418,33 @@ func (s *Service) buildInitCmd(wtCreated bool) string {
 	if !wtCreated || len(s.cp.PostNewHooks) == 0 {
 		return ""
 	}
-	shell := s.cp.Shell
-	if shell == "" {
-		shell = "sh"
+	return s.shellCmd(s.cp.PostNewHooks)
+}
+
+// shellCmd chains cmds like chainCmds, then execs the user's login shell so
+// the pane stays open. Returns "" if cmds is empty.
+func (s *Service) shellCmd(cmds []string) string {
+	if len(cmds) == 0 {
+		return ""
 	}
-	parts := make([]string, 0, len(s.cp.PostNewHooks))
-	for _, h := range s.cp.PostNewHooks {
-		parts = append(parts, fmt.Sprintf("sh -c %s", shellQuote(h)))
+	return chainCmds(cmds) + "; exec " + shellQuote(s.loginShell())
+}
+
+// chainCmds chains cmds, each in its own sh -c subshell, with && for fail-fast behavior.
+func chainCmds(cmds []string) string {
+	parts := make([]string, 0, len(cmds))
+	for _, c := range cmds {
+		parts = append(parts, fmt.Sprintf("sh -c %s", shellQuote(c)))
+	}
+	return strings.Join(parts, " && ")
+}
+
+// loginShell returns the user's login shell from CommonParams.Shell, or "sh".
+func (s *Service) loginShell() string {
+	if s.cp.Shell == "" {
+		return "sh"
 	}
-	return strings.Join(parts, " && ") + "; exec " + shellQuote(shell)
+	return s.cp.Shell
 }
 
 // copyFiles copies configured files and directories from repo root to the worktree.
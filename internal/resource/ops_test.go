@@ -100,12 +100,12 @@ func TestEnsureTmux(t *testing.T) {
 			HasSessionFunc: func(name string) (bool, error) {
 				return false, nil
 			},
-			NewSessionFunc: func(name string, wName string, d string, initCmd string) error {
+			NewSessionFunc: func(name string, wName string, d string, initCmd string) (string, error) {
 				sessionName = name
 				windowName = wName
 				dir = d
 				capturedInitCmd = initCmd
-				return nil
+				return "@1", nil
 			},
 		})
 
@@ -138,10 +138,10 @@ func TestEnsureTmux(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "main", Active: true}}, nil
 			},
-			NewWindowFunc: func(session string, name string, dir string, initCmd string) error {
+			NewWindowFunc: func(session string, name string, dir string, initCmd string) (string, error) {
 				newWindowName = name
 				capturedInitCmd = initCmd
-				return nil
+				return "@1", nil
 			},
 		})
 
@@ -160,8 +160,8 @@ func TestEnsureTmux(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "feature", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "zsh", nil
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				allKeys = append(allKeys, keys)
@@ -186,8 +186,8 @@ func TestEnsureTmux(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "feature", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "vim", nil
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "vim"}}, nil
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				sendKeysCalled = true
@@ -200,7 +200,7 @@ func TestEnsureTmux(t *testing.T) {
 		assert.False(t, sendKeysCalled)
 	})
 
-	t.Run("skips cd when PaneCurrentCommand errors", func(t *testing.T) {
+	t.Run("skips cd when ListPanes errors", func(t *testing.T) {
 		var sendKeysCalled bool
 		svc := NewService(nil, &tmux.ClientMock{
 			HasSessionFunc: func(name string) (bool, error) {
@@ -209,8 +209,8 @@ func TestEnsureTmux(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "feature", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "", fmt.Errorf("pane error")
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return nil, fmt.Errorf("pane error")
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				sendKeysCalled = true
@@ -238,6 +238,230 @@ func TestEnsureTmux(t *testing.T) {
 	})
 }
 
+func TestEnsureTmuxAndConnect(t *testing.T) {
+	// existingWindow returns a client whose session has a "feature" window running a shell.
+	existingWindow := func(inside bool) *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc:    func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc:   func(session string) ([]tmux.Window, error) { return []tmux.Window{{Name: "feature"}}, nil },
+			ListPanesFunc:     func(session, window string) ([]tmux.Pane, error) { return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil },
+			IsInsideTmuxFunc:  func() bool { return inside },
+			SwitchClientFunc:  func(session, window string) error { return nil },
+			AttachSessionFunc: func(session, window string) error { return nil },
+		}
+	}
+
+	t.Run("cd and switch in one batch", func(t *testing.T) {
+		tm := existingWindow(true)
+		b, queued := newBatchMock(nil)
+		svc := NewService(nil, batchingTmux{tm, b})
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+		require.NoError(t, ensureErr)
+		require.NoError(t, connectErr)
+		assert.Equal(t, []string{"send-keys", "switch-client"}, *queued)
+		assert.Equal(t, []string{"C-u", "cd '/wt/feature'", "Enter"}, b.SendKeysCalls()[0].Keys)
+		assert.Len(t, b.RunCalls(), 1)
+		assert.Empty(t, tm.SwitchClientCalls())
+	})
+
+	t.Run("failed cd is best-effort and still switches", func(t *testing.T) {
+		tm := existingWindow(true)
+		b, _ := newBatchMock(&tmux.BatchError{Index: 0, Command: "send-keys", Err: fmt.Errorf("no pane")})
+		svc := NewService(nil, batchingTmux{tm, b})
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+		require.NoError(t, ensureErr)
+		require.NoError(t, connectErr)
+		assert.Len(t, tm.SwitchClientCalls(), 1)
+	})
+
+	t.Run("failed switch is a connect error", func(t *testing.T) {
+		b, _ := newBatchMock(&tmux.BatchError{Index: 1, Command: "switch-client", Err: fmt.Errorf("no client")})
+		svc := NewService(nil, batchingTmux{existingWindow(true), b})
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+		require.NoError(t, ensureErr)
+		assert.EqualError(t, connectErr, "no client")
+	})
+
+	t.Run("failed new-window is an ensure error", func(t *testing.T) {
+		tm := existingWindow(true)
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) { return nil, nil }
+		b, queued := newBatchMock(&tmux.BatchError{Index: 0, Command: "new-window", Err: fmt.Errorf("bad dir")})
+		svc := NewService(nil, batchingTmux{tm, b})
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+		assert.ErrorContains(t, ensureErr, "bad dir")
+		require.NoError(t, connectErr)
+		assert.Equal(t, []string{"new-window", "switch-client"}, *queued)
+	})
+
+	t.Run("attaches after the batch outside tmux", func(t *testing.T) {
+		tm := existingWindow(false)
+		b, queued := newBatchMock(nil)
+		svc := NewService(nil, batchingTmux{tm, b})
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+		require.NoError(t, ensureErr)
+		require.NoError(t, connectErr)
+		assert.Equal(t, []string{"send-keys"}, *queued)
+		assert.Len(t, tm.AttachSessionCalls(), 1)
+	})
+
+	t.Run("creates missing extra windows before the branch window", func(t *testing.T) {
+		tm := existingWindow(true)
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "feature:logs"}}, nil
+		}
+		b, queued := newBatchMock(nil)
+		svc := NewService(nil, batchingTmux{tm, b}, WithCommonParams(CommonParams{
+			Shell:   "/bin/zsh",
+			Windows: []WindowSpec{{Name: "server", Command: "npm run dev"}, {Name: "logs"}},
+		}))
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+		require.NoError(t, ensureErr)
+		require.NoError(t, connectErr)
+		assert.Equal(t, []string{"new-window", "new-window", "switch-client"}, *queued)
+		calls := b.NewWindowCalls()
+		assert.Equal(t, "feature:server", calls[0].Name)
+		assert.Equal(t, "/wt/feature", calls[0].Dir)
+		assert.Equal(t, "sh -c 'npm run dev'; exec '/bin/zsh'", calls[0].InitCmd)
+		assert.Equal(t, "feature", calls[1].Name)
+	})
+
+	t.Run("new session starts with the first extra window", func(t *testing.T) {
+		tm := existingWindow(true)
+		tm.HasSessionFunc = func(name string) (bool, error) { return false, nil }
+		b, queued := newBatchMock(nil)
+		svc := NewService(nil, batchingTmux{tm, b}, WithCommonParams(CommonParams{
+			Windows: []WindowSpec{{Name: "server"}},
+		}))
+
+		ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "echo hi")
+		require.NoError(t, ensureErr)
+		require.NoError(t, connectErr)
+		assert.Equal(t, []string{"new-session", "new-window", "switch-client"}, *queued)
+		assert.Equal(t, "feature:server", b.NewSessionCalls()[0].WindowName)
+		assert.Empty(t, b.NewSessionCalls()[0].InitCmd)
+		assert.Equal(t, "feature", b.NewWindowCalls()[0].Name)
+		assert.Equal(t, "echo hi", b.NewWindowCalls()[0].InitCmd)
+	})
+}
+
+func TestEnsureTmux_Layout(t *testing.T) {
+	layout := []PaneSpec{
+		{Command: "nvim"},
+		{Target: 0, Horizontal: true, Size: "40%", PostNew: true},
+		{Target: 1, Command: "npm test", Focus: true},
+	}
+	newSvc := func(tm tmux.Client) *Service {
+		return NewService(nil, tm, WithCommonParams(CommonParams{
+			Shell:        "/bin/zsh",
+			PostNewHooks: []string{"npm install"},
+			Layout:       layout,
+		}))
+	}
+	splitting := func() *tmux.ClientMock {
+		n := 1
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return false, nil },
+			NewSessionFunc: func(name, windowName, dir, initCmd string) (string, error) { return "@1", nil },
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%0", Active: true}}, nil
+			},
+			SplitPaneFunc: func(pane string, opts tmux.SplitOptions) (string, error) {
+				n++
+				return fmt.Sprintf("%%%d", n-1), nil
+			},
+			SelectPaneFunc: func(pane string) error { return nil },
+		}
+	}
+
+	t.Run("splits a new window and runs post_new in the designated pane", func(t *testing.T) {
+		tm := splitting()
+		svc := newSvc(tm)
+
+		require.NoError(t, svc.ensureTmux("org/repo", "feature", "/wt", svc.buildInitCmd(true)))
+		assert.Equal(t, "sh -c 'nvim'; exec '/bin/zsh'", tm.NewSessionCalls()[0].InitCmd)
+
+		splits := tm.SplitPaneCalls()
+		require.Len(t, splits, 2)
+		assert.Equal(t, "%0", splits[0].Pane)
+		assert.Equal(t, tmux.SplitOptions{Horizontal: true, Size: "40%", Dir: "/wt", Command: "sh -c 'npm install'; exec '/bin/zsh'"}, splits[0].Opts)
+		assert.Equal(t, "%1", splits[1].Pane, "splits the pane created from layout pane 1")
+		assert.Equal(t, "sh -c 'npm test'; exec '/bin/zsh'", splits[1].Opts.Command)
+		require.Len(t, tm.SelectPaneCalls(), 1)
+		assert.Equal(t, "%2", tm.SelectPaneCalls()[0].Pane)
+	})
+
+	t.Run("hooks precede the command of their pane", func(t *testing.T) {
+		tm := splitting()
+		svc := NewService(nil, tm, WithCommonParams(CommonParams{
+			Shell:        "/bin/zsh",
+			PostNewHooks: []string{"npm install"},
+			Layout:       []PaneSpec{{Command: "nvim"}, {}},
+		}))
+
+		require.NoError(t, svc.ensureTmux("org/repo", "feature", "/wt", svc.buildInitCmd(true)))
+		assert.Equal(t, "sh -c 'npm install' && sh -c 'nvim'; exec '/bin/zsh'", tm.NewSessionCalls()[0].InitCmd)
+		assert.Empty(t, tm.SplitPaneCalls()[0].Opts.Command)
+		assert.Empty(t, tm.SelectPaneCalls())
+	})
+
+	t.Run("existing window is left alone", func(t *testing.T) {
+		tm := splitting()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) { return []tmux.Window{{Name: "feature"}}, nil }
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) {
+			return []tmux.Pane{{ID: "%0", Command: "nvim"}, {ID: "%1", Command: "zsh"}, {ID: "%2", Command: "bash"}}, nil
+		}
+		tm.SendKeysFunc = func(session, window string, keys ...string) error { return nil }
+		svc := newSvc(tm)
+
+		require.NoError(t, svc.ensureTmux("org/repo", "feature", "/wt", ""))
+		assert.Empty(t, tm.SplitPaneCalls())
+		sent := tm.SendKeysCalls()
+		require.Len(t, sent, 2, "cd into every shell pane")
+		assert.Equal(t, "%1", sent[0].Window)
+		assert.Equal(t, "%2", sent[1].Window)
+	})
+
+	t.Run("failed split stops the layout", func(t *testing.T) {
+		tm := splitting()
+		tm.SplitPaneFunc = func(pane string, opts tmux.SplitOptions) (string, error) {
+			return "", fmt.Errorf("size invalid")
+		}
+		svc := newSvc(tm)
+
+		require.NoError(t, svc.ensureTmux("org/repo", "feature", "/wt", ""))
+		assert.Len(t, tm.SplitPaneCalls(), 1)
+		assert.Empty(t, tm.SelectPaneCalls())
+	})
+}
+
+func TestEnsureTmuxAndConnect_cdEveryPane(t *testing.T) {
+	tm := &tmux.ClientMock{
+		HasSessionFunc:  func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) { return []tmux.Window{{Name: "feature"}}, nil },
+		ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+			return []tmux.Pane{{ID: "%1", Command: "zsh"}, {ID: "%2", Command: "zsh"}}, nil
+		},
+		IsInsideTmuxFunc: func() bool { return true },
+		SwitchClientFunc: func(session, window string) error { return nil },
+	}
+	// The second cd fails: tmux skipped the switch, which is retried on its own.
+	b, queued := newBatchMock(&tmux.BatchError{Index: 1, Command: "send-keys", Err: fmt.Errorf("no pane")})
+	svc := NewService(nil, batchingTmux{tm, b})
+
+	ensureErr, connectErr := svc.ensureTmuxAndConnect("org/repo", "feature", "/wt/feature", "")
+	require.NoError(t, ensureErr)
+	require.NoError(t, connectErr)
+	assert.Equal(t, []string{"send-keys", "send-keys", "switch-client"}, *queued)
+	assert.Len(t, tm.SwitchClientCalls(), 1)
+}
+
 func TestIsShellCommand(t *testing.T) {
 	svc := NewService(nil, nil)
 	shells := []string{"bash", "zsh", "fish", "sh", "dash", "ksh", "tcsh", "csh"}
@@ -0,0 +1,181 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// WindowOrder selects how managed windows are ordered in the session.
+type WindowOrder string
+
+const (
+	// WindowOrderCreated orders branches by when their window was created.
+	WindowOrderCreated WindowOrder = "created"
+	// WindowOrderAlpha orders branches by name.
+	WindowOrderAlpha WindowOrder = "alpha"
+	// WindowOrderRecent orders branches by most recent window activity.
+	WindowOrderRecent WindowOrder = "recent"
+)
+
+// Tidy sorts the managed windows of the session by CommonParams.WindowOrder,
+// or by creation if no order is configured, and returns how many windows
+// were moved.
+func (s *Service) Tidy(ctx context.Context) (int, error) {
+	if s.cp.Mapping == MappingSession {
+		return 0, errors.New("tidy requires the window mapping: every branch has its own session")
+	}
+	order := s.cp.WindowOrder
+	if order == "" {
+		order = WindowOrderCreated
+	}
+	return s.sortWindows(order)
+}
+
+// keepOrder re-sorts the session's windows after a window was created,
+// renamed, or removed, if an order is configured. It is best-effort.
+func (s *Service) keepOrder() {
+	if s.cp.WindowOrder == "" || s.cp.Mapping == MappingSession {
+		return
+	}
+	_, err := s.sortWindows(s.cp.WindowOrder)
+	s.bestEffort("sortWindows", err)
+}
+
+// windowGroup is a branch window together with its extra windows.
+type windowGroup struct {
+	branch  string
+	windows []tmux.Window
+}
+
+// sortWindows moves the managed windows into the given order, keeping the
+// default branch first and each branch's extra windows right after it.
+// Managed windows only trade places among the indices they already occupy,
+// so unmanaged windows stay where they are. The previously current window
+// stays current.
+func (s *Service) sortWindows(order WindowOrder) (int, error) {
+	session := s.cp.SessionName
+	if ok, err := s.tmux.HasSession(session); err != nil || !ok {
+		return 0, err
+	}
+	windows, err := s.tmux.ListWindows(session)
+	if err != nil {
+		return 0, err
+	}
+
+	slots := make([]int, 0, len(windows))
+	at := make(map[int]string, len(windows)) // index -> window ID
+	pos := make(map[string]int, len(windows))
+	var active string
+	for _, w := range windows {
+		slots = append(slots, w.Index)
+		at[w.Index] = w.ID
+		pos[w.ID] = w.Index
+		if w.Active {
+			active = w.ID
+		}
+	}
+	sort.Ints(slots)
+
+	moved := 0
+	for i, w := range s.orderWindows(windows, order) {
+		slot := slots[i]
+		if at[slot] == w.ID {
+			continue
+		}
+		if err := s.tmux.SwapWindow(session, w.ID, slot); err != nil {
+			return moved, err
+		}
+		from, other := pos[w.ID], at[slot]
+		at[slot], pos[w.ID] = w.ID, slot
+		at[from], pos[other] = other, from
+		moved++
+	}
+	if moved > 0 && active != "" {
+		s.bestEffort("SelectWindow", s.tmux.SelectWindow(session, active))
+	}
+	return moved, nil
+}
+
+// orderWindows returns the windows in the given order.
+func (s *Service) orderWindows(windows []tmux.Window, order WindowOrder) []tmux.Window {
+	byBranch := make(map[string]*windowGroup)
+	var groups []*windowGroup
+	for _, w := range windows {
+		branch, _ := splitWindowName(w.Name)
+		g, ok := byBranch[branch]
+		if !ok {
+			g = &windowGroup{branch: branch}
+			byBranch[branch] = g
+			groups = append(groups, g)
+		}
+		g.windows = append(g.windows, w)
+	}
+	for _, g := range groups {
+		sort.SliceStable(g.windows, func(i, j int) bool {
+			wi, wj := g.windows[i], g.windows[j]
+			if (wi.Name == g.branch) != (wj.Name == g.branch) {
+				return wi.Name == g.branch
+			}
+			return windowIDNum(wi.ID) < windowIDNum(wj.ID)
+		})
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		gi, gj := groups[i], groups[j]
+		if (gi.branch == s.cp.DefaultBranch) != (gj.branch == s.cp.DefaultBranch) {
+			return gi.branch == s.cp.DefaultBranch
+		}
+		switch order {
+		case WindowOrderCreated:
+			if ci, cj := gi.created(), gj.created(); ci != cj {
+				return ci < cj
+			}
+		case WindowOrderRecent:
+			if ai, aj := gi.activity(), gj.activity(); ai != aj {
+				return ai > aj
+			}
+		}
+		return gi.branch < gj.branch
+	})
+
+	sorted := make([]tmux.Window, 0, len(windows))
+	for _, g := range groups {
+		sorted = append(sorted, g.windows...)
+	}
+	return sorted
+}
+
+// created returns the lowest window ID of the group. tmux assigns window IDs
+// in increasing order, so this orders groups by creation.
+func (g *windowGroup) created() int {
+	lowest := -1
+	for _, w := range g.windows {
+		if n := windowIDNum(w.ID); lowest < 0 || n < lowest {
+			lowest = n
+		}
+	}
+	return lowest
+}
+
+// activity returns the latest activity time of the group's windows.
+func (g *windowGroup) activity() int64 {
+	var latest int64
+	for _, w := range g.windows {
+		latest = max(latest, w.Activity)
+	}
+	return latest
+}
+
+// windowIDNum returns the number of a window ID such as "@3", or -1.
+func windowIDNum(id string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(id, "@"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,206 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// orderTmux simulates the managed windows of a session for swap-window.
+type orderTmux struct {
+	*tmux.ClientMock
+	windows  []tmux.Window
+	selected string
+}
+
+func newOrderTmux(windows ...tmux.Window) *orderTmux {
+	o := &orderTmux{windows: windows}
+	o.ClientMock = &tmux.ClientMock{
+		HasSessionFunc: func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+			return append([]tmux.Window(nil), o.windows...), nil
+		},
+		SwapWindowFunc: func(session, window string, index int) error {
+			src, dst := o.find(window), -1
+			for i, w := range o.windows {
+				if w.Index == index {
+					dst = i
+				}
+			}
+			if src < 0 || dst < 0 {
+				return fmt.Errorf("no window %s or index %d", window, index)
+			}
+			o.windows[src].Index, o.windows[dst].Index = o.windows[dst].Index, o.windows[src].Index
+			return nil
+		},
+		SelectWindowFunc: func(session, window string) error {
+			o.selected = window
+			return nil
+		},
+	}
+	return o
+}
+
+func (o *orderTmux) find(id string) int {
+	for i, w := range o.windows {
+		if w.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// names returns the window names by index.
+func (o *orderTmux) names() []string {
+	ws := append([]tmux.Window(nil), o.windows...)
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Index < ws[j].Index })
+	names := make([]string, len(ws))
+	for i, w := range ws {
+		names[i] = w.Name
+	}
+	return names
+}
+
+func orderCP(order WindowOrder) CommonParams {
+	cp := defaultCP()
+	cp.WindowOrder = order
+	return cp
+}
+
+func TestSortWindows(t *testing.T) {
+	windows := func() []tmux.Window {
+		return []tmux.Window{
+			{ID: "@5", Name: "zeta", Index: 0, Activity: 300},
+			{ID: "@1", Name: "main", Index: 1, Activity: 100},
+			{ID: "@3", Name: "beta:server", Index: 2, Activity: 500},
+			{ID: "@2", Name: "beta", Index: 4, Activity: 200, Active: true},
+			{ID: "@4", Name: "alpha", Index: 6, Activity: 400},
+		}
+	}
+
+	tests := []struct {
+		order WindowOrder
+		want  []string
+	}{
+		{WindowOrderCreated, []string{"main", "beta", "beta:server", "alpha", "zeta"}},
+		{WindowOrderAlpha, []string{"main", "alpha", "beta", "beta:server", "zeta"}},
+		{WindowOrderRecent, []string{"main", "beta", "beta:server", "alpha", "zeta"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			tm := newOrderTmux(windows()...)
+			svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+
+			moved, err := svc.sortWindows(tt.order)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tm.names())
+			assert.Positive(t, moved)
+			assert.Equal(t, "@2", tm.selected, "the current window stays current")
+
+			var indices []int
+			for _, w := range tm.windows {
+				indices = append(indices, w.Index)
+			}
+			assert.ElementsMatch(t, []int{0, 1, 2, 4, 6}, indices, "managed windows keep their slots")
+		})
+	}
+
+	t.Run("already sorted", func(t *testing.T) {
+		tm := newOrderTmux(
+			tmux.Window{ID: "@1", Name: "main", Index: 0},
+			tmux.Window{ID: "@2", Name: "feat", Index: 1},
+		)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+		moved, err := svc.sortWindows(WindowOrderAlpha)
+		require.NoError(t, err)
+		assert.Zero(t, moved)
+		assert.Empty(t, tm.SwapWindowCalls())
+		assert.Empty(t, tm.SelectWindowCalls())
+	})
+
+	t.Run("no session", func(t *testing.T) {
+		tm := &tmux.ClientMock{HasSessionFunc: func(name string) (bool, error) { return false, nil }}
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+		moved, err := svc.sortWindows(WindowOrderAlpha)
+		require.NoError(t, err)
+		assert.Zero(t, moved)
+	})
+
+	t.Run("swap error", func(t *testing.T) {
+		tm := newOrderTmux(
+			tmux.Window{ID: "@2", Name: "feat", Index: 0},
+			tmux.Window{ID: "@1", Name: "main", Index: 1},
+		)
+		tm.SwapWindowFunc = func(session, window string, index int) error { return fmt.Errorf("swap failed") }
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+		_, err := svc.sortWindows(WindowOrderAlpha)
+		assert.ErrorContains(t, err, "swap failed")
+	})
+}
+
+func TestTidy(t *testing.T) {
+	t.Run("defaults to created", func(t *testing.T) {
+		tm := newOrderTmux(
+			tmux.Window{ID: "@3", Name: "alpha", Index: 0},
+			tmux.Window{ID: "@2", Name: "beta", Index: 1},
+			tmux.Window{ID: "@1", Name: "main", Index: 2},
+		)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP()))
+		moved, err := svc.Tidy(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, moved)
+		assert.Equal(t, []string{"main", "beta", "alpha"}, tm.names())
+	})
+
+	t.Run("uses the configured order", func(t *testing.T) {
+		tm := newOrderTmux(
+			tmux.Window{ID: "@1", Name: "main", Index: 0},
+			tmux.Window{ID: "@2", Name: "beta", Index: 1},
+			tmux.Window{ID: "@3", Name: "alpha", Index: 2},
+		)
+		svc := newTestSvc(&git.ClientMock{}, tm, WithCommonParams(orderCP(WindowOrderAlpha)))
+		_, err := svc.Tidy(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "alpha", "beta"}, tm.names())
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		cp := sessionCP()
+		cp.WindowOrder = WindowOrderAlpha
+		svc := newTestSvc(&git.ClientMock{}, &tmux.ClientMock{}, WithCommonParams(cp))
+		_, err := svc.Tidy(context.Background())
+		assert.ErrorContains(t, err, "window mapping")
+	})
+}
+
+func TestKeepOrder(t *testing.T) {
+	t.Run("disabled without window_order", func(t *testing.T) {
+		tm := &tmux.ClientMock{}
+		newTestSvc(&git.ClientMock{}, tm, WithCommonParams(defaultCP())).keepOrder()
+		assert.Empty(t, tm.HasSessionCalls())
+	})
+
+	t.Run("after remove", func(t *testing.T) {
+		tm := newOrderTmux(
+			tmux.Window{ID: "@3", Name: "beta", Index: 0},
+			tmux.Window{ID: "@1", Name: "main", Index: 1},
+			tmux.Window{ID: "@2", Name: "alpha", Index: 2},
+		)
+		tm.KillWindowFunc = func(session, window string) error {
+			tm.windows = tm.windows[:len(tm.windows)-1]
+			return nil
+		}
+		g := &git.ClientMock{DeleteBranchFromFunc: func(dir, name string) error { return nil }}
+		svc := newTestSvc(g, tm, WithCommonParams(orderCP(WindowOrderAlpha)))
+
+		_, err := svc.ExecuteRemove(context.Background(), RemoveCheck{Branch: "alpha", HasBranch: true, HasWindow: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "beta"}, tm.names())
+	})
+}
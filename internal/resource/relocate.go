@@ -0,0 +1,167 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// relativePathsKey is the git config key that makes worktree links relative,
+// so moving the repository directory does not break them (git 2.48+).
+const relativePathsKey = "worktree.useRelativePaths"
+
+// RelocateResult holds the outcome of a Relocate operation.
+type RelocateResult struct {
+	Repaired []string // worktree paths passed to git worktree repair
+	Windows  []string // windows whose directory was updated
+}
+
+// FindMovedWorktrees scans worktree_dir for linked worktrees whose .git file
+// no longer points into the repository's git common dir, which happens when
+// the whole repository directory was moved.
+func (s *Service) FindMovedWorktrees(ctx context.Context) ([]string, error) {
+	commonDir, err := s.git.GitCommonDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolving git common dir: %w", err)
+	}
+	base := filepath.Join(s.cp.RepoRoot, s.cp.WorktreeDir)
+
+	var moved []string
+	err = filepath.WalkDir(base, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		gitFile := filepath.Join(path, ".git")
+		info, err := os.Lstat(gitFile)
+		if err != nil || !info.Mode().IsRegular() {
+			return nil // not a worktree root; keep descending (e.g. feat/auth)
+		}
+		if !gitDirInside(gitFile, commonDir) {
+			moved = append(moved, path)
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", base, err)
+	}
+	return moved, nil
+}
+
+// gitDirInside reports whether the worktree .git file at gitFile points to
+// an existing admin directory under commonDir/worktrees.
+func gitDirInside(gitFile, commonDir string) bool {
+	data, err := os.ReadFile(gitFile)
+	if err != nil {
+		return false
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return false
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(gitFile), gitDir)
+	}
+	if _, err := os.Stat(gitDir); err != nil {
+		return false
+	}
+	return filepath.Dir(filepath.Clean(gitDir)) == filepath.Join(filepath.Clean(commonDir), "worktrees")
+}
+
+// Relocate repairs git's worktree links after the repository directory was moved
+// and points the existing tmux windows whose worktree moved at their new
+// locations, the repository root's included; other windows are left alone.
+// Returns an empty result if nothing moved.
+func (s *Service) Relocate(ctx context.Context) (*RelocateResult, error) {
+	moved, err := s.FindMovedWorktrees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &RelocateResult{}
+	if len(moved) > 0 {
+		if err := s.git.RepairWorktrees(moved...); err != nil {
+			return nil, fmt.Errorf("repairing worktrees: %w", err)
+		}
+		result.Repaired = moved
+	}
+
+	worktrees, err := s.git.ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+	m := s.mapping()
+	byBranch := toMap(worktrees, func(wt git.Worktree) string { return wt.Branch })
+	repaired := make(map[string]bool, len(moved))
+	for _, p := range moved {
+		repaired[filepath.Clean(p)] = true
+	}
+	for _, w := range m.windows() {
+		branch, _ := splitWindowName(w.Name)
+		wt, ok := byBranch[branch]
+		if !ok || wt.Detached || !windowMoved(w, wt.Path, repaired) {
+			continue
+		}
+		session := m.session(branch)
+		s.bestEffort("SetWindowOption", s.tmux.SetWindowOption(session, w.Name, tmux.OptionWorktree, wt.Path))
+		s.sendCd(session, w.Name, wt.Path)
+		result.Windows = append(result.Windows, w.Name)
+	}
+	return result, nil
+}
+
+// windowMoved reports whether the window is still at an old location of the
+// worktree now at path: its recorded worktree differs from path or, for a
+// window without one, the worktree was repaired or the window's directory is
+// outside it.
+func windowMoved(w tmux.Window, path string, repaired map[string]bool) bool {
+	if w.Worktree != "" {
+		return filepath.Clean(w.Worktree) != filepath.Clean(path)
+	}
+	return repaired[filepath.Clean(path)] || (w.Dir != "" && !containsDir(path, w.Dir))
+}
+
+// RelativeWorktreePathsEnabled reports whether git writes relative worktree links.
+func (s *Service) RelativeWorktreePathsEnabled() (bool, error) {
+	enabled, err := s.git.ConfigGetBool(relativePathsKey)
+	if err != nil {
+		return false, fmt.Errorf("reading %s: %w", relativePathsKey, err)
+	}
+	return enabled, nil
+}
+
+// EnableRelativeWorktreePaths turns on git's relative worktree links and
+// rewrites the links of existing linked worktrees.
+func (s *Service) EnableRelativeWorktreePaths() error {
+	if err := s.git.ConfigSet(relativePathsKey, "true"); err != nil {
+		return fmt.Errorf("setting %s: %w", relativePathsKey, err)
+	}
+	worktrees, err := s.git.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("listing worktrees: %w", err)
+	}
+	var paths []string
+	for _, wt := range worktrees {
+		if !wt.IsMain {
+			paths = append(paths, wt.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	if err := s.git.RepairWorktrees(paths...); err != nil {
+		return fmt.Errorf("repairing worktrees: %w", err)
+	}
+	return nil
+}
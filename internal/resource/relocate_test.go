@@ -0,0 +1,271 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// writeWorktreeLink creates a linked worktree directory whose .git file points to gitDir.
+func writeWorktreeLink(t *testing.T, wtPath, gitDir string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, ".git"), []byte("gitdir: "+gitDir+"\n"), 0644))
+}
+
+// relocateFixture builds a repo root with one intact and two moved worktrees.
+func relocateFixture(t *testing.T) (repoRoot, commonDir string) {
+	t.Helper()
+	repoRoot = t.TempDir()
+	commonDir = filepath.Join(repoRoot, ".git")
+	require.NoError(t, os.MkdirAll(filepath.Join(commonDir, "worktrees", "ok"), 0755))
+
+	writeWorktreeLink(t, filepath.Join(repoRoot, ".worktrees", "ok"), filepath.Join(commonDir, "worktrees", "ok"))
+	writeWorktreeLink(t, filepath.Join(repoRoot, ".worktrees", "feat", "auth"), "/old/repo/.git/worktrees/auth")
+	writeWorktreeLink(t, filepath.Join(repoRoot, ".worktrees", "fix"), "/old/repo/.git/worktrees/fix")
+	return repoRoot, commonDir
+}
+
+func TestFindMovedWorktrees(t *testing.T) {
+	t.Run("detects worktrees pointing outside the common dir", func(t *testing.T) {
+		repoRoot, commonDir := relocateFixture(t)
+		g := &git.ClientMock{GitCommonDirFunc: func() (string, error) { return commonDir, nil }}
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(cp))
+
+		moved, err := svc.FindMovedWorktrees(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(repoRoot, ".worktrees", "feat", "auth"),
+			filepath.Join(repoRoot, ".worktrees", "fix"),
+		}, moved)
+	})
+
+	t.Run("relative gitdir inside the common dir", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		commonDir := filepath.Join(repoRoot, ".git")
+		require.NoError(t, os.MkdirAll(filepath.Join(commonDir, "worktrees", "rel"), 0755))
+		writeWorktreeLink(t, filepath.Join(repoRoot, ".worktrees", "rel"), "../../.git/worktrees/rel")
+		g := &git.ClientMock{GitCommonDirFunc: func() (string, error) { return commonDir, nil }}
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(cp))
+
+		moved, err := svc.FindMovedWorktrees(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, moved)
+	})
+
+	t.Run("missing worktree dir", func(t *testing.T) {
+		g := &git.ClientMock{GitCommonDirFunc: func() (string, error) { return "/repo/.git", nil }}
+		cp := defaultCP()
+		cp.RepoRoot = t.TempDir()
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(cp))
+
+		moved, err := svc.FindMovedWorktrees(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, moved)
+	})
+
+	t.Run("GitCommonDir error", func(t *testing.T) {
+		g := &git.ClientMock{GitCommonDirFunc: func() (string, error) { return "", fmt.Errorf("git error") }}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.FindMovedWorktrees(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestRelocate(t *testing.T) {
+	t.Run("repairs moved worktrees and updates windows", func(t *testing.T) {
+		repoRoot, commonDir := relocateFixture(t)
+		var repaired []string
+		g := &git.ClientMock{
+			GitCommonDirFunc: func() (string, error) { return commonDir, nil },
+			RepairWorktreesFunc: func(paths ...string) error {
+				repaired = paths
+				return nil
+			},
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{
+					{Path: repoRoot, Branch: "main", IsMain: true},
+					{Path: filepath.Join(repoRoot, ".worktrees", "ok"), Branch: "ok"},
+					{Path: filepath.Join(repoRoot, ".worktrees", "fix"), Branch: "fix"},
+					{Path: filepath.Join(repoRoot, ".worktrees", "feat", "auth"), Branch: "feat/auth"},
+				}, nil
+			},
+		}
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{
+				{Name: "main", Worktree: "/old/repo"},
+				{Name: "ok", Worktree: filepath.Join(repoRoot, ".worktrees", "ok")},
+				{Name: "fix", Worktree: "/old/repo/.worktrees/fix"},
+				{Name: "feat/auth:server"},
+			}, nil
+		}
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) {
+			return []tmux.Pane{{ID: "%" + window, Command: "zsh"}}, nil
+		}
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+
+		res, err := svc.Relocate(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, repaired, 2)
+		assert.Equal(t, repaired, res.Repaired)
+		assert.Equal(t, []string{"main", "fix", "feat/auth:server"}, res.Windows, "windows of worktrees that were not moved are left alone")
+		require.Len(t, tm.SendKeysCalls(), 3)
+		assert.Equal(t, "%main", tm.SendKeysCalls()[0].Window)
+		assert.Equal(t, "cd "+shellQuote(repoRoot), tm.SendKeysCalls()[0].Keys[1])
+		assert.Equal(t, "%fix", tm.SendKeysCalls()[1].Window)
+		assert.Equal(t, "cd "+shellQuote(filepath.Join(repoRoot, ".worktrees", "fix")), tm.SendKeysCalls()[1].Keys[1])
+		assert.Equal(t, "%feat/auth:server", tm.SendKeysCalls()[2].Window)
+		assert.Equal(t, "cd "+shellQuote(filepath.Join(repoRoot, ".worktrees", "feat", "auth")), tm.SendKeysCalls()[2].Keys[1])
+		require.Len(t, tm.SetWindowOptionCalls(), 3)
+		assert.Equal(t, tmux.OptionWorktree, tm.SetWindowOptionCalls()[0].Key)
+		assert.Equal(t, repoRoot, tm.SetWindowOptionCalls()[0].Value)
+		assert.Equal(t, filepath.Join(repoRoot, ".worktrees", "fix"), tm.SetWindowOptionCalls()[1].Value)
+	})
+
+	t.Run("nothing moved", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		g := &git.ClientMock{
+			GitCommonDirFunc: func() (string, error) { return "/repo/.git", nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{{Path: repoRoot, Branch: "main", IsMain: true}}, nil
+			},
+		}
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "main", Worktree: repoRoot}}, nil
+		}
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+
+		res, err := svc.Relocate(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, res.Repaired)
+		assert.Empty(t, res.Windows)
+		assert.Empty(t, g.RepairWorktreesCalls())
+	})
+
+	t.Run("only the repository root moved", func(t *testing.T) {
+		repoRoot := t.TempDir()
+		g := &git.ClientMock{
+			GitCommonDirFunc: func() (string, error) { return filepath.Join(repoRoot, ".git"), nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{{Path: repoRoot, Branch: "main", IsMain: true}}, nil
+			},
+		}
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "main", Dir: "/old/repo/src"}}, nil
+		}
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) { return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil }
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+
+		res, err := svc.Relocate(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, res.Repaired)
+		assert.Equal(t, []string{"main"}, res.Windows, "an untagged window outside its worktree is updated")
+		assert.Empty(t, g.RepairWorktreesCalls())
+		require.Len(t, tm.SetWindowOptionCalls(), 1)
+		assert.Equal(t, repoRoot, tm.SetWindowOptionCalls()[0].Value)
+	})
+
+	t.Run("repair error", func(t *testing.T) {
+		repoRoot, commonDir := relocateFixture(t)
+		g := &git.ClientMock{
+			GitCommonDirFunc:    func() (string, error) { return commonDir, nil },
+			RepairWorktreesFunc: func(paths ...string) error { return fmt.Errorf("repair failed") },
+		}
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(cp))
+
+		_, err := svc.Relocate(context.Background())
+		assert.ErrorContains(t, err, "repairing worktrees")
+	})
+
+	t.Run("ListWorktrees error", func(t *testing.T) {
+		repoRoot, commonDir := relocateFixture(t)
+		g := &git.ClientMock{
+			GitCommonDirFunc:    func() (string, error) { return commonDir, nil },
+			RepairWorktreesFunc: func(paths ...string) error { return nil },
+			ListWorktreesFunc:   func() ([]git.Worktree, error) { return nil, fmt.Errorf("git error") },
+		}
+		cp := defaultCP()
+		cp.RepoRoot = repoRoot
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(cp))
+
+		_, err := svc.Relocate(context.Background())
+		assert.ErrorContains(t, err, "listing worktrees")
+	})
+}
+
+func TestRelativeWorktreePaths(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		g := &git.ClientMock{ConfigGetBoolFunc: func(key string) (bool, error) {
+			assert.Equal(t, "worktree.useRelativePaths", key)
+			return true, nil
+		}}
+		ok, err := newTestSvc(g, stubTmux()).RelativeWorktreePathsEnabled()
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("ConfigGetBool error", func(t *testing.T) {
+		g := &git.ClientMock{ConfigGetBoolFunc: func(key string) (bool, error) { return false, fmt.Errorf("git error") }}
+		_, err := newTestSvc(g, stubTmux()).RelativeWorktreePathsEnabled()
+		assert.Error(t, err)
+	})
+
+	t.Run("enable rewrites linked worktrees", func(t *testing.T) {
+		var repaired []string
+		g := &git.ClientMock{
+			ConfigSetFunc: func(key, value string) error { return nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}, {Path: "/repo/.worktrees/a", Branch: "a"}}, nil
+			},
+			RepairWorktreesFunc: func(paths ...string) error {
+				repaired = paths
+				return nil
+			},
+		}
+		require.NoError(t, newTestSvc(g, stubTmux()).EnableRelativeWorktreePaths())
+		assert.Equal(t, []string{"/repo/.worktrees/a"}, repaired)
+		require.Len(t, g.ConfigSetCalls(), 1)
+		assert.Equal(t, "true", g.ConfigSetCalls()[0].Value)
+	})
+
+	t.Run("enable without linked worktrees", func(t *testing.T) {
+		g := &git.ClientMock{
+			ConfigSetFunc: func(key, value string) error { return nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}}, nil
+			},
+		}
+		require.NoError(t, newTestSvc(g, stubTmux()).EnableRelativeWorktreePaths())
+		assert.Empty(t, g.RepairWorktreesCalls())
+	})
+
+	t.Run("ConfigSet error", func(t *testing.T) {
+		g := &git.ClientMock{ConfigSetFunc: func(key, value string) error { return fmt.Errorf("git error") }}
+		assert.Error(t, newTestSvc(g, stubTmux()).EnableRelativeWorktreePaths())
+	})
+}
@@ -7,11 +7,13 @@ import (
 
 // RemoveCheck holds the state information for a branch removal.
 type RemoveCheck struct {
-	Branch         string
-	HasBranch      bool
-	HasWorktree    bool
-	WorktreePath   string
-	HasWindow      bool
+	Branch       string
+	HasBranch    bool
+	HasWorktree  bool
+	WorktreePath string
+	HasWindow    bool
+	// Windows lists the names of the branch's extra windows.
+	Windows        []string
 	IsActive       bool
 	HasUncommitted bool
 	IsUnmerged     bool
@@ -19,7 +21,7 @@ type RemoveCheck struct {
 
 // HasResources reports whether any managed resource exists for this branch.
 func (c RemoveCheck) HasResources() bool {
-	return c.HasBranch || c.HasWorktree || c.HasWindow
+	return c.HasBranch || c.HasWorktree || c.HasWindow || len(c.Windows) > 0
 }
 
 // NeedsWarning reports whether the removal should warn the user about data loss.
@@ -54,9 +56,17 @@ func (s *Service) PrepareRemove(ctx context.Context, branch string) (RemoveCheck
 		check.WorktreePath = wt.Path
 	}
 
-	if w := findWindow(s.listWindowsSafe(s.cp.SessionName), branch); w != nil {
-		check.HasWindow = true
-		check.IsActive = w.Active
+	for _, w := range s.mapping().windows() {
+		b, extra := splitWindowName(w.Name)
+		if b != branch {
+			continue
+		}
+		if extra == "" {
+			check.HasWindow = true
+		} else {
+			check.Windows = append(check.Windows, extra)
+		}
+		check.IsActive = check.IsActive || w.Active
 	}
 
 	if !check.HasResources() {
@@ -89,14 +99,16 @@ type RemoveResult struct {
 // ExecuteRemove removes the resources for a branch.
 func (s *Service) ExecuteRemove(ctx context.Context, check RemoveCheck) (*RemoveResult, error) {
 	result := &RemoveResult{}
+	m := s.mapping()
 
 	// Switch from active window if needed
 	if check.IsActive {
-		if err := s.ensureTmux(s.cp.SessionName, s.cp.DefaultBranch, s.cp.RepoRoot, ""); err != nil {
+		session := m.session(s.cp.DefaultBranch)
+		if err := s.ensureTmux(session, s.cp.DefaultBranch, s.cp.RepoRoot, ""); err != nil {
 			return nil, fmt.Errorf("switching to default branch: %w", err)
 		}
 		if s.tmux.IsInsideTmux() {
-			s.bestEffort("SwitchClient", s.tmux.SwitchClient(s.cp.SessionName, s.cp.DefaultBranch))
+			s.bestEffort("switchClient", s.switchClient(session, s.cp.DefaultBranch))
 		}
 	}
 
@@ -121,22 +133,21 @@ func (s *Service) ExecuteRemove(ctx context.Context, check RemoveCheck) (*Remove
 	}
 
 	// Kill window last: may terminate this process via SIGHUP if it was the active window.
-	if check.HasWindow {
-		if err := s.tmux.KillWindow(s.cp.SessionName, check.Branch); err != nil {
+	if check.HasWindow || len(check.Windows) > 0 {
+		sessionKilled, err := m.kill(check.Branch, check.HasWindow, check.Windows)
+		if err != nil {
 			return nil, fmt.Errorf("killing window: %w", err)
 		}
-		result.WindowKilled = true
+		result.WindowKilled = check.HasWindow
+		result.SessionKilled = sessionKilled
 	}
 
 	// Best-effort: kill session if no windows remain.
-	if ok, _ := s.tmux.HasSession(s.cp.SessionName); ok {
-		windows, lErr := s.tmux.ListWindows(s.cp.SessionName)
-		s.bestEffort("ListWindows", lErr)
-		if len(windows) == 0 {
-			if err := s.tmux.KillSession(s.cp.SessionName); err == nil {
-				result.SessionKilled = true
-			}
-		}
+	if m.cleanup() {
+		result.SessionKilled = true
+	}
+	if result.WindowKilled && !result.SessionKilled {
+		s.keepOrder()
 	}
 
 	return result, nil
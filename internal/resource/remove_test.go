@@ -37,6 +37,28 @@ func TestPrepareRemove(t *testing.T) {
 		assert.Contains(t, err.Error(), "does not exist")
 	})
 
+	t.Run("detects extra windows", func(t *testing.T) {
+		svc := newTestSvc(
+			&git.ClientMock{
+				BranchExistsFunc:  mockBranchExists(),
+				ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, nil },
+			},
+			&tmux.ClientMock{
+				HasSessionFunc: func(name string) (bool, error) { return true, nil },
+				ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+					return []tmux.Window{{Name: "feature:server", Active: true}, {Name: "other:server"}}, nil
+				},
+			},
+			WithCommonParams(defaultCP()),
+		)
+
+		check, err := svc.PrepareRemove(context.Background(), "feature")
+		require.NoError(t, err)
+		assert.False(t, check.HasWindow)
+		assert.Equal(t, []string{"server"}, check.Windows)
+		assert.True(t, check.IsActive)
+	})
+
 	t.Run("detects all resource states", func(t *testing.T) {
 		svc := newTestSvc(
 			&git.ClientMock{
@@ -214,6 +236,33 @@ func TestExecuteRemove(t *testing.T) {
 		assert.True(t, result.BranchDeleted)
 	})
 
+	t.Run("kills extra windows before the branch window", func(t *testing.T) {
+		var killed []string
+		svc := newTestSvc(
+			&git.ClientMock{},
+			&tmux.ClientMock{
+				HasSessionFunc: func(name string) (bool, error) { return true, nil },
+				ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+					return []tmux.Window{{Name: "main"}}, nil
+				},
+				KillWindowFunc: func(session string, window string) error {
+					killed = append(killed, window)
+					return nil
+				},
+			},
+			WithCommonParams(defaultCP()),
+		)
+
+		result, err := svc.ExecuteRemove(context.Background(), RemoveCheck{
+			Branch:    "feature",
+			HasWindow: true,
+			Windows:   []string{"server", "logs"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature:server", "feature:logs", "feature"}, killed)
+		assert.True(t, result.WindowKilled)
+	})
+
 	t.Run("switches away from active window before removal", func(t *testing.T) {
 		var ensureTmuxCalled bool
 		svc := newTestSvc(
@@ -226,11 +275,14 @@ func TestExecuteRemove(t *testing.T) {
 				ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 					return []tmux.Window{{Name: "main", Active: false}}, nil
 				},
-				PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-					return "zsh", nil
+				ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+					if window != "main" {
+						return nil, nil
+					}
+					return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
 				},
 				SendKeysFunc: func(session string, window string, keys ...string) error {
-					if window == "main" {
+					if window == "%1" {
 						ensureTmuxCalled = true
 					}
 					return nil
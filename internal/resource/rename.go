@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
 )
 
 // RenameParams holds parameters for the Rename operation.
@@ -60,9 +62,10 @@ func (s *Service) Rename(ctx context.Context, p RenameParams) (*OperationResult,
 	// Handle tmux
 	initCmd := s.buildInitCmd(wtCreated)
 	s.renameTmuxWindow(p, wtPath, initCmd)
+	s.keepOrder()
 
 	// Best-effort connect to the renamed window (aligns with New/Switch behavior)
-	s.bestEffort("connect", s.connect(s.cp.SessionName, p.New))
+	s.bestEffort("connect", s.connect(s.mapping().session(p.New), p.New))
 
 	rb.disarm()
 	return &OperationResult{Operation: OpRename, Branch: p.New, WorktreePath: wtPath, Created: wtCreated}, nil
@@ -94,7 +97,7 @@ func (s *Service) moveWorktree(p RenameParams, oldPath string) (string, error) {
 	if err := os.Rename(oldPath, newPath); err != nil {
 		return "", fmt.Errorf("moving worktree: %w", err)
 	}
-	if err := s.git.RepairWorktrees(); err != nil {
+	if err := s.git.RepairWorktrees(newPath); err != nil {
 		s.bestEffort("os.Rename rollback", os.Rename(newPath, oldPath))
 		return "", fmt.Errorf("repairing worktrees: %w", err)
 	}
@@ -107,14 +110,28 @@ func (s *Service) moveWorktree(p RenameParams, oldPath string) (string, error) {
 // renameTmuxWindow updates the tmux window for the renamed branch.
 // All tmux operations are best-effort: failures are silently ignored.
 func (s *Service) renameTmuxWindow(p RenameParams, wtPath, initCmd string) {
-	windows := s.listWindowsSafe(s.cp.SessionName)
+	m := s.mapping()
+	windows := m.windows()
 	if windows == nil {
 		return
 	}
 	if findWindow(windows, p.Old) != nil {
-		s.bestEffort("RenameWindow", s.tmux.RenameWindow(s.cp.SessionName, p.Old, p.New))
-		s.sendCd(s.cp.SessionName, p.New, wtPath)
-		return
+		s.bestEffort("RenameWindow", m.rename(p.Old, p.New))
+	} else {
+		s.bestEffort("NewWindow", m.create(p.New, wtPath, initCmd))
+	}
+	session := m.session(p.New)
+	for _, w := range windows {
+		branch, extra := splitWindowName(w.Name)
+		if branch != p.Old {
+			continue
+		}
+		name := p.New
+		if extra != "" {
+			name = extraWindowName(p.New, extra)
+			s.bestEffort("RenameWindow", s.tmux.RenameWindow(session, w.Name, name))
+		}
+		s.bestEffort("SetWindowOption", s.tmux.SetWindowOption(session, name, tmux.OptionWorktree, wtPath))
+		s.sendCd(session, name, wtPath)
 	}
-	s.bestEffort("NewWindow", s.tmux.NewWindow(s.cp.SessionName, p.New, wtPath, initCmd))
 }
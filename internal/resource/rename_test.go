@@ -109,6 +109,23 @@ func TestRename(t *testing.T) {
 		assert.Contains(t, addedWT, ".worktrees/new")
 	})
 
+	t.Run("no attach", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListBranchesFunc:  mockListBranches("old"),
+			RenameBranchFunc:  func(old string, newName string) error { return nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, nil },
+			AddWorktreeFunc:   func(path string, branch string) error { return nil },
+		}
+		tm := stubTmux()
+
+		cp := CommonParams{RepoRoot: t.TempDir(), WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
+		svc := newTestSvc(g, tm, WithCommonParams(cp), WithNoAttach())
+		_, err := svc.Rename(context.Background(), RenameParams{Old: "old", New: "new"})
+		require.NoError(t, err)
+		assert.Empty(t, tm.IsInsideTmuxCalls())
+		assert.Empty(t, tm.AttachSessionCalls())
+	})
+
 	t.Run("moves existing worktree", func(t *testing.T) {
 		repoRoot := t.TempDir()
 		oldPath := filepath.Join(repoRoot, ".worktrees", "old")
@@ -125,7 +142,7 @@ func TestRename(t *testing.T) {
 					{Path: oldPath, Branch: "new"},
 				}, nil
 			},
-			RepairWorktreesFunc: func() error {
+			RepairWorktreesFunc: func(paths ...string) error {
 				return nil
 			},
 		}
@@ -144,6 +161,8 @@ func TestRename(t *testing.T) {
 
 		_, err = os.Stat(oldPath)
 		assert.True(t, os.IsNotExist(err), "old path should not exist")
+		require.Len(t, g.RepairWorktreesCalls(), 1)
+		assert.Equal(t, []string{newPath}, g.RepairWorktreesCalls()[0].Paths, "the moved worktree is repaired wherever hashi runs")
 	})
 
 	t.Run("rolls back worktree move on repair failure", func(t *testing.T) {
@@ -165,7 +184,7 @@ func TestRename(t *testing.T) {
 					{Path: oldPath, Branch: "new"},
 				}, nil
 			},
-			RepairWorktreesFunc: func() error {
+			RepairWorktreesFunc: func(paths ...string) error {
 				return fmt.Errorf("repair failed")
 			},
 		}
@@ -236,8 +255,13 @@ func TestRename(t *testing.T) {
 				renamedWindow = true
 				return nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "zsh", nil
+			SetWindowOptionFunc: func(session string, window string, key string, value string) error {
+				assert.Equal(t, "new", window)
+				assert.Equal(t, tmux.OptionWorktree, key)
+				return nil
+			},
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				return nil
@@ -254,6 +278,42 @@ func TestRename(t *testing.T) {
 		})
 		require.NoError(t, err)
 		assert.True(t, renamedWindow)
+		assert.Len(t, tm.SetWindowOptionCalls(), 1)
+	})
+
+	t.Run("renames extra windows together", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListBranchesFunc:  mockListBranches("old"),
+			RenameBranchFunc:  func(old string, newName string) error { return nil },
+			ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, nil },
+			AddWorktreeFunc:   func(path string, branch string) error { return nil },
+		}
+		var renamed []string
+		tm := &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{Name: "old"}, {Name: "old:server"}, {Name: "other:server"}}, nil
+			},
+			RenameWindowFunc: func(session string, old string, newName string) error {
+				renamed = append(renamed, old+"->"+newName)
+				return nil
+			},
+			SetWindowOptionFunc: func(session string, window string, key string, value string) error { return nil },
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "node"}}, nil
+			},
+			IsInsideTmuxFunc: func() bool { return true },
+			SwitchClientFunc: func(session string, window string) error { return nil },
+		}
+
+		cp := CommonParams{RepoRoot: t.TempDir(), WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+		_, err := svc.Rename(context.Background(), RenameParams{Old: "old", New: "new"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old->new", "old:server->new:server"}, renamed)
+		require.Len(t, tm.SetWindowOptionCalls(), 2)
+		assert.Equal(t, "new:server", tm.SetWindowOptionCalls()[1].Window)
+		assert.Empty(t, tm.SendKeysCalls(), "no cd into a pane running a command")
 	})
 
 	t.Run("creates new tmux window when old window not found", func(t *testing.T) {
@@ -278,9 +338,9 @@ func TestRename(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "main", Active: true}}, nil
 			},
-			NewWindowFunc: func(session string, name string, dir string, initCmd string) error {
+			NewWindowFunc: func(session string, name string, dir string, initCmd string) (string, error) {
 				newWindowCreated = true
-				return nil
+				return "@1", nil
 			},
 			IsInsideTmuxFunc: func() bool { return true },
 			SwitchClientFunc: func(session string, window string) error { return nil },
@@ -338,9 +398,9 @@ func TestRename(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "main", Active: true}}, nil
 			},
-			NewWindowFunc: func(session string, name string, dir string, initCmd string) error {
+			NewWindowFunc: func(session string, name string, dir string, initCmd string) (string, error) {
 				capturedInitCmd = initCmd
-				return nil
+				return "@1", nil
 			},
 			IsInsideTmuxFunc: func() bool { return true },
 			SwitchClientFunc: func(session string, window string) error { return nil },
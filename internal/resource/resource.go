@@ -32,6 +32,13 @@ func WithShellCommands(m map[string]struct{}) Option {
 	return func(s *Service) { s.shellCommands = m }
 }
 
+// WithNoAttach makes New, Switch and Rename leave the client where it is:
+// they create or repair the branch's window without attaching or switching
+// to it.
+func WithNoAttach() Option {
+	return func(s *Service) { s.noAttach = true }
+}
+
 // Service provides resource operations backed by git and tmux clients.
 type Service struct {
 	git           git.Client
@@ -39,6 +46,7 @@ type Service struct {
 	cp            CommonParams
 	shellCommands map[string]struct{}
 	logger        Logger
+	noAttach      bool
 }
 
 // nopLogger discards all log messages.
@@ -66,9 +74,43 @@ type CommonParams struct {
 	WorktreeDir   string
 	DefaultBranch string
 	SessionName   string
+	Mapping       Mapping
 	Shell         string
 	CopyFiles     []string
 	PostNewHooks  []string
+	// Windows are extra windows created next to each branch window.
+	Windows []WindowSpec
+	// Layout lists the panes of a new branch window; empty for a single pane.
+	Layout []PaneSpec
+	// WindowOrder keeps managed windows sorted; empty leaves them where tmux puts them.
+	WindowOrder WindowOrder
+	// GroupedSessions connects through a session grouped with the target
+	// session, one per terminal, instead of the session itself.
+	GroupedSessions bool
+}
+
+// PaneSpec describes a pane of the branch window layout. The first pane is
+// the window's initial pane; each following pane is split from an earlier one.
+type PaneSpec struct {
+	// Target is the index of the earlier pane to split.
+	Target int
+	// Horizontal places the pane beside Target; otherwise below it.
+	Horizontal bool
+	// Size is the pane's size in lines/columns or a percentage; empty for half.
+	Size string
+	// Command runs in the pane when it is created; empty for a shell.
+	Command string
+	// Focus selects the pane once the layout is built.
+	Focus bool
+	// PostNew runs the post_new hooks in the pane instead of the first one.
+	PostNew bool
+}
+
+// WindowSpec describes an extra per-branch window, named "<branch>:<Name>".
+type WindowSpec struct {
+	Name string
+	// Command runs in the window when it is created; empty for a shell.
+	Command string
 }
 
 // WorktreePath returns the filesystem path for the given branch's worktree.
@@ -149,6 +191,7 @@ const (
 	OpNew OperationType = iota
 	OpSwitch
 	OpRename
+	OpAdopt
 )
 
 // String returns the string representation of the OperationType.
@@ -160,12 +203,14 @@ func (o OperationType) String() string {
 		return "switch"
 	case OpRename:
 		return "rename"
+	case OpAdopt:
+		return "adopt"
 	default:
 		return "unknown"
 	}
 }
 
-// OperationResult holds the outcome of a New, Switch, or Rename operation.
+// OperationResult holds the outcome of a New, Switch, Rename, or Adopt operation.
 // Currently used internally; will be surfaced in CLI output (e.g. --json flag).
 type OperationResult struct {
 	Operation    OperationType
@@ -176,10 +221,12 @@ type OperationResult struct {
 
 // State represents the combined state of a branch across git and tmux.
 type State struct {
-	Branch    string `json:"branch"`
-	Worktree  string `json:"worktree,omitempty"`
-	Window    bool   `json:"window"`
-	Active    bool   `json:"active"`
-	IsDefault bool   `json:"is_default"`
-	Status    Status `json:"status"`
+	Branch   string `json:"branch"`
+	Worktree string `json:"worktree,omitempty"`
+	Window   bool   `json:"window"`
+	// Windows lists the names of the branch's extra windows that exist.
+	Windows   []string `json:"windows,omitempty"`
+	Active    bool     `json:"active"`
+	IsDefault bool     `json:"is_default"`
+	Status    Status   `json:"status"`
 }
@@ -0,0 +1,128 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// SendParams selects the branch windows to type into and what to type.
+type SendParams struct {
+	// Branches selects the branches whose windows receive the keys; empty
+	// selects every branch with a window.
+	Branches []string
+	// Status keeps only the branches whose worktree is "dirty" or "clean";
+	// empty keeps all of them.
+	Status string
+	// Keys is typed as one command line, after clearing the prompt and
+	// followed by Enter, unless Raw is set.
+	Keys []string
+	// Raw sends Keys as they are, as tmux key names or literal text.
+	Raw bool
+	// AllPanes types into every pane, not only those running a shell.
+	AllPanes bool
+}
+
+// SendResult reports the panes of a branch that received the keys.
+type SendResult struct {
+	Branch string
+	Panes  int
+}
+
+// Send types the keys into the panes of the selected branch windows, extra
+// windows included. By default only panes running a shell receive them, so
+// that a command line is not typed into an editor. It returns a result for
+// every branch sent to, with zero panes when none of them qualified; a
+// branch that fails does not stop the others.
+func (s *Service) Send(ctx context.Context, p SendParams) ([]SendResult, error) {
+	if p.Status != "" && p.Status != "dirty" && p.Status != "clean" {
+		return nil, fmt.Errorf("unknown status '%s': use dirty or clean", p.Status)
+	}
+	if len(p.Keys) == 0 {
+		return nil, errors.New("nothing to send")
+	}
+	m := s.mapping()
+	windows := m.windows()
+	byBranch := make(map[string][]tmux.Window)
+	var branches []string
+	for _, w := range windows {
+		branch, _ := splitWindowName(w.Name)
+		if _, ok := byBranch[branch]; !ok {
+			branches = append(branches, branch)
+		}
+		byBranch[branch] = append(byBranch[branch], w)
+	}
+	if len(p.Branches) > 0 {
+		for _, b := range p.Branches {
+			if _, ok := byBranch[b]; !ok {
+				return nil, fmt.Errorf("'%s' has no window; open it with 'hashi switch %s'", b, b)
+			}
+		}
+		branches = p.Branches
+	}
+	if p.Status != "" {
+		var err error
+		if branches, err = s.filterDirty(windows, branches, p.Status == "dirty"); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := p.Keys
+	if !p.Raw {
+		keys = []string{"C-u", strings.Join(p.Keys, " "), "Enter"}
+	}
+	results := make([]SendResult, 0, len(branches))
+	var errs []error
+	for _, b := range branches {
+		n, err := s.sendToWindows(m.session(b), byBranch[b], keys, p.AllPanes)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sending to '%s': %w", b, err))
+			continue
+		}
+		results = append(results, SendResult{Branch: b, Panes: n})
+	}
+	return results, errors.Join(errs...)
+}
+
+// filterDirty keeps the branches whose worktree has uncommitted changes, or
+// those whose worktree has none when dirty is false.
+func (s *Service) filterDirty(windows []tmux.Window, branches []string, dirty bool) ([]string, error) {
+	states, err := s.collectState(windows)
+	if err != nil {
+		return nil, err
+	}
+	byBranch := toMap(states, func(st State) string { return st.Branch })
+	isDirty := s.dirtyWorktrees(states)
+	var kept []string
+	for _, b := range branches {
+		if isDirty[byBranch[b].Worktree] == dirty {
+			kept = append(kept, b)
+		}
+	}
+	return kept, nil
+}
+
+// sendToWindows sends keys to the panes of the windows and returns how many
+// received them. It stops at the first failure.
+func (s *Service) sendToWindows(session string, windows []tmux.Window, keys []string, allPanes bool) (int, error) {
+	n := 0
+	for _, w := range windows {
+		panes, err := s.tmux.ListPanes(session, w.ID)
+		if err != nil {
+			return n, fmt.Errorf("listing panes of '%s': %w", w.Name, err)
+		}
+		for _, p := range panes {
+			if !allPanes && !s.isShellCommand(p.Command) {
+				continue
+			}
+			if err := s.tmux.SendKeys(session, p.ID, keys...); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestSend(t *testing.T) {
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{
+					{ID: "@1", Name: "main"},
+					{ID: "@2", Name: "feature"},
+					{ID: "@3", Name: "feature:server"},
+					{ID: "@4", Name: "other"},
+				}, nil
+			},
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				switch window {
+				case "@2":
+					return []tmux.Pane{{ID: "%2", Command: "nvim"}, {ID: "%3", Command: "zsh"}}, nil
+				case "@3":
+					return []tmux.Pane{{ID: "%4", Command: "node"}}, nil
+				}
+				return []tmux.Pane{{ID: "%" + window[1:] + "0", Command: "bash"}}, nil
+			},
+			SendKeysFunc: func(session, window string, keys ...string) error { return nil },
+		}
+	}
+	sentTo := func(tm *tmux.ClientMock) []string {
+		var panes []string
+		for _, c := range tm.SendKeysCalls() {
+			panes = append(panes, c.Window)
+		}
+		return panes
+	}
+
+	t.Run("shell panes of every window", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		results, err := svc.Send(context.Background(), SendParams{Keys: []string{"git", "pull", "--rebase"}})
+		require.NoError(t, err)
+		assert.Equal(t, []SendResult{{Branch: "main", Panes: 1}, {Branch: "feature", Panes: 1}, {Branch: "other", Panes: 1}}, results)
+		assert.Equal(t, []string{"%10", "%3", "%40"}, sentTo(tm), "panes running other programs are skipped")
+		assert.Equal(t, []string{"C-u", "git pull --rebase", "Enter"}, tm.SendKeysCalls()[0].Keys)
+		assert.Equal(t, "org/repo", tm.SendKeysCalls()[0].Session)
+	})
+
+	t.Run("named branches, every pane, raw keys", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		results, err := svc.Send(context.Background(), SendParams{
+			Branches: []string{"feature"},
+			Keys:     []string{"C-c"},
+			Raw:      true,
+			AllPanes: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []SendResult{{Branch: "feature", Panes: 3}}, results)
+		assert.Equal(t, []string{"%2", "%3", "%4"}, sentTo(tm), "extra windows are included")
+		assert.Equal(t, []string{"C-c"}, tm.SendKeysCalls()[0].Keys)
+	})
+
+	t.Run("dirty worktrees", func(t *testing.T) {
+		tm := newTmux()
+		g := upDownGitMock()
+		g.HasUncommittedChangesFunc = func(path string) (bool, error) { return path == "/repo/.worktrees/other", nil }
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		results, err := svc.Send(context.Background(), SendParams{Status: "dirty", Keys: []string{"make"}})
+		require.NoError(t, err)
+		assert.Equal(t, []SendResult{{Branch: "other", Panes: 1}}, results)
+
+		tm = newTmux()
+		svc = newTestSvc(g, tm, WithCommonParams(defaultCP()))
+		results, err = svc.Send(context.Background(), SendParams{Branches: []string{"feature", "other"}, Status: "clean", Keys: []string{"make"}})
+		require.NoError(t, err)
+		assert.Equal(t, []SendResult{{Branch: "feature", Panes: 1}}, results)
+	})
+
+	t.Run("branch without a window", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		_, err := svc.Send(context.Background(), SendParams{Branches: []string{"feature", "idle"}, Keys: []string{"make"}})
+		assert.ErrorContains(t, err, "'idle' has no window")
+		assert.Empty(t, tm.SendKeysCalls(), "nothing is sent")
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		svc := newTestSvc(upDownGitMock(), newTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.Send(context.Background(), SendParams{Status: "stale", Keys: []string{"make"}})
+		assert.ErrorContains(t, err, "unknown status 'stale': use dirty or clean")
+	})
+
+	t.Run("a failure does not stop the others", func(t *testing.T) {
+		tm := newTmux()
+		tm.SendKeysFunc = func(session, window string, keys ...string) error {
+			if window == "%3" {
+				return fmt.Errorf("can't find pane: %%3")
+			}
+			return nil
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		results, err := svc.Send(context.Background(), SendParams{Keys: []string{"make"}})
+		assert.ErrorContains(t, err, "sending to 'feature': can't find pane: %3")
+		assert.Equal(t, []SendResult{{Branch: "main", Panes: 1}, {Branch: "other", Panes: 1}}, results)
+	})
+}
@@ -0,0 +1,156 @@
+package resource
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// BranchDetail is the detailed, read-only view of a single branch returned by Show.
+type BranchDetail struct {
+	State
+	Tip         string   `json:"tip,omitempty"`
+	Subject     string   `json:"subject,omitempty"`
+	Upstream    string   `json:"upstream,omitempty"`
+	Ahead       int      `json:"ahead"`
+	Behind      int      `json:"behind"`
+	DiskUsage   int64    `json:"disk_usage,omitempty"`
+	Dirty       []string `json:"dirty,omitempty"`
+	Untracked   []string `json:"untracked,omitempty"`
+	Stashes     int      `json:"stashes"`
+	PaneCommand string   `json:"pane_command,omitempty"`
+	// Suggestion is the hashi command that fixes an unhealthy status (e.g. "hashi new feature").
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Show gathers everything hashi knows about one branch.
+// Only the branch/worktree/window lookup can fail; the remaining details are
+// collected best-effort and left zero-valued when their git or tmux query fails.
+func (s *Service) Show(ctx context.Context, branch string) (*BranchDetail, error) {
+	if err := ValidateBranchName(branch); err != nil {
+		return nil, err
+	}
+
+	states, err := s.CollectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st := findBy(states, func(st State) string { return st.Branch }, branch)
+	if st == nil {
+		if err := s.requireBranchExists(branch); err != nil {
+			return nil, err
+		}
+		// Existing branch without worktree or window: not yet managed by hashi.
+		st = &State{Branch: branch, IsDefault: branch == s.cp.DefaultBranch, Status: StatusOK}
+	}
+
+	d := &BranchDetail{State: *st}
+	if cmd := st.Status.SuggestedCommand(); cmd != "" {
+		d.Suggestion = "hashi " + cmd + " " + branch
+	}
+
+	if st.Status != StatusOrphanedWindow && st.Status != StatusOrphanedWorktree {
+		s.collectBranchDetail(d)
+	}
+	if st.Worktree != "" {
+		s.collectWorktreeDetail(d)
+	}
+	if st.Window {
+		cmd, err := s.tmux.PaneCurrentCommand(s.mapping().session(branch), branch)
+		s.bestEffort("PaneCurrentCommand", err)
+		d.PaneCommand = cmd
+	}
+	return d, nil
+}
+
+// collectBranchDetail fills in the commit, upstream, and stash information.
+func (s *Service) collectBranchDetail(d *BranchDetail) {
+	var err error
+	d.Tip, d.Subject, err = s.git.BranchTip(d.Branch)
+	s.bestEffort("BranchTip", err)
+
+	d.Upstream, err = s.git.Upstream(d.Branch)
+	s.bestEffort("Upstream", err)
+	if d.Upstream != "" {
+		d.Ahead, d.Behind, err = s.git.AheadBehind(d.Branch, d.Upstream)
+		s.bestEffort("AheadBehind", err)
+	}
+
+	stashes, err := s.git.ListStashes()
+	s.bestEffort("ListStashes", err)
+	d.Stashes = countBranchStashes(stashes, d.Branch)
+}
+
+// collectWorktreeDetail fills in the changed files and disk usage of the worktree.
+func (s *Service) collectWorktreeDetail(d *BranchDetail) {
+	st, err := s.git.WorktreeStatus(d.Worktree)
+	s.bestEffort("WorktreeStatus", err)
+	d.Dirty, d.Untracked = st.Dirty, st.Untracked
+
+	d.DiskUsage, err = diskUsage(d.Worktree)
+	s.bestEffort("diskUsage", err)
+}
+
+// previewCommits is the number of recent commits in a BranchPreview.
+const previewCommits = 10
+
+// BranchPreview is a brief look at a branch, cheaper to collect than a BranchDetail.
+type BranchPreview struct {
+	// Commits lists the latest commits as "<short hash> <subject>", newest first.
+	Commits []string
+	// Changes lists the worktree's changed files in `git status --short` form.
+	Changes []string
+}
+
+// Preview returns the recent commits of st's branch and the uncommitted
+// changes of its worktree. It is best-effort: a failed query leaves its part empty.
+func (s *Service) Preview(st State) BranchPreview {
+	var p BranchPreview
+	var err error
+	if st.Status != StatusOrphanedWindow && st.Status != StatusOrphanedWorktree {
+		p.Commits, err = s.git.RecentCommits(st.Branch, previewCommits)
+		s.bestEffort("RecentCommits", err)
+	}
+	if st.Worktree != "" {
+		ws, err := s.git.WorktreeStatus(st.Worktree)
+		s.bestEffort("WorktreeStatus", err)
+		p.Changes = ws.Dirty
+		for _, f := range ws.Untracked {
+			p.Changes = append(p.Changes, "?? "+f)
+		}
+	}
+	return p
+}
+
+// countBranchStashes counts stash entries created on branch.
+// git records them as "WIP on <branch>: ..." or "On <branch>: ...".
+func countBranchStashes(subjects []string, branch string) int {
+	n := 0
+	for _, subj := range subjects {
+		if strings.HasPrefix(subj, "WIP on "+branch+":") || strings.HasPrefix(subj, "On "+branch+":") {
+			n++
+		}
+	}
+	return n
+}
+
+// diskUsage returns the total size in bytes of regular files under dir.
+func diskUsage(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
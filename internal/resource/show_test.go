@@ -0,0 +1,221 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// showGitMock returns a git mock with a single managed worktree for "feature" at wtPath.
+func showGitMock(wtPath string) *git.ClientMock {
+	return &git.ClientMock{
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: wtPath, Branch: "feature"},
+			}, nil
+		},
+		ListBranchesFunc: mockListBranches("main", "feature", "plain"),
+		BranchExistsFunc: mockBranchExists("main", "feature", "plain"),
+		BranchTipFunc: func(branch string) (string, string, error) {
+			return "abc123", "Add feature", nil
+		},
+		UpstreamFunc: func(branch string) (string, error) { return "origin/" + branch, nil },
+		AheadBehindFunc: func(branch, upstream string) (int, int, error) {
+			return 2, 1, nil
+		},
+		WorktreeStatusFunc: func(path string) (git.WorktreeStatus, error) {
+			return git.WorktreeStatus{Dirty: []string{" M a.go"}, Untracked: []string{"b.txt"}}, nil
+		},
+		ListStashesFunc: func() ([]string, error) {
+			return []string{"WIP on feature: abc msg", "On feature: saved", "On main: other"}, nil
+		},
+	}
+}
+
+func TestShow(t *testing.T) {
+	t.Run("collects all details", func(t *testing.T) {
+		wtPath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(wtPath, "file"), make([]byte, 100), 0644))
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "feature", Active: true}}, nil
+		}
+		tm.PaneCurrentCommandFunc = func(session, window string) (string, error) { return "nvim", nil }
+		svc := newTestSvc(showGitMock(wtPath), tm, WithCommonParams(defaultCP()))
+
+		d, err := svc.Show(context.Background(), "feature")
+		require.NoError(t, err)
+		assert.Equal(t, "feature", d.Branch)
+		assert.Equal(t, wtPath, d.Worktree)
+		assert.True(t, d.Window)
+		assert.True(t, d.Active)
+		assert.Equal(t, StatusOK, d.Status)
+		assert.Equal(t, "abc123", d.Tip)
+		assert.Equal(t, "Add feature", d.Subject)
+		assert.Equal(t, "origin/feature", d.Upstream)
+		assert.Equal(t, 2, d.Ahead)
+		assert.Equal(t, 1, d.Behind)
+		assert.Equal(t, int64(100), d.DiskUsage)
+		assert.Equal(t, []string{" M a.go"}, d.Dirty)
+		assert.Equal(t, []string{"b.txt"}, d.Untracked)
+		assert.Equal(t, 2, d.Stashes)
+		assert.Equal(t, "nvim", d.PaneCommand)
+		assert.Empty(t, d.Suggestion)
+	})
+
+	t.Run("unhealthy status has suggestion", func(t *testing.T) {
+		g := showGitMock("/repo/.worktrees/feature")
+		g.ListWorktreesFunc = func() ([]git.Worktree, error) {
+			return []git.Worktree{{Path: "/repo", Branch: "main", IsMain: true}}, nil
+		}
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "feature"}}, nil
+		}
+		tm.PaneCurrentCommandFunc = func(session, window string) (string, error) { return "zsh", nil }
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+
+		d, err := svc.Show(context.Background(), "feature")
+		require.NoError(t, err)
+		assert.Equal(t, StatusWorktreeMissing, d.Status)
+		assert.Equal(t, "hashi new feature", d.Suggestion)
+		assert.Empty(t, g.WorktreeStatusCalls())
+	})
+
+	t.Run("branch without worktree or window", func(t *testing.T) {
+		g := showGitMock("/repo/.worktrees/feature")
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+		d, err := svc.Show(context.Background(), "plain")
+		require.NoError(t, err)
+		assert.Equal(t, "plain", d.Branch)
+		assert.Empty(t, d.Worktree)
+		assert.False(t, d.Window)
+		assert.Equal(t, "abc123", d.Tip)
+	})
+
+	t.Run("orphaned worktree skips branch details", func(t *testing.T) {
+		g := showGitMock(t.TempDir())
+		g.ListBranchesFunc = mockListBranches("main")
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+		d, err := svc.Show(context.Background(), "feature")
+		require.NoError(t, err)
+		assert.Equal(t, StatusOrphanedWorktree, d.Status)
+		assert.Empty(t, g.BranchTipCalls())
+		assert.Len(t, g.WorktreeStatusCalls(), 1)
+	})
+
+	t.Run("no upstream skips ahead/behind", func(t *testing.T) {
+		g := showGitMock(t.TempDir())
+		g.UpstreamFunc = func(branch string) (string, error) { return "", nil }
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+		d, err := svc.Show(context.Background(), "feature")
+		require.NoError(t, err)
+		assert.Empty(t, d.Upstream)
+		assert.Empty(t, g.AheadBehindCalls())
+	})
+
+	t.Run("detail errors are best-effort", func(t *testing.T) {
+		g := showGitMock(t.TempDir())
+		g.BranchTipFunc = func(branch string) (string, string, error) { return "", "", fmt.Errorf("fail") }
+		g.WorktreeStatusFunc = func(path string) (git.WorktreeStatus, error) { return git.WorktreeStatus{}, fmt.Errorf("fail") }
+		g.ListStashesFunc = func() ([]string, error) { return nil, fmt.Errorf("fail") }
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+		d, err := svc.Show(context.Background(), "feature")
+		require.NoError(t, err)
+		assert.Empty(t, d.Tip)
+		assert.Zero(t, d.Stashes)
+	})
+
+	t.Run("unknown branch", func(t *testing.T) {
+		svc := newTestSvc(showGitMock("/x"), stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.Show(context.Background(), "nope")
+		var notFound *BranchNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("invalid branch name", func(t *testing.T) {
+		svc := newTestSvc(&git.ClientMock{}, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.Show(context.Background(), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("CollectState error", func(t *testing.T) {
+		g := &git.ClientMock{
+			ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, fmt.Errorf("git error") },
+		}
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		_, err := svc.Show(context.Background(), "feature")
+		assert.Error(t, err)
+	})
+}
+
+func TestPreview(t *testing.T) {
+	g := showGitMock("/repo/.worktrees/feature")
+	g.RecentCommitsFunc = func(branch string, n int) ([]string, error) {
+		return []string{"abc1234 Add feature", "def5678 Initial commit"}, nil
+	}
+	svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+	t.Run("worktree", func(t *testing.T) {
+		p := svc.Preview(State{Branch: "feature", Worktree: "/repo/.worktrees/feature"})
+		assert.Equal(t, []string{"abc1234 Add feature", "def5678 Initial commit"}, p.Commits)
+		assert.Equal(t, []string{" M a.go", "?? b.txt"}, p.Changes)
+		assert.Equal(t, previewCommits, g.RecentCommitsCalls()[0].N)
+	})
+
+	t.Run("no worktree", func(t *testing.T) {
+		p := svc.Preview(State{Branch: "plain"})
+		assert.Len(t, p.Commits, 2)
+		assert.Empty(t, p.Changes)
+	})
+
+	t.Run("orphaned worktree", func(t *testing.T) {
+		calls := len(g.RecentCommitsCalls())
+		p := svc.Preview(State{Branch: "gone", Worktree: "/repo/.worktrees/gone", Status: StatusOrphanedWorktree})
+		assert.Empty(t, p.Commits)
+		assert.Len(t, p.Changes, 2)
+		assert.Len(t, g.RecentCommitsCalls(), calls)
+	})
+
+	t.Run("git error", func(t *testing.T) {
+		g := showGitMock("/repo/.worktrees/feature")
+		g.RecentCommitsFunc = func(branch string, n int) ([]string, error) { return nil, fmt.Errorf("git error") }
+		p := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP())).Preview(State{Branch: "feature"})
+		assert.Empty(t, p.Commits)
+	})
+}
+
+func TestCountBranchStashes(t *testing.T) {
+	subjects := []string{"WIP on feat: a", "On feat: b", "On feat/x: c", "WIP on main: d"}
+	assert.Equal(t, 2, countBranchStashes(subjects, "feat"))
+	assert.Equal(t, 1, countBranchStashes(subjects, "feat/x"))
+	assert.Equal(t, 0, countBranchStashes(nil, "feat"))
+}
+
+func TestDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), make([]byte, 10), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 20), 0644))
+
+	n, err := diskUsage(dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), n)
+
+	_, err = diskUsage(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,199 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// Snapshot records the managed windows of a repository, for Restore to
+// recreate them after the tmux server is gone.
+type Snapshot struct {
+	Saved   time.Time        `json:"saved"`
+	Windows []WindowSnapshot `json:"windows"`
+}
+
+// WindowSnapshot records a branch window or one of its extra windows.
+type WindowSnapshot struct {
+	Branch string `json:"branch"`
+	// Name is the window's name: the branch, or "<branch>:<extra>".
+	Name string `json:"name"`
+	// Layout is the window's tmux layout string.
+	Layout string         `json:"layout"`
+	Panes  []PaneSnapshot `json:"panes"`
+}
+
+// PaneSnapshot records a pane of a window.
+type PaneSnapshot struct {
+	Dir string `json:"dir"`
+	// Command is the command line running in the pane; empty for a shell.
+	Command string `json:"command,omitempty"`
+	Active  bool   `json:"active,omitempty"`
+}
+
+// Snapshot records the windows of every branch that still exists, with their
+// panes' layout, directory and running command. A pane whose command line
+// cannot be read records the name of its program instead.
+func (s *Service) Snapshot(ctx context.Context) (*Snapshot, error) {
+	windows := s.mapping().windows()
+	states, err := s.collectState(windows)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]bool)
+	for _, st := range states {
+		live[st.Branch] = st.Status != StatusOrphanedWindow && st.Status != StatusOrphanedWorktree
+	}
+
+	snap := &Snapshot{Saved: time.Now()}
+	for _, w := range windows {
+		branch, _ := splitWindowName(w.Name)
+		if !live[branch] {
+			continue
+		}
+		panes, err := s.tmux.ListPanes(s.mapping().session(branch), w.Name)
+		if err != nil {
+			return nil, fmt.Errorf("listing panes of '%s': %w", w.Name, err)
+		}
+		ws := WindowSnapshot{Branch: branch, Name: w.Name, Layout: w.Layout}
+		for _, p := range panes {
+			ws.Panes = append(ws.Panes, PaneSnapshot{Dir: p.Dir, Command: s.paneCommand(p), Active: p.Active})
+		}
+		snap.Windows = append(snap.Windows, ws)
+	}
+	return snap, nil
+}
+
+// paneCommand returns the command line running in the pane, or "" for a shell.
+func (s *Service) paneCommand(p tmux.Pane) string {
+	if s.isShellCommand(p.Command) {
+		return ""
+	}
+	cmd, err := s.tmux.ForegroundCommand(p.ID)
+	s.bestEffort("ForegroundCommand", err)
+	if cmd == "" {
+		return p.Command
+	}
+	return cmd
+}
+
+// Restore recreates the windows of the snapshot that are not open, with
+// their panes in the recorded layout and directories. With rerun, the
+// recorded commands are typed into their panes; otherwise the panes start a
+// shell. Windows of branches without a healthy worktree are skipped. It
+// returns the names of the windows created; a window that fails does not
+// stop the others.
+func (s *Service) Restore(ctx context.Context, snap *Snapshot, rerun bool) ([]string, error) {
+	windows := s.mapping().windows()
+	states, err := s.collectState(windows)
+	if err != nil {
+		return nil, err
+	}
+	worktrees := make(map[string]string)
+	for _, st := range states {
+		if st.Worktree != "" && st.Status.IsHealthy() {
+			worktrees[st.Branch] = st.Worktree
+		}
+	}
+	open := toSet(windowNames(windows))
+
+	var restored []string
+	var errs []error
+	for _, ws := range snap.Windows {
+		wt, ok := worktrees[ws.Branch]
+		if _, exists := open[ws.Name]; !ok || exists {
+			continue
+		}
+		if err := s.restoreWindow(ws, wt, rerun); err != nil {
+			errs = append(errs, fmt.Errorf("restoring '%s': %w", ws.Name, err))
+			continue
+		}
+		restored = append(restored, ws.Name)
+	}
+	if len(restored) > 0 {
+		s.keepOrder()
+	}
+	return restored, errors.Join(errs...)
+}
+
+// restoreWindow creates the window in the session of its branch. The panes
+// are best-effort: a failed split keeps the panes created so far, in tmux's
+// default arrangement.
+func (s *Service) restoreWindow(ws WindowSnapshot, worktree string, rerun bool) error {
+	panes := ws.Panes
+	if len(panes) == 0 {
+		panes = []PaneSnapshot{{Dir: worktree}}
+	}
+	session := s.mapping().session(ws.Branch)
+	ok, err := s.tmux.HasSession(session)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	dir := restoreDir(panes[0].Dir, worktree)
+	var id string
+	if ok {
+		id, err = s.tmux.NewWindow(session, ws.Name, dir, "")
+	} else {
+		id, err = s.tmux.NewSession(session, ws.Name, dir, "")
+	}
+	if err != nil {
+		return err
+	}
+	// The window is tagged with the directory it was created in; the first
+	// pane may have been in a subdirectory of the worktree.
+	if dir != worktree {
+		s.bestEffort("SetWindowOption", s.tmux.SetWindowOption(session, id, tmux.OptionWorktree, worktree))
+	}
+
+	created, err := s.tmux.ListPanes(session, ws.Name)
+	if err != nil || len(created) == 0 {
+		s.bestEffort("ListPanes", err)
+		return nil
+	}
+	ids := []string{created[0].ID}
+	for _, p := range panes[1:] {
+		id, err := s.tmux.SplitPane(ids[len(ids)-1], tmux.SplitOptions{Dir: restoreDir(p.Dir, worktree)})
+		if err != nil {
+			s.bestEffort("SplitPane", err)
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) > 1 && len(ids) == len(panes) && ws.Layout != "" {
+		s.bestEffort("SelectLayout", s.tmux.SelectLayout(session, ws.Name, ws.Layout))
+	}
+	for i, id := range ids {
+		if rerun && panes[i].Command != "" {
+			s.bestEffort("SendKeys", s.tmux.SendKeys(session, id, panes[i].Command, "Enter"))
+		}
+		if panes[i].Active && i > 0 {
+			s.bestEffort("SelectPane", s.tmux.SelectPane(id))
+		}
+	}
+	return nil
+}
+
+// restoreDir returns the recorded directory of a pane if it still exists,
+// or the branch's worktree.
+func restoreDir(dir, worktree string) string {
+	if dir == "" {
+		return worktree
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return worktree
+	}
+	return dir
+}
+
+// windowNames returns the names of windows.
+func windowNames(windows []tmux.Window) []string {
+	names := make([]string, len(windows))
+	for i, w := range windows {
+		names[i] = w.Name
+	}
+	return names
+}
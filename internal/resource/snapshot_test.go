@@ -0,0 +1,210 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func TestSnapshot(t *testing.T) {
+	tm := &tmux.ClientMock{
+		HasSessionFunc: func(name string) (bool, error) { return true, nil },
+		ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{
+				{ID: "@1", Name: "main", Layout: "aaaa,80x24,0,0,1"},
+				{ID: "@2", Name: "feature", Layout: "bbbb,80x24,0,0{40x24,0,0,2,39x24,41,0,3}"},
+				{ID: "@3", Name: "feature:server"},
+				{ID: "@4", Name: "scratch"},
+			}, nil
+		},
+		ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+			switch window {
+			case "feature":
+				return []tmux.Pane{
+					{ID: "%2", Command: "zsh", Dir: "/repo/.worktrees/feature"},
+					{ID: "%3", Active: true, Command: "nvim", Dir: "/repo/.worktrees/feature/src"},
+				}, nil
+			case "feature:server":
+				return []tmux.Pane{{ID: "%4", Active: true, Command: "node", Dir: "/repo/.worktrees/feature"}}, nil
+			}
+			return []tmux.Pane{{ID: "%1", Active: true, Command: "zsh", Dir: "/repo"}}, nil
+		},
+		ForegroundCommandFunc: func(pane string) (string, error) {
+			if pane == "%3" {
+				return "nvim .", nil
+			}
+			return "", fmt.Errorf("ps failed")
+		},
+	}
+	svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+	snap, err := svc.Snapshot(context.Background())
+	require.NoError(t, err)
+	assert.False(t, snap.Saved.IsZero())
+	assert.Equal(t, []WindowSnapshot{
+		{Branch: "main", Name: "main", Layout: "aaaa,80x24,0,0,1", Panes: []PaneSnapshot{{Dir: "/repo", Active: true}}},
+		{Branch: "feature", Name: "feature", Layout: "bbbb,80x24,0,0{40x24,0,0,2,39x24,41,0,3}", Panes: []PaneSnapshot{
+			{Dir: "/repo/.worktrees/feature"},
+			{Dir: "/repo/.worktrees/feature/src", Command: "nvim .", Active: true},
+		}},
+		{Branch: "feature", Name: "feature:server", Panes: []PaneSnapshot{
+			{Dir: "/repo/.worktrees/feature", Command: "node", Active: true},
+		}},
+	}, snap.Windows, "orphaned windows are skipped; a command line that cannot be read falls back to the program")
+	require.Len(t, tm.ForegroundCommandCalls(), 2, "shell panes are not inspected")
+
+	t.Run("list-panes fails", func(t *testing.T) {
+		tm.ListPanesFunc = func(session, window string) ([]tmux.Pane, error) { return nil, fmt.Errorf("no server") }
+		_, err := svc.Snapshot(context.Background())
+		assert.ErrorContains(t, err, "listing panes of 'main': no server")
+	})
+}
+
+func TestRestore(t *testing.T) {
+	src := t.TempDir()
+	snap := &Snapshot{Windows: []WindowSnapshot{
+		{Branch: "main", Name: "main", Panes: []PaneSnapshot{{Dir: "/repo"}}},
+		{Branch: "feature", Name: "feature", Layout: "bbbb,80x24,0,0{40x24,0,0,2,39x24,41,0,3}", Panes: []PaneSnapshot{
+			{Dir: "/gone"},
+			{Dir: src, Command: "nvim .", Active: true},
+		}},
+		{Branch: "feature", Name: "feature:server", Panes: []PaneSnapshot{{Dir: "/gone", Command: "npm run dev"}}},
+		{Branch: "idle", Name: "idle", Panes: []PaneSnapshot{{Dir: "/repo"}}},
+		{Branch: "gone", Name: "gone", Panes: []PaneSnapshot{{Dir: "/repo"}}},
+	}}
+	newTmux := func() *tmux.ClientMock {
+		panes := map[string]int{}
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}}, nil
+			},
+			NewWindowFunc: func(session, name, dir, initCmd string) (string, error) { return "@9", nil },
+			ListPanesFunc: func(session, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%" + window}}, nil
+			},
+			SplitPaneFunc: func(pane string, opts tmux.SplitOptions) (string, error) {
+				panes[pane]++
+				return fmt.Sprintf("%s.%d", pane, panes[pane]), nil
+			},
+			SelectLayoutFunc: func(session, window, layout string) error { return nil },
+			SelectPaneFunc:   func(pane string) error { return nil },
+			SendKeysFunc:     func(session, window string, keys ...string) error { return nil },
+		}
+	}
+
+	t.Run("recreates missing windows", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		restored, err := svc.Restore(context.Background(), snap, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature", "feature:server"}, restored, "open windows and branches without a worktree are skipped")
+
+		require.Len(t, tm.NewWindowCalls(), 2)
+		assert.Equal(t, "/repo/.worktrees/feature", tm.NewWindowCalls()[0].Dir, "missing directories fall back to the worktree")
+		assert.Empty(t, tm.NewWindowCalls()[0].InitCmd)
+		require.Len(t, tm.SplitPaneCalls(), 1)
+		assert.Equal(t, "%feature", tm.SplitPaneCalls()[0].Pane)
+		assert.Equal(t, src, tm.SplitPaneCalls()[0].Opts.Dir)
+		require.Len(t, tm.SelectLayoutCalls(), 1)
+		assert.Equal(t, "bbbb,80x24,0,0{40x24,0,0,2,39x24,41,0,3}", tm.SelectLayoutCalls()[0].Layout)
+		require.Len(t, tm.SelectPaneCalls(), 1)
+		assert.Equal(t, "%feature.1", tm.SelectPaneCalls()[0].Pane)
+		assert.Empty(t, tm.SendKeysCalls(), "commands run only on request")
+	})
+
+	t.Run("tags the worktree when the first pane was in a subdirectory", func(t *testing.T) {
+		cp := defaultCP()
+		cp.RepoRoot = t.TempDir()
+		worktree := cp.WorktreePath("feature")
+		sub := filepath.Join(worktree, "src")
+		require.NoError(t, os.MkdirAll(sub, 0755))
+		tm := newTmux()
+		tm.SetWindowOptionFunc = func(session, window, key, value string) error { return nil }
+		g := upDownGitMock()
+		g.ListWorktreesFunc = func() ([]git.Worktree, error) {
+			return []git.Worktree{{Path: cp.RepoRoot, Branch: "main", IsMain: true}, {Path: worktree, Branch: "feature"}}, nil
+		}
+		svc := newTestSvc(g, tm, WithCommonParams(cp))
+
+		_, err := svc.Restore(context.Background(), &Snapshot{Windows: []WindowSnapshot{
+			{Branch: "feature", Name: "feature", Panes: []PaneSnapshot{{Dir: sub}}},
+		}}, false)
+		require.NoError(t, err)
+		require.Len(t, tm.NewWindowCalls(), 1)
+		assert.Equal(t, sub, tm.NewWindowCalls()[0].Dir)
+		require.Len(t, tm.SetWindowOptionCalls(), 1)
+		assert.Equal(t, "@9", tm.SetWindowOptionCalls()[0].Window)
+		assert.Equal(t, tmux.OptionWorktree, tm.SetWindowOptionCalls()[0].Key)
+		assert.Equal(t, worktree, tm.SetWindowOptionCalls()[0].Value)
+	})
+
+	t.Run("rerun", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		_, err := svc.Restore(context.Background(), snap, true)
+		require.NoError(t, err)
+		require.Len(t, tm.SendKeysCalls(), 2)
+		assert.Equal(t, "%feature.1", tm.SendKeysCalls()[0].Window)
+		assert.Equal(t, []string{"nvim .", "Enter"}, tm.SendKeysCalls()[0].Keys)
+		assert.Equal(t, []string{"npm run dev", "Enter"}, tm.SendKeysCalls()[1].Keys)
+	})
+
+	t.Run("failed split keeps the default arrangement", func(t *testing.T) {
+		tm := newTmux()
+		tm.SplitPaneFunc = func(pane string, opts tmux.SplitOptions) (string, error) {
+			return "", fmt.Errorf("no space for new pane")
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		restored, err := svc.Restore(context.Background(), snap, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature", "feature:server"}, restored)
+		assert.Empty(t, tm.SelectLayoutCalls())
+	})
+
+	t.Run("a failure does not stop the others", func(t *testing.T) {
+		tm := newTmux()
+		tm.NewWindowFunc = func(session, name, dir, initCmd string) (string, error) {
+			if name == "feature" {
+				return "", fmt.Errorf("new-window failed")
+			}
+			return "@9", nil
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		restored, err := svc.Restore(context.Background(), snap, false)
+		assert.ErrorContains(t, err, "restoring 'feature': new-window failed")
+		assert.Equal(t, []string{"feature:server"}, restored)
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		sessions := map[string]bool{}
+		tm := newTmux()
+		tm.ListAllWindowsFunc = func() ([]tmux.Window, error) { return nil, nil }
+		tm.HasSessionFunc = func(name string) (bool, error) { return sessions[name], nil }
+		tm.NewSessionFunc = func(name, windowName, dir, initCmd string) (string, error) {
+			sessions[name] = true
+			return "@9", nil
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(sessionCP()))
+
+		restored, err := svc.Restore(context.Background(), snap, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "feature", "feature:server"}, restored)
+		require.Len(t, tm.NewSessionCalls(), 2)
+		assert.Equal(t, "org/repo/feature", tm.NewSessionCalls()[1].Name)
+		require.Len(t, tm.NewWindowCalls(), 1)
+		assert.Equal(t, "org/repo/feature", tm.NewWindowCalls()[0].Session)
+		assert.Equal(t, "feature:server", tm.NewWindowCalls()[0].Name)
+	})
+}
@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// dirtyCheckWorkers bounds the number of worktrees checked for uncommitted
+// changes at once.
+const dirtyCheckWorkers = 8
+
+// Summary is a compact overview of the repository's managed branches.
+type Summary struct {
+	Branches  int
+	Unhealthy int
+	// Dirty counts worktrees with uncommitted changes.
+	Dirty int
+	// Current is the branch of the active window; empty if no window is active.
+	Current  string
+	Upstream string
+	Ahead    int
+	Behind   int
+}
+
+// Summarize builds a Summary from CollectState, checking the worktrees for
+// uncommitted changes in one concurrent batch. When several branch windows are active, as with
+// mapping: session and several attached sessions, Current is the branch whose
+// worktree contains dir. Per-branch queries are best-effort.
+func (s *Service) Summarize(ctx context.Context, dir string) (*Summary, error) {
+	states, err := s.CollectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := &Summary{Branches: len(states)}
+	dirty := s.dirtyWorktrees(states)
+	var current *State
+	for i, st := range states {
+		if !st.Status.IsHealthy() {
+			sum.Unhealthy++
+		}
+		if dirty[st.Worktree] {
+			sum.Dirty++
+		}
+		if st.Active && (current == nil || closerTo(dir, st, *current)) {
+			current = &states[i]
+		}
+	}
+	if current == nil {
+		return sum, nil
+	}
+
+	sum.Current = current.Branch
+	if current.Status == StatusOrphanedWindow {
+		return sum, nil
+	}
+	sum.Upstream, err = s.git.Upstream(current.Branch)
+	s.bestEffort("Upstream", err)
+	if sum.Upstream != "" {
+		sum.Ahead, sum.Behind, err = s.git.AheadBehind(current.Branch, sum.Upstream)
+		s.bestEffort("AheadBehind", err)
+	}
+	return sum, nil
+}
+
+// dirtyWorktrees returns the set of worktrees of states that have uncommitted
+// changes. The worktrees are checked concurrently, dirtyCheckWorkers at a
+// time, so that the batch takes about as long as the slowest check instead
+// of the sum of all of them. A worktree whose check fails counts as clean.
+func (s *Service) dirtyWorktrees(states []State) map[string]bool {
+	paths := make(chan string)
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		dirty = make(map[string]bool)
+	)
+	for range min(dirtyCheckWorkers, len(states)) {
+		wg.Go(func() {
+			for path := range paths {
+				ok, err := s.git.HasUncommittedChanges(path)
+				s.bestEffort("HasUncommittedChanges", err)
+				if ok {
+					mu.Lock()
+					dirty[path] = true
+					mu.Unlock()
+				}
+			}
+		})
+	}
+	seen := make(map[string]bool, len(states))
+	for _, st := range states {
+		if st.Worktree != "" && !seen[st.Worktree] {
+			seen[st.Worktree] = true
+			paths <- st.Worktree
+		}
+	}
+	close(paths)
+	wg.Wait()
+	return dirty
+}
+
+// closerTo reports whether a's worktree contains dir more closely than b's.
+func closerTo(dir string, a, b State) bool {
+	if !containsDir(a.Worktree, dir) {
+		return false
+	}
+	return !containsDir(b.Worktree, dir) || len(a.Worktree) > len(b.Worktree)
+}
+
+// containsDir reports whether dir is root or inside it.
+func containsDir(root, dir string) bool {
+	if root == "" || dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,128 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+func summaryGitMock() *git.ClientMock {
+	return &git.ClientMock{
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feature", Branch: "feature"},
+				{Path: "/repo/.worktrees/gone", Branch: "gone"},
+			}, nil
+		},
+		ListBranchesFunc: mockListBranches("main", "feature"),
+		HasUncommittedChangesFunc: func(path string) (bool, error) {
+			return path == "/repo/.worktrees/feature", nil
+		},
+		UpstreamFunc: func(branch string) (string, error) { return "origin/" + branch, nil },
+		AheadBehindFunc: func(branch, upstream string) (int, int, error) {
+			return 2, 1, nil
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("counts branches and the active branch", func(t *testing.T) {
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "main"}, {Name: "feature", Active: true}}, nil
+		}
+		svc := newTestSvc(summaryGitMock(), tm, WithCommonParams(defaultCP()))
+
+		sum, err := svc.Summarize(context.Background(), "/repo")
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{
+			Branches:  3,
+			Unhealthy: 1,
+			Dirty:     1,
+			Current:   "feature",
+			Upstream:  "origin/feature",
+			Ahead:     2,
+			Behind:    1,
+		}, sum)
+	})
+
+	t.Run("prefers the active branch containing dir", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			ListAllWindowsFunc: func() ([]tmux.Window, error) {
+				return []tmux.Window{
+					{Name: "feature", Session: "org/repo/feature", Active: true, SessionAttached: true},
+					{Name: "main", Session: "org/repo/main", Active: true, SessionAttached: true},
+				}, nil
+			},
+		}
+		svc := newTestSvc(summaryGitMock(), tm, WithCommonParams(sessionCP()))
+
+		sum, err := svc.Summarize(context.Background(), "/repo/src")
+		require.NoError(t, err)
+		assert.Equal(t, "main", sum.Current)
+
+		sum, err = svc.Summarize(context.Background(), "/repo/.worktrees/feature")
+		require.NoError(t, err)
+		assert.Equal(t, "feature", sum.Current)
+	})
+
+	t.Run("no active window", func(t *testing.T) {
+		g := summaryGitMock()
+		svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+		sum, err := svc.Summarize(context.Background(), "/repo")
+		require.NoError(t, err)
+		assert.Empty(t, sum.Current)
+		assert.Empty(t, g.UpstreamCalls())
+	})
+
+	t.Run("git errors are best-effort", func(t *testing.T) {
+		g := summaryGitMock()
+		g.HasUncommittedChangesFunc = func(path string) (bool, error) { return false, fmt.Errorf("fail") }
+		g.UpstreamFunc = func(branch string) (string, error) { return "", fmt.Errorf("fail") }
+		tm := stubTmux()
+		tm.HasSessionFunc = func(name string) (bool, error) { return true, nil }
+		tm.ListWindowsFunc = func(session string) ([]tmux.Window, error) {
+			return []tmux.Window{{Name: "main", Active: true}}, nil
+		}
+		svc := newTestSvc(g, tm, WithCommonParams(defaultCP()))
+		sum, err := svc.Summarize(context.Background(), "/repo")
+		require.NoError(t, err)
+		assert.Zero(t, sum.Dirty)
+		assert.Equal(t, "main", sum.Current)
+		assert.Empty(t, g.AheadBehindCalls())
+	})
+
+	t.Run("collect error", func(t *testing.T) {
+		g := &git.ClientMock{ListWorktreesFunc: func() ([]git.Worktree, error) { return nil, fmt.Errorf("fail") }}
+		_, err := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP())).Summarize(context.Background(), "/repo")
+		assert.Error(t, err)
+	})
+}
+
+func TestDirtyWorktrees(t *testing.T) {
+	var states []State
+	for i := range 20 {
+		states = append(states, State{Branch: fmt.Sprintf("b%d", i), Worktree: fmt.Sprintf("/repo/.worktrees/b%d", i)})
+	}
+	states = append(states, State{Branch: "no-worktree"}, states[0])
+	g := &git.ClientMock{
+		HasUncommittedChangesFunc: func(path string) (bool, error) {
+			if path == "/repo/.worktrees/b3" {
+				return false, fmt.Errorf("git error")
+			}
+			return path == "/repo/.worktrees/b1" || path == "/repo/.worktrees/b7", nil
+		},
+	}
+	svc := newTestSvc(g, stubTmux(), WithCommonParams(defaultCP()))
+
+	dirty := svc.dirtyWorktrees(states)
+	assert.Equal(t, map[string]bool{"/repo/.worktrees/b1": true, "/repo/.worktrees/b7": true}, dirty)
+	assert.Len(t, g.HasUncommittedChangesCalls(), 20, "each worktree is checked once")
+}
@@ -33,9 +33,7 @@ func (s *Service) Switch(ctx context.Context, p SwitchParams) (*OperationResult,
 	}
 
 	initCmd := s.buildInitCmd(wtCreated)
-	if err := s.ensureTmux(s.cp.SessionName, p.Branch, wtPath, initCmd); err != nil {
-		return nil, fmt.Errorf("ensuring tmux: %w", err)
-	}
-
-	return s.finalizeOperation(OpSwitch, p.Branch, wtPath, wtCreated)
+	return s.finalizeOperation(OpSwitch, p.Branch, wtPath, wtCreated, initCmd, func(err error) error {
+		return fmt.Errorf("ensuring tmux: %w", err)
+	})
 }
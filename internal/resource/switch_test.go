@@ -29,8 +29,8 @@ func TestSwitch(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "feature", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "zsh", nil
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				return nil
@@ -47,6 +47,26 @@ func TestSwitch(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("no attach", func(t *testing.T) {
+		g := &git.ClientMock{
+			BranchExistsFunc: mockBranchExists("feature"),
+			ListWorktreesFunc: func() ([]git.Worktree, error) {
+				return []git.Worktree{{Path: "/repo/.worktrees/feature", Branch: "feature"}}, nil
+			},
+		}
+		tm := &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return false, nil },
+			NewSessionFunc: func(name, windowName, dir, initCmd string) (string, error) { return "@1", nil },
+		}
+
+		cp := CommonParams{RepoRoot: "/repo", WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
+		svc := newTestSvc(g, tm, WithCommonParams(cp), WithNoAttach())
+		res, err := svc.Switch(context.Background(), SwitchParams{Branch: "feature"})
+		require.NoError(t, err, "neither attach-session nor switch-client is run")
+		assert.Equal(t, "/repo/.worktrees/feature", res.WorktreePath)
+		require.Len(t, tm.NewSessionCalls(), 1)
+	})
+
 	t.Run("errors when branch does not exist", func(t *testing.T) {
 		g := &git.ClientMock{
 			BranchExistsFunc: mockBranchExists(), // nothing exists
@@ -114,9 +134,9 @@ func TestSwitch(t *testing.T) {
 		var capturedInitCmd string
 		tm := &tmux.ClientMock{
 			HasSessionFunc: func(name string) (bool, error) { return false, nil },
-			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
+			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
 				capturedInitCmd = initCmd
-				return nil
+				return "@1", nil
 			},
 			IsInsideTmuxFunc: func() bool { return true },
 			SwitchClientFunc: func(session string, window string) error { return nil },
@@ -172,8 +192,8 @@ func TestSwitch(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "main", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
-				return "bash", nil
+			ListPanesFunc: func(session string, window string) ([]tmux.Pane, error) {
+				return []tmux.Pane{{ID: "%1", Command: "bash"}}, nil
 			},
 			SendKeysFunc: func(session string, window string, keys ...string) error {
 				return nil
@@ -208,10 +228,10 @@ func TestSwitch(t *testing.T) {
 			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
 				return []tmux.Window{{Name: "main", Active: false}}, nil
 			},
-			PaneCurrentCommandFunc: func(session, window string) (string, error) { return "zsh", nil },
-			SendKeysFunc:           func(session, window string, keys ...string) error { return nil },
-			IsInsideTmuxFunc:       func() bool { return true },
-			SwitchClientFunc:       func(session, window string) error { return nil },
+			ListPanesFunc:    func(session, window string) ([]tmux.Pane, error) { return []tmux.Pane{{ID: "%1", Command: "zsh"}}, nil },
+			SendKeysFunc:     func(session, window string, keys ...string) error { return nil },
+			IsInsideTmuxFunc: func() bool { return true },
+			SwitchClientFunc: func(session, window string) error { return nil },
 		}
 
 		cp := CommonParams{RepoRoot: "/repo", WorktreeDir: ".worktrees", DefaultBranch: "main", SessionName: "org/repo"}
@@ -42,6 +42,9 @@ func stubTmux() *tmux.ClientMock {
 		IsInsideTmuxFunc:  func() bool { return false },
 		AttachSessionFunc: func(session string, window string) error { return nil },
 		SendKeysFunc:      func(session string, window string, keys ...string) error { return nil },
+		SetWindowOptionFunc: func(session string, window string, key string, value string) error {
+			return nil
+		},
 	}
 }
 
@@ -51,10 +54,13 @@ func stubTmuxInside() *tmux.ClientMock {
 		HasSessionFunc: func(name string) (bool, error) {
 			return false, nil
 		},
-		NewSessionFunc:   func(name string, windowName string, dir string, initCmd string) error { return nil },
+		NewSessionFunc:   func(name string, windowName string, dir string, initCmd string) (string, error) { return "@1", nil },
 		IsInsideTmuxFunc: func() bool { return true },
 		SwitchClientFunc: func(session string, window string) error { return nil },
 		SendKeysFunc:     func(session string, window string, keys ...string) error { return nil },
+		SetWindowOptionFunc: func(session string, window string, key string, value string) error {
+			return nil
+		},
 	}
 }
 
@@ -62,3 +68,27 @@ func stubTmuxInside() *tmux.ClientMock {
 func newTestSvc(g git.Client, tm tmux.Client, opts ...Option) *Service {
 	return NewService(g, tm, opts...)
 }
+
+// batchingTmux is a tmux client that implements tmux.Batcher with a BatchMock.
+type batchingTmux struct {
+	*tmux.ClientMock
+	batch *tmux.BatchMock
+}
+
+func (c batchingTmux) NewBatch() tmux.Batch { return c.batch }
+
+// newBatchMock returns a BatchMock that records the names of queued commands
+// and returns runErr from Run.
+func newBatchMock(runErr error) (*tmux.BatchMock, *[]string) {
+	var queued []string
+	add := func(name string) { queued = append(queued, name) }
+	return &tmux.BatchMock{
+		NewSessionFunc:      func(name, windowName, dir, initCmd string) { add("new-session") },
+		NewWindowFunc:       func(session, name, dir, initCmd string) { add("new-window") },
+		SendKeysFunc:        func(session, window string, keys ...string) { add("send-keys") },
+		SetWindowOptionFunc: func(session, window, key, value string) { add("set-option") },
+		SwitchClientFunc:    func(session, window string) { add("switch-client") },
+		LenFunc:             func() int { return len(queued) },
+		RunFunc:             func() error { return runErr },
+	}, &queued
+}
@@ -0,0 +1,73 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Down closes the windows of every managed branch, extra windows included,
+// keeping the branches and worktrees so that Up can bring the windows back.
+// Windows whose branch no longer exists are left alone, since Up could not
+// restore them. The branch of the current window goes last, since its window may be
+// running hashi itself. It returns the branches whose windows were closed;
+// a branch that fails does not stop the others.
+func (s *Service) Down(ctx context.Context) ([]string, error) {
+	states, err := s.CollectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, _ := s.CurrentBranch(ctx)
+
+	var open []State
+	var last *State
+	for i, st := range states {
+		switch {
+		case !st.Window && len(st.Windows) == 0:
+		case st.Status == StatusOrphanedWindow || st.Status == StatusOrphanedWorktree:
+		case st.Branch == current:
+			last = &states[i]
+		default:
+			open = append(open, st)
+		}
+	}
+	if last != nil {
+		open = append(open, *last)
+	}
+
+	var closed []string
+	var errs []error
+	for _, st := range open {
+		if _, err := s.mapping().kill(st.Branch, st.Window, st.Windows); err != nil {
+			errs = append(errs, fmt.Errorf("closing windows of '%s': %w", st.Branch, err))
+			continue
+		}
+		closed = append(closed, st.Branch)
+	}
+	return closed, errors.Join(errs...)
+}
+
+// Up creates the window, with its extra windows, of every branch that has a
+// healthy worktree but no window. Unlike Switch, it runs no post_new hooks and
+// does not connect to the windows. It returns the branches whose windows were
+// created; a branch that fails does not stop the others.
+func (s *Service) Up(ctx context.Context) ([]string, error) {
+	states, err := s.CollectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var opened []string
+	var errs []error
+	for _, st := range states {
+		if st.Window || st.Worktree == "" || !st.Status.IsHealthy() {
+			continue
+		}
+		if err := s.ensureTmuxWindows(s.mapping().session(st.Branch), st.Branch, st.Worktree, "", s.cp.Windows); err != nil {
+			errs = append(errs, fmt.Errorf("opening window of '%s': %w", st.Branch, err))
+			continue
+		}
+		opened = append(opened, st.Branch)
+	}
+	return opened, errors.Join(errs...)
+}
@@ -0,0 +1,187 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/git"
+	"github.com/wasabi0522/hashi/internal/tmux"
+)
+
+// upDownGitMock returns a git mock with worktrees for main, feature, and
+// other, an orphaned worktree for gone, and a branch idle without a worktree.
+func upDownGitMock() *git.ClientMock {
+	return &git.ClientMock{
+		ListWorktreesFunc: func() ([]git.Worktree, error) {
+			return []git.Worktree{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo/.worktrees/feature", Branch: "feature"},
+				{Path: "/repo/.worktrees/other", Branch: "other"},
+				{Path: "/repo/.worktrees/gone", Branch: "gone"},
+			}, nil
+		},
+		ListBranchesFunc: mockListBranches("main", "feature", "other", "idle"),
+	}
+}
+
+func TestDown(t *testing.T) {
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{
+					{ID: "@1", Name: "main"},
+					{ID: "@2", Name: "feature", Active: true},
+					{ID: "@3", Name: "feature:server"},
+					{ID: "@4", Name: "other"},
+					{ID: "@5", Name: "scratch"},
+				}, nil
+			},
+			CurrentWindowFunc: func() (string, error) { return "@3", nil },
+			KillWindowFunc:    func(session, window string) error { return nil },
+		}
+	}
+	killed := func(tm *tmux.ClientMock) []string {
+		var names []string
+		for _, c := range tm.KillWindowCalls() {
+			names = append(names, c.Window)
+		}
+		return names
+	}
+
+	t.Run("closes the current branch last", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		closed, err := svc.Down(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "other", "feature"}, closed)
+		assert.Equal(t, []string{"main", "other", "feature:server", "feature"}, killed(tm), "orphaned windows stay")
+	})
+
+	t.Run("outside tmux", func(t *testing.T) {
+		tm := newTmux()
+		tm.CurrentWindowFunc = func() (string, error) { return "", fmt.Errorf("not inside tmux") }
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		closed, err := svc.Down(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "feature", "other"}, closed)
+	})
+
+	t.Run("a failure does not stop the others", func(t *testing.T) {
+		tm := newTmux()
+		tm.KillWindowFunc = func(session, window string) error {
+			if window == "main" {
+				return fmt.Errorf("kill failed")
+			}
+			return nil
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP()))
+
+		closed, err := svc.Down(context.Background())
+		assert.ErrorContains(t, err, "closing windows of 'main': kill failed")
+		assert.Equal(t, []string{"other", "feature"}, closed)
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			ListAllWindowsFunc: func() ([]tmux.Window, error) {
+				return []tmux.Window{
+					{ID: "@1", Name: "main", Session: "org/repo/main"},
+					{ID: "@2", Name: "feature", Session: "org/repo/feature"},
+					{ID: "@3", Name: "scratch", Session: "scratch"},
+				}, nil
+			},
+			CurrentWindowFunc: func() (string, error) { return "@3", nil },
+			KillSessionFunc:   func(name string) error { return nil },
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(sessionCP()))
+
+		closed, err := svc.Down(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "feature"}, closed)
+		require.Len(t, tm.KillSessionCalls(), 2)
+		assert.Equal(t, "org/repo/feature", tm.KillSessionCalls()[1].Name)
+	})
+
+	t.Run("no windows", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			HasSessionFunc:    func(name string) (bool, error) { return false, nil },
+			CurrentWindowFunc: func() (string, error) { return "", fmt.Errorf("not inside tmux") },
+		}
+		closed, err := newTestSvc(upDownGitMock(), tm, WithCommonParams(defaultCP())).Down(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, closed)
+	})
+}
+
+func TestUp(t *testing.T) {
+	upCP := func() CommonParams {
+		cp := defaultCP()
+		cp.PostNewHooks = []string{"npm install"}
+		cp.Windows = []WindowSpec{{Name: "server"}}
+		return cp
+	}
+	newTmux := func() *tmux.ClientMock {
+		return &tmux.ClientMock{
+			HasSessionFunc: func(name string) (bool, error) { return true, nil },
+			ListWindowsFunc: func(session string) ([]tmux.Window, error) {
+				return []tmux.Window{{ID: "@1", Name: "main"}, {ID: "@2", Name: "main:server"}}, nil
+			},
+			NewWindowFunc: func(session, name, dir, initCmd string) (string, error) { return "@9", nil },
+		}
+	}
+
+	t.Run("opens healthy worktrees without windows", func(t *testing.T) {
+		tm := newTmux()
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(upCP()))
+
+		opened, err := svc.Up(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature", "other"}, opened)
+
+		var created []string
+		for _, c := range tm.NewWindowCalls() {
+			created = append(created, c.Name)
+			assert.Empty(t, c.InitCmd, "post_new hooks do not run")
+		}
+		assert.Equal(t, []string{"feature:server", "feature", "other:server", "other"}, created)
+		assert.Equal(t, "/repo/.worktrees/feature", tm.NewWindowCalls()[1].Dir)
+		assert.Empty(t, tm.SwitchClientCalls())
+		assert.Empty(t, tm.AttachSessionCalls())
+	})
+
+	t.Run("a failure does not stop the others", func(t *testing.T) {
+		tm := newTmux()
+		tm.NewWindowFunc = func(session, name, dir, initCmd string) (string, error) {
+			if name == "feature:server" {
+				return "", fmt.Errorf("new-window failed")
+			}
+			return "@9", nil
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(upCP()))
+
+		opened, err := svc.Up(context.Background())
+		assert.ErrorContains(t, err, "opening window of 'feature'")
+		assert.Equal(t, []string{"other"}, opened)
+	})
+
+	t.Run("session mapping", func(t *testing.T) {
+		tm := &tmux.ClientMock{
+			ListAllWindowsFunc: func() ([]tmux.Window, error) { return nil, nil },
+			HasSessionFunc:     func(name string) (bool, error) { return false, nil },
+			NewSessionFunc:     func(name, windowName, dir, initCmd string) (string, error) { return "@9", nil },
+		}
+		svc := newTestSvc(upDownGitMock(), tm, WithCommonParams(sessionCP()))
+
+		opened, err := svc.Up(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main", "feature", "other"}, opened)
+		require.Len(t, tm.NewSessionCalls(), 3)
+		assert.Equal(t, "org/repo/feature", tm.NewSessionCalls()[1].Name)
+	})
+}
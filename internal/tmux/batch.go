@@ -0,0 +1,116 @@
+package tmux
+
+import "fmt"
+
+// batchMarker is printed between batched commands to locate the one that failed.
+const batchMarker = "__hashi_batch_step__"
+
+// BatchError reports the command of a Batch that failed.
+// tmux does not run the commands queued after it.
+type BatchError struct {
+	// Index is the position of the failed command in the order it was queued.
+	Index int
+	// Command is the tmux command name, e.g. "new-window".
+	Command string
+	Err     error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%s (batched command %d): %v", e.Command, e.Index+1, e.Err)
+}
+
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// NewBatch returns a Batch for c. Clients that do not implement Batcher get a
+// Batch that runs each command through c in turn, with the same stop-at-first-failure
+// semantics and error reporting.
+func NewBatch(c Client) Batch {
+	if b, ok := c.(Batcher); ok {
+		return b.NewBatch()
+	}
+	return &sequentialBatch{c: c}
+}
+
+// cmdBatch collects command arguments for a runner.
+type cmdBatch struct {
+	cmd  runner
+	cmds [][]string
+}
+
+func (b *cmdBatch) NewSession(name, windowName, dir, initCmd string) {
+	b.cmds = append(b.cmds, newSessionArgs(name, windowName, dir, initCmd))
+}
+
+func (b *cmdBatch) NewWindow(session, name, dir, initCmd string) {
+	b.cmds = append(b.cmds, newWindowArgs(session, name, dir, initCmd))
+}
+
+func (b *cmdBatch) SendKeys(session, window string, keys ...string) {
+	b.cmds = append(b.cmds, sendKeysArgs(session, window, keys))
+}
+
+func (b *cmdBatch) SetWindowOption(session, window, key, value string) {
+	b.cmds = append(b.cmds, setWindowOptionArgs(session, window, key, value))
+}
+
+func (b *cmdBatch) SwitchClient(session, window string) {
+	b.cmds = append(b.cmds, switchClientArgs(session, window))
+}
+
+func (b *cmdBatch) Len() int { return len(b.cmds) }
+
+func (b *cmdBatch) Run() error {
+	if len(b.cmds) == 0 {
+		return nil
+	}
+	return b.cmd.batch(b.cmds)
+}
+
+// sequentialBatch runs queued commands one Client call at a time.
+type sequentialBatch struct {
+	c     Client
+	names []string
+	ops   []func() error
+}
+
+func (b *sequentialBatch) add(name string, op func() error) {
+	b.names = append(b.names, name)
+	b.ops = append(b.ops, op)
+}
+
+func (b *sequentialBatch) NewSession(name, windowName, dir, initCmd string) {
+	b.add("new-session", func() error {
+		_, err := b.c.NewSession(name, windowName, dir, initCmd)
+		return err
+	})
+}
+
+func (b *sequentialBatch) NewWindow(session, name, dir, initCmd string) {
+	b.add("new-window", func() error {
+		_, err := b.c.NewWindow(session, name, dir, initCmd)
+		return err
+	})
+}
+
+func (b *sequentialBatch) SendKeys(session, window string, keys ...string) {
+	b.add("send-keys", func() error { return b.c.SendKeys(session, window, keys...) })
+}
+
+func (b *sequentialBatch) SetWindowOption(session, window, key, value string) {
+	b.add("set-option", func() error { return b.c.SetWindowOption(session, window, key, value) })
+}
+
+func (b *sequentialBatch) SwitchClient(session, window string) {
+	b.add("switch-client", func() error { return b.c.SwitchClient(session, window) })
+}
+
+func (b *sequentialBatch) Len() int { return len(b.ops) }
+
+func (b *sequentialBatch) Run() error {
+	for i, op := range b.ops {
+		if err := op(); err != nil {
+			return &BatchError{Index: i, Command: b.names[i], Err: err}
+		}
+	}
+	return nil
+}
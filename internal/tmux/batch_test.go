@@ -0,0 +1,204 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/exec"
+)
+
+func TestClientBatch(t *testing.T) {
+	t.Run("chains commands in one invocation", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) { return "", nil }
+		b := NewBatch(NewClient(e))
+		b.NewWindow("sess", "feat", "/wt/feat", "")
+		b.SendKeys("sess", "", "C-u", "cd /wt/feat", "Enter")
+		b.SwitchClient("sess", "")
+		assert.Equal(t, 3, b.Len())
+		require.NoError(t, b.Run())
+
+		require.Len(t, e.OutputCalls(), 1)
+		assert.Equal(t, "tmux", e.OutputCalls()[0].Name)
+		assert.Equal(t, []string{
+			"new-window", "-a", "-t", "sess", "-n", "feat", "-c", "/wt/feat", "-P", "-F", "#{window_id}",
+			";", "display-message", "-p", batchMarker, ";",
+			"send-keys", "-t", "sess:", "C-u", "cd /wt/feat", "Enter",
+			";", "display-message", "-p", batchMarker, ";",
+			"switch-client", "-t", "sess:",
+		}, e.OutputCalls()[0].Args)
+	})
+
+	t.Run("reports the failed command", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			return "", &exec.OutputError{Stdout: "@4\n" + batchMarker + "\n", Err: fmt.Errorf("exit status 1")}
+		}
+		b := NewBatch(NewClient(e))
+		b.NewSession("sess", "main", "/repo", "")
+		b.SetWindowOption("sess", "", OptionBranch, "main")
+		b.SwitchClient("sess", "")
+
+		err := b.Run()
+		var be *BatchError
+		require.ErrorAs(t, err, &be)
+		assert.Equal(t, 1, be.Index)
+		assert.Equal(t, "set-option", be.Command)
+	})
+
+	t.Run("empty batch runs nothing", func(t *testing.T) {
+		e := mockExec()
+		require.NoError(t, NewBatch(NewClient(e)).Run())
+		assert.Empty(t, e.OutputCalls())
+	})
+}
+
+func TestNewBatch_sequential(t *testing.T) {
+	inner := newMock()
+	inner.NewWindowFunc = func(session, name, dir, initCmd string) (string, error) { return "@2", nil }
+	inner.SendKeysFunc = func(session, window string, keys ...string) error { return fmt.Errorf("no pane") }
+	b := NewBatch(inner)
+	b.NewWindow("sess", "feat", "/dir", "")
+	b.SendKeys("sess", "feat", "Enter")
+	b.SwitchClient("sess", "feat")
+
+	err := b.Run()
+	var be *BatchError
+	require.ErrorAs(t, err, &be)
+	assert.Equal(t, 1, be.Index)
+	assert.Equal(t, "send-keys", be.Command)
+	assert.Empty(t, inner.SwitchClientCalls(), "commands after a failure do not run")
+}
+
+func TestControlClient_batch(t *testing.T) {
+	t.Run("one command line", func(t *testing.T) {
+		f := startFakeControl("", okReply)
+		e := controlExec(f)
+		b := NewBatch(NewControlClient(e))
+		b.NewWindow("sess", "feat", "/dir", "")
+		b.SendKeys("sess", "", "Enter")
+		require.NoError(t, b.Run())
+
+		assert.Empty(t, e.OutputCalls())
+		require.NoError(t, f.Close())
+		assert.Equal(t, []string{
+			"new-window -a -t sess -n feat -c /dir -P -F '#{window_id}' ; send-keys -t sess: Enter",
+		}, f.received())
+	})
+
+	t.Run("reports the failed command", func(t *testing.T) {
+		f := startFakeControl("", func(cmd string) (string, bool) {
+			if strings.HasSuffix(cmd, "missing Enter") {
+				return "can't find window: missing", true
+			}
+			return "", false
+		})
+		c := NewControlClient(controlExec(f))
+		b := NewBatch(c)
+		b.SendKeys("sess", "@1", "Enter")
+		b.SendKeys("sess", "missing", "Enter")
+		b.SendKeys("sess", "@2", "Enter")
+
+		err := b.Run()
+		var be *BatchError
+		require.ErrorAs(t, err, &be)
+		assert.Equal(t, 1, be.Index)
+		var cmdErr *CommandError
+		require.ErrorAs(t, err, &cmdErr)
+
+		// The connection stays in sync for the next command.
+		require.NoError(t, c.KillSession("x"))
+		require.NoError(t, f.Close())
+		assert.Len(t, f.received(), 2)
+	})
+
+	t.Run("switch-client uses a process", func(t *testing.T) {
+		e := controlExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) { return "", nil }
+		b := NewBatch(NewControlClient(e))
+		b.SendKeys("sess", "@1", "Enter")
+		b.SwitchClient("sess", "@1")
+		require.NoError(t, b.Run())
+		assert.Empty(t, e.StartCalls())
+		require.Len(t, e.OutputCalls(), 1)
+	})
+}
+
+func TestPrefixedBatch(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		if args[0] == "list-windows" {
+			return "@7\tedited\t1\tmain\t/repo", nil
+		}
+		return "", nil
+	}
+	b := NewBatch(NewPrefixedClient(NewClient(e), "hs/"))
+	b.NewWindow("sess", "feat", "/wt/feat", "")
+	b.SendKeys("sess", "feat", "Enter")
+	b.SendKeys("sess", "main", "Enter")
+	assert.Equal(t, 3, b.Len())
+	require.NoError(t, b.Run())
+
+	calls := e.OutputCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, []string{"list-windows", "-t", "hs/sess", "-F", windowListFormat}, calls[0].Args)
+	assert.Equal(t, strings.Join([]string{
+		"new-window -a -t hs/sess -n hs/feat -c /wt/feat -P -F #{window_id}",
+		"set-option -w -t hs/sess: @hashi_branch feat",
+		"set-option -w -t hs/sess: @hashi_worktree /wt/feat",
+		"send-keys -t hs/sess: Enter",
+		"send-keys -t hs/sess:@7 Enter",
+	}, " ; display-message -p "+batchMarker+" ; "), strings.Join(calls[1].Args, " "))
+}
+
+func TestPrefixedBatch_sharesClientCache(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		if args[0] == "list-windows" {
+			return "@7\tedited\t1\tmain\t/repo", nil
+		}
+		return "", nil
+	}
+	e.RunFunc = func(name string, args ...string) error { return nil }
+	c := NewPrefixedClient(NewClient(e), "hs/")
+	b := NewBatch(c)
+	b.SendKeys("sess", "main", "Enter")
+	require.NoError(t, b.Run())
+	require.NoError(t, c.SendKeys("sess", "main", "Enter"))
+
+	b = NewBatch(c)
+	b.NewWindow("sess", "feat", "/wt/feat", "")
+	require.NoError(t, b.Run())
+	require.NoError(t, c.SendKeys("sess", "main", "Enter"))
+
+	var lists int
+	for _, call := range e.OutputCalls() {
+		if call.Args[0] == "list-windows" {
+			lists++
+		}
+	}
+	assert.Equal(t, 2, lists, "only the batch that created a window drops the cache")
+}
+
+func TestPrefixedBatch_remapsFailedIndex(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		if args[0] == "list-windows" {
+			return "", nil
+		}
+		// new-window and both tags succeeded; the send-keys failed.
+		return "", &exec.OutputError{Stdout: strings.Repeat(batchMarker+"\n", 3), Err: fmt.Errorf("exit status 1")}
+	}
+	b := NewBatch(NewPrefixedClient(NewClient(e), "hs/"))
+	b.NewWindow("sess", "feat", "/wt/feat", "")
+	b.SendKeys("sess", "feat", "Enter")
+
+	err := b.Run()
+	var be *BatchError
+	require.ErrorAs(t, err, &be)
+	assert.Equal(t, 1, be.Index, "index counts the caller's commands, not the tags")
+	assert.Equal(t, "send-keys", be.Command)
+}
@@ -1,122 +1,485 @@
 package tmux
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/wasabi0522/hashi/internal/exec"
 )
 
+// target returns the tmux target for a window. An empty window targets the
+// session's current window, which is the one created by a preceding
+// new-session or new-window in the same batch. A pane ID is a target on its
+// own: tmux rejects it after a session name.
 func target(session, window string) string {
+	if isPaneID(window) {
+		return window
+	}
 	return session + ":" + window
 }
 
+// isPaneID reports whether s is a tmux pane ID such as "%3".
+func isPaneID(s string) bool {
+	return isID(s, '%')
+}
+
+// isWindowID reports whether s is a tmux window ID such as "@3".
+func isWindowID(s string) bool {
+	return isID(s, '@')
+}
+
+func isID(s string, sigil byte) bool {
+	if len(s) < 2 || s[0] != sigil {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 var _ Client = (*client)(nil)
 
+// runner runs tmux commands on behalf of client.
+type runner interface {
+	run(args ...string) error
+	output(args ...string) (string, error)
+	// batch runs cmds in one invocation and returns a *BatchError for the command that failed.
+	batch(cmds [][]string) error
+	// close releases any persistent connection to the tmux server.
+	close() error
+}
+
+// execRunner starts a tmux process per command.
+type execRunner struct {
+	exec exec.Executor
+	// server holds the global arguments selecting the tmux server (see serverArgs).
+	server []string
+}
+
+// args prepends the server arguments to a tmux command line.
+func (r execRunner) args(args ...string) []string {
+	if len(r.server) == 0 {
+		return args
+	}
+	return append(append([]string(nil), r.server...), args...)
+}
+
+func (r execRunner) run(args ...string) error { return r.exec.Run("tmux", r.args(args...)...) }
+
+func (r execRunner) output(args ...string) (string, error) {
+	return r.exec.Output("tmux", r.args(args...)...)
+}
+
+func (r execRunner) close() error { return nil }
+
+// batch chains cmds with ";" in one tmux process. A marker is printed after
+// each command so the markers in the output before a failure tell which
+// command failed: tmux stops at the first error.
+func (r execRunner) batch(cmds [][]string) error {
+	var args []string
+	for i, cmd := range cmds {
+		if i > 0 {
+			args = append(args, ";", "display-message", "-p", batchMarker, ";")
+		}
+		args = append(args, cmd...)
+	}
+	_, err := r.output(args...)
+	if err == nil {
+		return nil
+	}
+	i := 0
+	for line := range strings.SplitSeq(exec.PartialOutput(err), "\n") {
+		if line == batchMarker {
+			i++
+		}
+	}
+	i = min(i, len(cmds)-1)
+	return &BatchError{Index: i, Command: cmds[i][0], Err: err}
+}
+
 type client struct {
 	exec exec.Executor
+	cmd  runner
+	// proc starts the tmux processes that act on the user's terminal.
+	proc   execRunner
+	socket string
 }
 
 // NewClient creates a tmux Client backed by the given Executor.
-func NewClient(exec exec.Executor) Client {
-	return &client{exec: exec}
+func NewClient(e exec.Executor, opts ...Option) Client {
+	o := newOptions(opts)
+	r := execRunner{exec: e, server: serverArgs(o.socket)}
+	return &client{exec: e, cmd: r, proc: r, socket: o.socket}
 }
 
 func (c *client) HasSession(name string) (bool, error) {
-	err := c.exec.Run("tmux", "has-session", "-t", name)
+	err := c.cmd.run("has-session", "-t", name)
 	if err == nil {
 		return true, nil
 	}
-	if exec.IsExitCode(err, 1) {
+	var cmdErr *CommandError
+	if exec.IsExitCode(err, 1) || errors.As(err, &cmdErr) {
 		return false, nil
 	}
 	return false, err
 }
 
-func (c *client) NewSession(name, windowName, dir, initCmd string) error {
-	args := []string{"new-session", "-d", "-s", name, "-n", windowName, "-c", dir}
+// windowIDFormat makes new-session/new-window print the ID of the created window.
+const windowIDFormat = "#{window_id}"
+
+func newSessionArgs(name, windowName, dir, initCmd string) []string {
+	args := []string{"new-session", "-d", "-s", name, "-n", windowName, "-c", dir, "-P", "-F", windowIDFormat}
 	if initCmd != "" {
 		args = append(args, initCmd)
 	}
-	return c.exec.Run("tmux", args...)
+	return args
+}
+
+func (c *client) NewSession(name, windowName, dir, initCmd string) (string, error) {
+	return c.cmd.output(newSessionArgs(name, windowName, dir, initCmd)...)
 }
 
 func (c *client) KillSession(name string) error {
-	return c.exec.Run("tmux", "kill-session", "-t", name)
+	return c.cmd.run("kill-session", "-t", name)
+}
+
+func (c *client) RenameSession(old, new string) error {
+	return c.cmd.run("rename-session", "-t", old, new)
 }
 
 func (c *client) ListWindows(session string) ([]Window, error) {
-	out, err := c.exec.Output("tmux", "list-windows", "-t", session, "-F", "#{window_name}\t#{window_active}")
+	out, err := c.cmd.output("list-windows", "-t", session, "-F", windowListFormat)
 	if err != nil {
 		return nil, err
 	}
 	return parseWindowList(out), nil
 }
 
-func (c *client) NewWindow(session, name, dir, initCmd string) error {
-	args := []string{"new-window", "-a", "-t", session, "-n", name, "-c", dir}
+func (c *client) ListAllWindows() ([]Window, error) {
+	out, err := c.cmd.output("list-windows", "-a", "-F", windowListFormat)
+	if err != nil {
+		return nil, err
+	}
+	return parseWindowList(out), nil
+}
+
+func (c *client) ListUnmanagedWindows(session string) ([]Window, error) {
+	windows, err := c.ListWindows(session)
+	if err != nil {
+		return nil, err
+	}
+	unmanaged := windows[:0]
+	for _, w := range windows {
+		if w.Branch == "" {
+			unmanaged = append(unmanaged, w)
+		}
+	}
+	return unmanaged, nil
+}
+
+func newWindowArgs(session, name, dir, initCmd string) []string {
+	args := []string{"new-window", "-a", "-t", session, "-n", name, "-c", dir, "-P", "-F", windowIDFormat}
 	if initCmd != "" {
 		args = append(args, initCmd)
 	}
-	return c.exec.Run("tmux", args...)
+	return args
+}
+
+func (c *client) NewWindow(session, name, dir, initCmd string) (string, error) {
+	return c.cmd.output(newWindowArgs(session, name, dir, initCmd)...)
 }
 
 func (c *client) KillWindow(session, window string) error {
-	return c.exec.Run("tmux", "kill-window", "-t", target(session, window))
+	return c.cmd.run("kill-window", "-t", target(session, window))
 }
 
 func (c *client) RenameWindow(session, old, new string) error {
-	return c.exec.Run("tmux", "rename-window", "-t", target(session, old), new)
+	return c.cmd.run("rename-window", "-t", target(session, old), new)
+}
+
+func sendKeysArgs(session, window string, keys []string) []string {
+	return append([]string{"send-keys", "-t", target(session, window)}, keys...)
 }
 
 func (c *client) SendKeys(session, window string, keys ...string) error {
-	args := []string{"send-keys", "-t", target(session, window)}
-	args = append(args, keys...)
-	return c.exec.Run("tmux", args...)
+	return c.cmd.run(sendKeysArgs(session, window, keys)...)
 }
 
 func (c *client) PaneCurrentCommand(session, window string) (string, error) {
-	return c.exec.Output("tmux", "display-message", "-t", target(session, window), "-p", "#{pane_current_command}")
+	return c.cmd.output("display-message", "-t", target(session, window), "-p", "#{pane_current_command}")
+}
+
+func (c *client) SwapWindow(session, window string, index int) error {
+	return c.cmd.run("swap-window", "-s", target(session, window), "-t", target(session, strconv.Itoa(index)))
+}
+
+func (c *client) SelectWindow(session, window string) error {
+	return c.cmd.run("select-window", "-t", target(session, window))
+}
+
+func (c *client) MoveWindow(window, session string) error {
+	return c.cmd.run("move-window", "-d", "-s", window, "-t", session+":")
+}
+
+func (c *client) ListPanes(session, window string) ([]Pane, error) {
+	out, err := c.cmd.output("list-panes", "-t", target(session, window), "-F", paneListFormat)
+	if err != nil {
+		return nil, err
+	}
+	return parsePaneList(out), nil
+}
+
+func (c *client) CapturePane(pane string, lines int) (string, error) {
+	args := []string{"capture-pane", "-p", "-J", "-t", pane}
+	if lines > 0 {
+		args = append(args, "-S", strconv.Itoa(-lines))
+	}
+	return c.cmd.output(args...)
+}
+
+func (c *client) PipePane(pane, command string) error {
+	args := []string{"pipe-pane", "-t", pane}
+	if command != "" {
+		args = append(args, command)
+	}
+	return c.cmd.run(args...)
 }
 
+func (c *client) SplitPane(pane string, opts SplitOptions) (string, error) {
+	orientation := "-v"
+	if opts.Horizontal {
+		orientation = "-h"
+	}
+	args := []string{"split-window", "-d", orientation, "-t", pane}
+	if opts.Size != "" {
+		args = append(args, "-l", opts.Size)
+	}
+	if opts.Dir != "" {
+		args = append(args, "-c", opts.Dir)
+	}
+	args = append(args, "-P", "-F", "#{pane_id}")
+	if opts.Command != "" {
+		args = append(args, opts.Command)
+	}
+	return c.cmd.output(args...)
+}
+
+func (c *client) SelectPane(pane string) error {
+	return c.cmd.run("select-pane", "-t", pane)
+}
+
+func (c *client) SelectLayout(session, window, layout string) error {
+	return c.cmd.run("select-layout", "-t", target(session, window), layout)
+}
+
+// ForegroundCommand asks ps for the foreground process group of the pane's
+// terminal. Its leader is the job the shell started, so a pipeline or a
+// script reports the command line that was typed. A pane without a
+// terminal reports "".
+func (c *client) ForegroundCommand(pane string) (string, error) {
+	pid, err := c.cmd.output("display-message", "-t", pane, "-p", "#{pane_pid}")
+	if err != nil {
+		return "", err
+	}
+	tpgid, err := c.exec.Output("ps", "-o", "tpgid=", "-p", pid)
+	if err != nil {
+		return "", fmt.Errorf("finding the foreground job of %s: %w", pane, err)
+	}
+	tpgid = strings.TrimSpace(tpgid)
+	if tpgid == "" || strings.HasPrefix(tpgid, "-") {
+		return "", nil
+	}
+	args, err := c.exec.Output("ps", "-o", "args=", "-p", tpgid)
+	if err != nil {
+		return "", fmt.Errorf("reading the foreground job of %s: %w", pane, err)
+	}
+	return strings.TrimSpace(args), nil
+}
+
+func setWindowOptionArgs(session, window, key, value string) []string {
+	return []string{"set-option", "-w", "-t", target(session, window), key, value}
+}
+
+func (c *client) SetWindowOption(session, window, key, value string) error {
+	return c.cmd.run(setWindowOptionArgs(session, window, key, value)...)
+}
+
+func (c *client) SetSessionOption(session, key, value string) error {
+	if value == "" {
+		return c.cmd.run("set-option", "-u", "-t", session, key)
+	}
+	return c.cmd.run("set-option", "-t", session, key, value)
+}
+
+func (c *client) SetGlobalHook(hook, command string) error {
+	return c.cmd.run("set-hook", "-g", hook, command)
+}
+
+// DisplayMessage escapes '#' so that the message is not expanded as a format.
+func (c *client) DisplayMessage(message string) error {
+	return c.cmd.run("display-message", strings.ReplaceAll(message, "#", "##"))
+}
+
+// AttachSession hands the terminal to tmux until the user detaches.
+// A persistent connection is released first so it does not stay attached
+// alongside the user; it is re-established on the next command.
+//
+// From a session of another tmux server than the client's, tmux would refuse
+// to nest, so $TMUX is cleared for the attach.
 func (c *client) AttachSession(session, window string) error {
-	return c.exec.RunInteractive("tmux", "attach-session", "-t", target(session, window))
+	_ = c.cmd.close()
+	if c.socket != "" && os.Getenv("TMUX") != "" {
+		_ = os.Unsetenv("TMUX")
+	}
+	return c.exec.RunInteractive("tmux", c.proc.args("attach-session", "-t", target(session, window))...)
+}
+
+// SwitchClient always starts a separate tmux process: tmux applies switch-client
+// to the client running the command, which must be the user's, not a control client.
+func switchClientArgs(session, window string) []string {
+	return []string{"switch-client", "-t", target(session, window)}
 }
 
 func (c *client) SwitchClient(session, window string) error {
-	return c.exec.Run("tmux", "switch-client", "-t", target(session, window))
+	return c.proc.run(switchClientArgs(session, window)...)
+}
+
+// groupedTarget targets the window of a grouped session, or the session
+// itself for its current window.
+func groupedTarget(name, window string) string {
+	if window == "" {
+		return name
+	}
+	return target(name, window)
+}
+
+// destroy-unattached is set once a client is in the grouped session, since
+// tmux destroys an unattached session as soon as the option is set.
+func (c *client) AttachGrouped(session, name, window string) error {
+	_ = c.cmd.close()
+	if c.socket != "" && os.Getenv("TMUX") != "" {
+		_ = os.Unsetenv("TMUX")
+	}
+	args := []string{"new-session", "-t", session, "-s", name, ";", "set-option", "-t", name, "destroy-unattached", "on"}
+	if window != "" {
+		args = append(args, ";", "select-window", "-t", target(name, window))
+	}
+	return c.exec.RunInteractive("tmux", c.proc.args(args...)...)
+}
+
+func (c *client) SwitchClientGrouped(session, name, window string) error {
+	return c.proc.run("new-session", "-d", "-t", session, "-s", name,
+		";", "switch-client", "-t", groupedTarget(name, window),
+		";", "set-option", "-t", name, "destroy-unattached", "on")
+}
+
+// CurrentSession runs a separate tmux process, like SwitchClient: the
+// client's session is the one the user sees, which may differ from the
+// session named by $TMUX when sessions are grouped.
+func (c *client) CurrentSession() (string, error) {
+	return c.proc.output("display-message", "-p", "#{client_session}")
 }
 
+func (c *client) NewBatch() Batch {
+	return &cmdBatch{cmd: c.cmd}
+}
+
+// IsInsideTmux reports whether hashi runs inside a session of the client's
+// tmux server; with a dedicated socket, sessions of other servers do not count.
 func (c *client) IsInsideTmux() bool {
-	return os.Getenv("TMUX") != ""
+	return insideServer(os.Getenv("TMUX"), c.socket)
+}
+
+func (c *client) CurrentWindow() (string, error) {
+	env := os.Getenv("TMUX")
+	id, ok := sessionID(env)
+	if !ok || !insideServer(env, c.socket) {
+		return "", errors.New("not inside tmux")
+	}
+	return c.cmd.output("display-message", "-t", id, "-p", "#{window_id}")
 }
 
 // tmuxActiveFlag is the value tmux uses in #{window_active} to indicate the active window.
 const tmuxActiveFlag = "1"
 
-// parseWindowList parses the output of `tmux list-windows -F '#{window_name}\t#{window_active}'`.
-func parseWindowList(output string) []Window {
+// paneListFormat is the list-panes format parsed by parsePaneList.
+const paneListFormat = "#{pane_id}\t#{pane_active}\t#{pane_pipe}\t#{pane_current_command}\t#{pane_current_path}"
+
+// parsePaneList parses the output of `tmux list-panes -F paneListFormat`.
+// Lines with fewer than the ID and active fields are ignored.
+func parsePaneList(output string) []Pane {
 	if output == "" {
 		return nil
 	}
 
-	lines := strings.Split(output, "\n")
-	windows := make([]Window, 0, len(lines))
+	var panes []Pane
 	for line := range strings.SplitSeq(output, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 5)
+		if len(parts) < 2 {
 			continue
 		}
+		p := Pane{ID: parts[0], Active: parts[1] == tmuxActiveFlag}
+		if len(parts) > 2 {
+			p.Piped = parts[2] == "1"
+		}
+		if len(parts) > 3 {
+			p.Command = parts[3]
+		}
+		if len(parts) > 4 {
+			p.Dir = parts[4]
+		}
+		panes = append(panes, p)
+	}
+	return panes
+}
+
+// windowListFormat is the list-windows format parsed by parseWindowList.
+const windowListFormat = "#{window_id}\t#{window_name}\t#{window_active}\t#{" + OptionBranch + "}\t#{" + OptionWorktree + "}" +
+	"\t#{session_name}\t#{session_attached}\t#{window_index}\t#{window_activity}\t#{window_layout}\t#{pane_current_path}"
+
+// parseWindowList parses the output of `tmux list-windows -F windowListFormat`.
+// Lines with fewer than the ID, name and active fields are ignored.
+func parseWindowList(output string) []Window {
+	if output == "" {
+		return nil
+	}
 
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
+	var windows []Window
+	for line := range strings.SplitSeq(output, "\n") {
+		// Unset options print as empty trailing fields, so only strip the line ending.
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 11)
+		if len(parts) < 3 {
 			continue
 		}
-
-		windows = append(windows, Window{
-			Name:   parts[0],
-			Active: parts[1] == tmuxActiveFlag,
-		})
+		w := Window{ID: parts[0], Name: parts[1], Active: parts[2] == tmuxActiveFlag}
+		if len(parts) > 3 {
+			w.Branch = parts[3]
+		}
+		if len(parts) > 4 {
+			w.Worktree = parts[4]
+		}
+		if len(parts) > 6 {
+			w.Session = parts[5]
+			// session_attached is the number of attached clients.
+			w.SessionAttached = parts[6] != "" && parts[6] != "0"
+		}
+		if len(parts) > 8 {
+			w.Index, _ = strconv.Atoi(parts[7])
+			w.Activity, _ = strconv.ParseInt(parts[8], 10, 64)
+		}
+		if len(parts) > 9 {
+			w.Layout = parts[9]
+		}
+		if len(parts) > 10 {
+			w.Dir = parts[10]
+		}
+		windows = append(windows, w)
 	}
 
 	return windows
@@ -53,24 +53,28 @@ func TestClientHasSession(t *testing.T) {
 func TestClientNewSession(t *testing.T) {
 	t.Run("without initCmd", func(t *testing.T) {
 		e := mockExec()
-		e.RunFunc = func(name string, args ...string) error {
+		e.OutputFunc = func(name string, args ...string) (string, error) {
 			assert.Equal(t, "tmux", name)
-			assert.Equal(t, []string{"new-session", "-d", "-s", "sess", "-n", "win", "-c", "/dir"}, args)
-			return nil
+			assert.Equal(t, []string{"new-session", "-d", "-s", "sess", "-n", "win", "-c", "/dir", "-P", "-F", "#{window_id}"}, args)
+			return "@1", nil
 		}
 		c := NewClient(e)
-		require.NoError(t, c.NewSession("sess", "win", "/dir", ""))
+		id, err := c.NewSession("sess", "win", "/dir", "")
+		require.NoError(t, err)
+		assert.Equal(t, "@1", id)
 	})
 
 	t.Run("with initCmd", func(t *testing.T) {
 		e := mockExec()
-		e.RunFunc = func(name string, args ...string) error {
+		e.OutputFunc = func(name string, args ...string) (string, error) {
 			assert.Equal(t, "tmux", name)
-			assert.Equal(t, []string{"new-session", "-d", "-s", "sess", "-n", "win", "-c", "/dir", "echo hello; exec zsh"}, args)
-			return nil
+			assert.Equal(t, []string{"new-session", "-d", "-s", "sess", "-n", "win", "-c", "/dir", "-P", "-F", "#{window_id}", "echo hello; exec zsh"}, args)
+			return "@1", nil
 		}
 		c := NewClient(e)
-		require.NoError(t, c.NewSession("sess", "win", "/dir", "echo hello; exec zsh"))
+		id, err := c.NewSession("sess", "win", "/dir", "echo hello; exec zsh")
+		require.NoError(t, err)
+		assert.Equal(t, "@1", id)
 	})
 }
 
@@ -84,11 +88,22 @@ func TestClientKillSession(t *testing.T) {
 	require.NoError(t, c.KillSession("sess"))
 }
 
+func TestClientRenameSession(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"rename-session", "-t", "old", "new"}, args)
+		return nil
+	}
+	c := NewClient(e)
+	require.NoError(t, c.RenameSession("old", "new"))
+}
+
 func TestClientListWindows(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		e := mockExec()
 		e.OutputFunc = func(name string, args ...string) (string, error) {
-			return "main\t1\nfeat\t0", nil
+			assert.Equal(t, []string{"list-windows", "-t", "sess", "-F", windowListFormat}, args)
+			return "@1\tmain\t1\t\t\n@2\tfeat\t0\tfeat\t/wt/feat", nil
 		}
 		c := NewClient(e)
 		ws, err := c.ListWindows("sess")
@@ -96,6 +111,7 @@ func TestClientListWindows(t *testing.T) {
 		require.Len(t, ws, 2)
 		assert.Equal(t, "main", ws[0].Name)
 		assert.True(t, ws[0].Active)
+		assert.Equal(t, Window{ID: "@2", Name: "feat", Branch: "feat", Worktree: "/wt/feat"}, ws[1])
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -109,25 +125,53 @@ func TestClientListWindows(t *testing.T) {
 	})
 }
 
+func TestClientListAllWindows(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"list-windows", "-a", "-F", windowListFormat}, args)
+		return "@1\tmain\t1\t\t\ta\t1\n@2\tmain\t1\t\t\tb\t0", nil
+	}
+	c := NewClient(e)
+	ws, err := c.ListAllWindows()
+	require.NoError(t, err)
+	require.Len(t, ws, 2)
+	assert.Equal(t, "b", ws[1].Session)
+}
+
+func TestClientListUnmanagedWindows(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"list-windows", "-t", "work", "-F", windowListFormat}, args)
+		return "@1\tmain\t1\tmain\t/repo\n@2\tvim\t0\t\t", nil
+	}
+	ws, err := NewClient(e).ListUnmanagedWindows("work")
+	require.NoError(t, err)
+	assert.Equal(t, []Window{{ID: "@2", Name: "vim"}}, ws)
+}
+
 func TestClientNewWindow(t *testing.T) {
 	t.Run("without initCmd", func(t *testing.T) {
 		e := mockExec()
-		e.RunFunc = func(name string, args ...string) error {
-			assert.Equal(t, []string{"new-window", "-a", "-t", "sess", "-n", "win", "-c", "/dir"}, args)
-			return nil
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"new-window", "-a", "-t", "sess", "-n", "win", "-c", "/dir", "-P", "-F", "#{window_id}"}, args)
+			return "@1", nil
 		}
 		c := NewClient(e)
-		require.NoError(t, c.NewWindow("sess", "win", "/dir", ""))
+		id, err := c.NewWindow("sess", "win", "/dir", "")
+		require.NoError(t, err)
+		assert.Equal(t, "@1", id)
 	})
 
 	t.Run("with initCmd", func(t *testing.T) {
 		e := mockExec()
-		e.RunFunc = func(name string, args ...string) error {
-			assert.Equal(t, []string{"new-window", "-a", "-t", "sess", "-n", "win", "-c", "/dir", "npm install; exec bash"}, args)
-			return nil
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"new-window", "-a", "-t", "sess", "-n", "win", "-c", "/dir", "-P", "-F", "#{window_id}", "npm install; exec bash"}, args)
+			return "@1", nil
 		}
 		c := NewClient(e)
-		require.NoError(t, c.NewWindow("sess", "win", "/dir", "npm install; exec bash"))
+		id, err := c.NewWindow("sess", "win", "/dir", "npm install; exec bash")
+		require.NoError(t, err)
+		assert.Equal(t, "@1", id)
 	})
 }
 
@@ -151,6 +195,33 @@ func TestClientRenameWindow(t *testing.T) {
 	require.NoError(t, c.RenameWindow("sess", "old", "new"))
 }
 
+func TestClientSwapWindow(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"swap-window", "-s", "sess:@3", "-t", "sess:1"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).SwapWindow("sess", "@3", 1))
+}
+
+func TestClientSelectWindow(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"select-window", "-t", "sess:@3"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).SelectWindow("sess", "@3"))
+}
+
+func TestClientMoveWindow(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"move-window", "-d", "-s", "@3", "-t", "sess:"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).MoveWindow("@3", "sess"))
+}
+
 func TestClientSendKeys(t *testing.T) {
 	t.Run("single key", func(t *testing.T) {
 		e := mockExec()
@@ -171,6 +242,206 @@ func TestClientSendKeys(t *testing.T) {
 		c := NewClient(e)
 		require.NoError(t, c.SendKeys("sess", "win", "cd /dir", "Enter"))
 	})
+
+	t.Run("pane ID target", func(t *testing.T) {
+		e := mockExec()
+		e.RunFunc = func(name string, args ...string) error {
+			assert.Equal(t, []string{"send-keys", "-t", "%12", "Enter"}, args)
+			return nil
+		}
+		c := NewClient(e)
+		require.NoError(t, c.SendKeys("sess", "%12", "Enter"))
+	})
+}
+
+func TestClientListPanes(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"list-panes", "-t", "sess:@3", "-F", paneListFormat}, args)
+		return "%1\t0\t0\tnvim\t/wt/feat\n%2\t1\t1\tzsh\t/wt/my dir", nil
+	}
+	c := NewClient(e)
+	panes, err := c.ListPanes("sess", "@3")
+	require.NoError(t, err)
+	assert.Equal(t, []Pane{
+		{ID: "%1", Command: "nvim", Dir: "/wt/feat"},
+		{ID: "%2", Active: true, Command: "zsh", Dir: "/wt/my dir", Piped: true},
+	}, panes)
+}
+
+func TestClientSplitPane(t *testing.T) {
+	t.Run("all options", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"split-window", "-d", "-h", "-t", "%1", "-l", "30%", "-c", "/dir", "-P", "-F", "#{pane_id}", "npm test"}, args)
+			return "%4", nil
+		}
+		c := NewClient(e)
+		id, err := c.SplitPane("%1", SplitOptions{Horizontal: true, Size: "30%", Dir: "/dir", Command: "npm test"})
+		require.NoError(t, err)
+		assert.Equal(t, "%4", id)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"split-window", "-d", "-v", "-t", "%1", "-P", "-F", "#{pane_id}"}, args)
+			return "%5", nil
+		}
+		c := NewClient(e)
+		_, err := c.SplitPane("%1", SplitOptions{})
+		require.NoError(t, err)
+	})
+}
+
+func TestClientSelectPane(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"select-pane", "-t", "%2"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).SelectPane("%2"))
+}
+
+func TestClientCapturePane(t *testing.T) {
+	for _, tt := range []struct {
+		lines int
+		want  []string
+	}{
+		{0, []string{"capture-pane", "-p", "-J", "-t", "%2"}},
+		{100, []string{"capture-pane", "-p", "-J", "-t", "%2", "-S", "-100"}},
+	} {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, tt.want, args)
+			return "$ make\nok", nil
+		}
+		out, err := NewClient(e).CapturePane("%2", tt.lines)
+		require.NoError(t, err)
+		assert.Equal(t, "$ make\nok", out)
+	}
+}
+
+func TestClientPipePane(t *testing.T) {
+	for _, tt := range []struct {
+		command string
+		want    []string
+	}{
+		{"cat >> '/tmp/out'", []string{"pipe-pane", "-t", "%2", "cat >> '/tmp/out'"}},
+		{"", []string{"pipe-pane", "-t", "%2"}},
+	} {
+		e := mockExec()
+		e.RunFunc = func(name string, args ...string) error {
+			assert.Equal(t, tt.want, args)
+			return nil
+		}
+		require.NoError(t, NewClient(e).PipePane("%2", tt.command))
+	}
+}
+
+func TestClientSelectLayout(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"select-layout", "-t", "sess:feat", "b25d,80x24,0,0{40x24,0,0,1,39x24,41,0,2}"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).SelectLayout("sess", "feat", "b25d,80x24,0,0{40x24,0,0,1,39x24,41,0,2}"))
+}
+
+func TestClientForegroundCommand(t *testing.T) {
+	newExec := func(tpgid string) *exec.ExecutorMock {
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			switch {
+			case name == "tmux":
+				assert.Equal(t, []string{"display-message", "-t", "%2", "-p", "#{pane_pid}"}, args)
+				return "100", nil
+			case args[1] == "tpgid=":
+				assert.Equal(t, []string{"-o", "tpgid=", "-p", "100"}, args)
+				return tpgid, nil
+			default:
+				assert.Equal(t, []string{"-o", "args=", "-p", "200"}, args)
+				return "npm run dev\n", nil
+			}
+		}
+		return e
+	}
+
+	t.Run("job in the foreground", func(t *testing.T) {
+		cmd, err := NewClient(newExec("  200\n")).ForegroundCommand("%2")
+		require.NoError(t, err)
+		assert.Equal(t, "npm run dev", cmd)
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		e := newExec("   -1\n")
+		cmd, err := NewClient(e).ForegroundCommand("%2")
+		require.NoError(t, err)
+		assert.Empty(t, cmd)
+		assert.Len(t, e.OutputCalls(), 2)
+	})
+
+	t.Run("ps fails", func(t *testing.T) {
+		e := newExec("")
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			if name == "tmux" {
+				return "100", nil
+			}
+			return "", fmt.Errorf("exit status 1")
+		}
+		_, err := NewClient(e).ForegroundCommand("%2")
+		assert.ErrorContains(t, err, "finding the foreground job of %2")
+	})
+}
+
+func TestClientSetSessionOption(t *testing.T) {
+	var calls [][]string
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}
+	c := NewClient(e)
+	require.NoError(t, c.SetSessionOption("sess", "@hashi_hooks", "1"))
+	require.NoError(t, c.SetSessionOption("sess", "@hashi_hooks", ""))
+	assert.Equal(t, [][]string{
+		{"set-option", "-t", "sess", "@hashi_hooks", "1"},
+		{"set-option", "-u", "-t", "sess", "@hashi_hooks"},
+	}, calls)
+}
+
+func TestClientSetGlobalHook(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"set-hook", "-g", "window-renamed[42]", "run-shell -b 'true'"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).SetGlobalHook("window-renamed[42]", "run-shell -b 'true'"))
+}
+
+func TestClientDisplayMessage(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"display-message", "hashi: renamed 'fix-##1'"}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).DisplayMessage("hashi: renamed 'fix-#1'"))
+}
+
+func TestIsPaneID(t *testing.T) {
+	assert.True(t, isPaneID("%0"))
+	assert.True(t, isPaneID("%12"))
+	assert.False(t, isPaneID("%"))
+	assert.False(t, isPaneID("%a1"))
+	assert.False(t, isPaneID("feature"))
+	assert.False(t, isPaneID("@3"))
+}
+
+func TestIsWindowID(t *testing.T) {
+	assert.True(t, isWindowID("@3"))
+	assert.False(t, isWindowID("@"))
+	assert.False(t, isWindowID("%3"))
+	assert.False(t, isWindowID("feature"))
 }
 
 func TestClientPaneCurrentCommand(t *testing.T) {
@@ -197,6 +468,16 @@ func TestClientPaneCurrentCommand(t *testing.T) {
 	})
 }
 
+func TestClientSetWindowOption(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{"set-option", "-w", "-t", "sess:@1", "@hashi_branch", "feat"}, args)
+		return nil
+	}
+	c := NewClient(e)
+	require.NoError(t, c.SetWindowOption("sess", "@1", OptionBranch, "feat"))
+}
+
 func TestClientAttachSession(t *testing.T) {
 	e := mockExec()
 	e.RunInteractiveFunc = func(name string, args ...string) error {
@@ -217,6 +498,57 @@ func TestClientSwitchClient(t *testing.T) {
 	require.NoError(t, c.SwitchClient("sess", "win"))
 }
 
+func TestClientAttachGrouped(t *testing.T) {
+	t.Run("window", func(t *testing.T) {
+		e := mockExec()
+		e.RunInteractiveFunc = func(name string, args ...string) error {
+			assert.Equal(t, []string{
+				"new-session", "-t", "sess", "-s", "sess~1",
+				";", "set-option", "-t", "sess~1", "destroy-unattached", "on",
+				";", "select-window", "-t", "sess~1:win",
+			}, args)
+			return nil
+		}
+		require.NoError(t, NewClient(e).AttachGrouped("sess", "sess~1", "win"))
+	})
+
+	t.Run("current window", func(t *testing.T) {
+		e := mockExec()
+		e.RunInteractiveFunc = func(name string, args ...string) error {
+			assert.Equal(t, []string{
+				"new-session", "-t", "sess", "-s", "sess~1",
+				";", "set-option", "-t", "sess~1", "destroy-unattached", "on",
+			}, args)
+			return nil
+		}
+		require.NoError(t, NewClient(e).AttachGrouped("sess", "sess~1", ""))
+	})
+}
+
+func TestClientSwitchClientGrouped(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error {
+		assert.Equal(t, []string{
+			"new-session", "-d", "-t", "sess", "-s", "sess~2",
+			";", "switch-client", "-t", "sess~2:win",
+			";", "set-option", "-t", "sess~2", "destroy-unattached", "on",
+		}, args)
+		return nil
+	}
+	require.NoError(t, NewClient(e).SwitchClientGrouped("sess", "sess~2", "win"))
+}
+
+func TestClientCurrentSession(t *testing.T) {
+	e := mockExec()
+	e.OutputFunc = func(name string, args ...string) (string, error) {
+		assert.Equal(t, []string{"display-message", "-p", "#{client_session}"}, args)
+		return "sess~1", nil
+	}
+	name, err := NewClient(e).CurrentSession()
+	require.NoError(t, err)
+	assert.Equal(t, "sess~1", name)
+}
+
 func TestClientIsInsideTmux(t *testing.T) {
 	t.Run("inside", func(t *testing.T) {
 		t.Setenv("TMUX", "/tmp/tmux-1000/default,12345,0")
@@ -231,6 +563,26 @@ func TestClientIsInsideTmux(t *testing.T) {
 	})
 }
 
+func TestClientCurrentWindow(t *testing.T) {
+	t.Run("inside", func(t *testing.T) {
+		t.Setenv("TMUX", "/tmp/tmux-1000/default,12345,7")
+		e := mockExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) {
+			assert.Equal(t, []string{"display-message", "-t", "$7", "-p", "#{window_id}"}, args)
+			return "@4", nil
+		}
+		id, err := NewClient(e).CurrentWindow()
+		require.NoError(t, err)
+		assert.Equal(t, "@4", id)
+	})
+
+	t.Run("outside", func(t *testing.T) {
+		t.Setenv("TMUX", "")
+		_, err := NewClient(mockExec()).CurrentWindow()
+		assert.ErrorContains(t, err, "not inside tmux")
+	})
+}
+
 func TestParseWindowList(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -239,29 +591,56 @@ func TestParseWindowList(t *testing.T) {
 	}{
 		{name: "empty", input: "", want: nil},
 		{
-			name: "single active window", input: "main\t1",
-			want: []Window{{Name: "main", Active: true}},
+			name: "single active window", input: "@1\tmain\t1\t\t",
+			want: []Window{{ID: "@1", Name: "main", Active: true}},
+		},
+		{
+			name: "multiple windows", input: "@1\tmain\t1\t\t\n@2\tfeature-login\t0\t\t\n@3\tfix-bug\t0\t\t",
+			want: []Window{
+				{ID: "@1", Name: "main", Active: true},
+				{ID: "@2", Name: "feature-login", Active: false},
+				{ID: "@3", Name: "fix-bug", Active: false},
+			},
 		},
 		{
-			name: "multiple windows", input: "main\t1\nfeature-login\t0\nfix-bug\t0",
+			name: "slash in window name", input: "@1\tmain\t0\t\t\n@2\tfeat/auth\t1\t\t",
 			want: []Window{
-				{Name: "main", Active: true},
-				{Name: "feature-login", Active: false},
-				{Name: "fix-bug", Active: false},
+				{ID: "@1", Name: "main", Active: false},
+				{ID: "@2", Name: "feat/auth", Active: true},
 			},
 		},
 		{
-			name: "slash in window name", input: "main\t0\nfeat/auth\t1",
+			name: "tagged window", input: "@4\tmy editor\t0\trelease/v1.2\t/wt/release/v1.2",
+			want: []Window{{ID: "@4", Name: "my editor", Branch: "release/v1.2", Worktree: "/wt/release/v1.2"}},
+		},
+		{
+			name: "session fields", input: "@5\tfeat\t1\tfeat\t/wt/feat\torg/repo/feat\t2\n@6\tmain\t1\t\t\torg/repo\t0",
 			want: []Window{
-				{Name: "main", Active: false},
-				{Name: "feat/auth", Active: true},
+				{ID: "@5", Name: "feat", Active: true, Branch: "feat", Worktree: "/wt/feat", Session: "org/repo/feat", SessionAttached: true},
+				{ID: "@6", Name: "main", Active: true, Session: "org/repo"},
 			},
 		},
 		{
-			name: "malformed line ignored", input: "main\t1\nbadline\nfeat\t0",
+			name: "index and activity", input: "@7\tfeat\t0\tfeat\t/wt/feat\torg/repo\t1\t3\t1700000000",
+			want: []Window{{ID: "@7", Name: "feat", Branch: "feat", Worktree: "/wt/feat", Session: "org/repo", SessionAttached: true, Index: 3, Activity: 1700000000}},
+		},
+		{
+			name: "layout", input: "@8\tfeat\t0\tfeat\t/wt/feat\torg/repo\t1\t3\t1700000000\tb25d,80x24,0,0,1",
+			want: []Window{{ID: "@8", Name: "feat", Branch: "feat", Worktree: "/wt/feat", Session: "org/repo", SessionAttached: true, Index: 3, Activity: 1700000000, Layout: "b25d,80x24,0,0,1"}},
+		},
+		{
+			name: "active pane directory", input: "@9\tvim\t0\t\t\twork\t1\t2\t1700000000\tb25d,80x24,0,0,1\t/repo/.worktrees/feat",
+			want: []Window{{ID: "@9", Name: "vim", Session: "work", SessionAttached: true, Index: 2, Activity: 1700000000, Layout: "b25d,80x24,0,0,1", Dir: "/repo/.worktrees/feat"}},
+		},
+		{
+			name: "missing option fields", input: "@1\tmain\t1",
+			want: []Window{{ID: "@1", Name: "main", Active: true}},
+		},
+		{
+			name: "malformed line ignored", input: "@1\tmain\t1\t\t\nbadline\n@2\tfeat\t0\t\t",
 			want: []Window{
-				{Name: "main", Active: true},
-				{Name: "feat", Active: false},
+				{ID: "@1", Name: "main", Active: true},
+				{ID: "@2", Name: "feat", Active: false},
 			},
 		},
 	}
@@ -0,0 +1,247 @@
+package tmux
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/wasabi0522/hashi/internal/exec"
+)
+
+// CommandError is a tmux command failure reported over a control-mode connection.
+type CommandError struct {
+	Command string
+	Message string
+}
+
+func (e *CommandError) Error() string {
+	return "tmux " + e.Command + ": " + e.Message
+}
+
+// errConnectionLost is returned when the control connection closes while a command is running.
+var errConnectionLost = errors.New("tmux control connection lost")
+
+// NewControlClient creates a tmux Client that sends commands over a single
+// persistent control-mode (`tmux -C`) connection instead of starting a tmux
+// process per call.
+//
+// A control client must be attached to a session, so the connection is opened
+// lazily on the first command. While no session exists, or if the connection
+// drops, commands fall back to a tmux process per call and the connection is
+// retried on the next command.
+func NewControlClient(e exec.Executor, opts ...Option) Client {
+	o := newOptions(opts)
+	r := execRunner{exec: e, server: serverArgs(o.socket)}
+	return &client{exec: e, cmd: &controlRunner{exec: e, fallback: r}, proc: r, socket: o.socket}
+}
+
+// controlRunner runs commands over a `tmux -C` connection.
+type controlRunner struct {
+	exec     exec.Executor
+	fallback execRunner
+
+	mu   sync.Mutex
+	proc exec.Process
+	r    *bufio.Reader
+}
+
+func (c *controlRunner) run(args ...string) error {
+	_, sent, _, err := c.send([][]string{args})
+	if !sent {
+		return c.fallback.run(args...)
+	}
+	return err
+}
+
+func (c *controlRunner) output(args ...string) (string, error) {
+	out, sent, _, err := c.send([][]string{args})
+	if !sent {
+		return c.fallback.output(args...)
+	}
+	return out, err
+}
+
+// batch sends cmds as one command line. Batches containing switch-client go
+// through a separate process, which must be the user's client (see SwitchClient).
+func (c *controlRunner) batch(cmds [][]string) error {
+	for _, cmd := range cmds {
+		if len(cmd) > 0 && cmd[0] == "switch-client" {
+			return c.fallback.batch(cmds)
+		}
+	}
+	_, sent, failed, err := c.send(cmds)
+	if !sent {
+		return c.fallback.batch(cmds)
+	}
+	if err != nil {
+		return &BatchError{Index: failed, Command: cmds[failed][0], Err: err}
+	}
+	return nil
+}
+
+func (c *controlRunner) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.disconnect()
+}
+
+// send runs cmds over the control connection as one line, connecting first if
+// needed. It returns the output of the last command, or the index of the
+// command that failed. sent is false if tmux never started the first command,
+// in which case the caller falls back to a separate process.
+func (c *controlRunner) send(cmds [][]string) (out string, sent bool, failed int, err error) {
+	for _, args := range cmds {
+		if !controlSafe(args) {
+			return "", false, 0, nil
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A reused connection may have been closed by the server since the last
+	// command (e.g. its session was killed); retry once on a fresh one.
+	reused := c.proc != nil
+	for {
+		if c.proc == nil {
+			if err := c.connect(); err != nil {
+				return "", false, 0, nil
+			}
+		}
+		out, started, failed, err := c.roundTrip(cmds)
+		if started {
+			if errors.Is(err, errConnectionLost) {
+				_ = c.disconnect()
+			}
+			return out, true, failed, err
+		}
+		_ = c.disconnect()
+		if !reused {
+			return "", false, 0, nil
+		}
+		reused = false
+	}
+}
+
+// roundTrip writes cmds as one command line, separated by ";", and reads one
+// reply per command. tmux sends no reply for the commands after a failure.
+func (c *controlRunner) roundTrip(cmds [][]string) (out string, started bool, failed int, err error) {
+	lines := make([]string, len(cmds))
+	for i, args := range cmds {
+		lines[i] = quoteCommand(args)
+	}
+	if _, err := io.WriteString(c.proc, strings.Join(lines, " ; ")+"\n"); err != nil {
+		return "", false, 0, err
+	}
+	for i, args := range cmds {
+		var ok bool
+		out, ok, err = c.readBlock(args[0])
+		started = started || ok
+		if err != nil {
+			return "", started, i, err
+		}
+	}
+	return out, true, 0, nil
+}
+
+// connect starts `tmux -C attach-session` and consumes the reply to the attach.
+func (c *controlRunner) connect() error {
+	proc, err := c.exec.Start("tmux", c.fallback.args("-C", "attach-session")...)
+	if err != nil {
+		return err
+	}
+	c.proc, c.r = proc, bufio.NewReader(proc)
+
+	// The first block is the reply to attach-session itself (e.g. "no sessions").
+	_, started, err := c.readBlock("attach-session")
+	if !started {
+		err = errConnectionLost
+	}
+	if err != nil {
+		_ = c.disconnect()
+		return err
+	}
+
+	// Stop %output notifications for pane output (tmux 3.2+).
+	// Older versions reject the flag and keep sending them; readBlock skips them either way.
+	if _, started, _, _ := c.roundTrip([][]string{{"refresh-client", "-f", "no-output"}}); !started {
+		_ = c.disconnect()
+		return errConnectionLost
+	}
+	return nil
+}
+
+func (c *controlRunner) disconnect() error {
+	if c.proc == nil {
+		return nil
+	}
+	err := c.proc.Close()
+	c.proc, c.r = nil, nil
+	return err
+}
+
+// readBlock reads the reply to one command: the lines between
+// "%begin <time> <number> <flags>" and the matching "%end" or "%error".
+// Notifications outside the block are skipped. started reports whether
+// %begin was seen before the connection closed.
+func (c *controlRunner) readBlock(command string) (out string, started bool, err error) {
+	var guard string
+	var lines []string
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return "", started, errConnectionLost
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if !started {
+			if line == "%exit" || strings.HasPrefix(line, "%exit ") {
+				return "", false, errConnectionLost
+			}
+			if rest, ok := strings.CutPrefix(line, "%begin"); ok {
+				started, guard = true, rest
+			}
+			continue
+		}
+
+		switch line {
+		case "%end" + guard:
+			return strings.Join(lines, "\n"), true, nil
+		case "%error" + guard:
+			return "", true, &CommandError{Command: command, Message: strings.Join(lines, "\n")}
+		}
+		lines = append(lines, line)
+	}
+}
+
+// controlSafe reports whether args can be sent as a single control-mode command line.
+func controlSafe(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	for _, a := range args {
+		if strings.ContainsAny(a, "\r\n") {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteCommand formats args as a tmux command line. Arguments are single-quoted
+// unless they are plain words, so tmux does not expand ~, $VAR, or treat ; # { as syntax.
+func quoteCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = quoteArg(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quoteArg(a string) string {
+	if a != "" && strings.Trim(a, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:@=,+") == "" {
+		return a
+	}
+	return "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+}
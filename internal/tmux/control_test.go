@@ -0,0 +1,267 @@
+package tmux
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wasabi0522/hashi/internal/exec"
+)
+
+// fakeControl is an exec.Process that speaks the tmux control-mode protocol.
+// reply returns the output of a command and whether it failed.
+type fakeControl struct {
+	stdin  *io.PipeWriter
+	stdout *io.PipeReader
+	out    *io.PipeWriter
+	done   chan struct{}
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func startFakeControl(attachErr string, reply func(line string) (string, bool)) *fakeControl {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	f := &fakeControl{stdin: inW, stdout: outR, out: outW, done: make(chan struct{})}
+
+	go func() {
+		defer close(f.done)
+		defer func() { _ = outW.Close() }()
+		w := bufio.NewWriter(outW)
+		block := func(n int, out string, failed bool) {
+			_, _ = fmt.Fprintf(w, "%%begin 100 %d 1\n", n)
+			if out != "" {
+				_, _ = fmt.Fprintln(w, out)
+			}
+			end := "%end"
+			if failed {
+				end = "%error"
+			}
+			_, _ = fmt.Fprintf(w, "%s 100 %d 1\n", end, n)
+			_ = w.Flush()
+		}
+
+		if attachErr != "" {
+			block(0, attachErr, true)
+			_, _ = w.WriteString("%exit\n")
+			_ = w.Flush()
+			return
+		}
+		block(0, "", false)
+		// Buffered until the next reply, like a real pipe would hold it.
+		_, _ = w.WriteString("%session-changed $0 sess\n")
+
+		sc := bufio.NewScanner(inR)
+		for n := 1; sc.Scan(); {
+			line := sc.Text()
+			f.mu.Lock()
+			f.lines = append(f.lines, line)
+			f.mu.Unlock()
+			// Notifications may arrive between blocks.
+			_, _ = w.WriteString("%output %1 noise\n")
+			// Commands chained with ";" get a block each; tmux stops at the first failure.
+			for _, cmd := range strings.Split(line, " ; ") {
+				out, failed := reply(cmd)
+				block(n, out, failed)
+				n++
+				if failed {
+					break
+				}
+			}
+		}
+		_, _ = w.WriteString("%exit\n")
+		_ = w.Flush()
+	}()
+	return f
+}
+
+func (f *fakeControl) Write(b []byte) (int, error) { return f.stdin.Write(b) }
+func (f *fakeControl) Read(b []byte) (int, error)  { return f.stdout.Read(b) }
+
+// hangUp simulates the server dropping the control client (e.g. its session was killed).
+func (f *fakeControl) hangUp() { _ = f.out.Close() }
+
+func (f *fakeControl) Close() error {
+	_ = f.stdin.Close()
+	_ = f.stdout.Close()
+	<-f.done
+	return nil
+}
+
+// received returns the command lines sent after the initial refresh-client.
+func (f *fakeControl) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.lines[1:]...)
+}
+
+func controlExec(procs ...exec.Process) *exec.ExecutorMock {
+	e := mockExec()
+	e.StartFunc = func(name string, args ...string) (exec.Process, error) {
+		if len(procs) == 0 {
+			return nil, fmt.Errorf("no more processes")
+		}
+		p := procs[0]
+		procs = procs[1:]
+		return p, nil
+	}
+	return e
+}
+
+func okReply(line string) (string, bool) { return "", false }
+
+func TestControlClient_commands(t *testing.T) {
+	f := startFakeControl("", func(line string) (string, bool) {
+		switch {
+		case strings.HasPrefix(line, "list-windows"):
+			return "@1\tmain\t1\tmain\t/repo\n@2\tfeat\t0\tfeat\t/wt/feat", false
+		case strings.HasPrefix(line, "has-session"):
+			return "can't find session: nope", true
+		case strings.HasPrefix(line, "display-message"):
+			return "zsh", false
+		}
+		return "", false
+	})
+	e := controlExec(f)
+	c := NewControlClient(e)
+	defer func() { require.NoError(t, c.(*client).cmd.close()) }()
+
+	ws, err := c.ListWindows("sess")
+	require.NoError(t, err)
+	require.Len(t, ws, 2)
+	assert.Equal(t, Window{ID: "@2", Name: "feat", Branch: "feat", Worktree: "/wt/feat"}, ws[1])
+
+	ok, err := c.HasSession("nope")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	cmd, err := c.PaneCurrentCommand("sess", "@1")
+	require.NoError(t, err)
+	assert.Equal(t, "zsh", cmd)
+
+	require.NoError(t, c.SendKeys("sess", "@1", "C-u", "cd '/a b'", "Enter"))
+
+	assert.Len(t, e.StartCalls(), 1, "one connection is reused")
+	assert.Equal(t, []string{"-C", "attach-session"}, e.StartCalls()[0].Args)
+	assert.Empty(t, e.RunCalls())
+	assert.Empty(t, e.OutputCalls())
+	assert.Equal(t, []string{
+		"list-windows -t sess -F '" + windowListFormat + "'",
+		"has-session -t nope",
+		"display-message -t sess:@1 -p '#{pane_current_command}'",
+		`send-keys -t sess:@1 C-u 'cd '\''/a b'\''' Enter`,
+	}, f.received())
+}
+
+func TestControlClient_commandError(t *testing.T) {
+	f := startFakeControl("", func(line string) (string, bool) { return "can't find window: x", true })
+	c := NewControlClient(controlExec(f))
+	defer func() { _ = c.(*client).cmd.close() }()
+
+	err := c.KillWindow("sess", "x")
+	var cmdErr *CommandError
+	require.ErrorAs(t, err, &cmdErr)
+	assert.Equal(t, "kill-window", cmdErr.Command)
+	assert.Equal(t, "tmux kill-window: can't find window: x", err.Error())
+}
+
+func TestControlClient_fallback(t *testing.T) {
+	t.Run("no sessions", func(t *testing.T) {
+		e := controlExec(startFakeControl("no sessions", okReply))
+		e.RunFunc = func(name string, args ...string) error {
+			assert.Equal(t, "tmux", name)
+			assert.Equal(t, []string{"kill-session", "-t", "sess"}, args)
+			return nil
+		}
+		c := NewControlClient(e)
+		require.NoError(t, c.KillSession("sess"))
+		assert.Len(t, e.RunCalls(), 1)
+	})
+
+	t.Run("start fails", func(t *testing.T) {
+		e := controlExec()
+		e.OutputFunc = func(name string, args ...string) (string, error) { return "@3", nil }
+		c := NewControlClient(e)
+		id, err := c.NewWindow("sess", "win", "/dir", "")
+		require.NoError(t, err)
+		assert.Equal(t, "@3", id)
+		assert.Len(t, e.OutputCalls(), 1)
+	})
+
+	t.Run("argument with newline", func(t *testing.T) {
+		e := controlExec()
+		e.RunFunc = func(name string, args ...string) error { return nil }
+		c := NewControlClient(e)
+		require.NoError(t, c.SendKeys("sess", "win", "a\nb"))
+		assert.Empty(t, e.StartCalls())
+		assert.Len(t, e.RunCalls(), 1)
+	})
+
+	t.Run("reconnects after connection closes", func(t *testing.T) {
+		first := startFakeControl("", okReply)
+		second := startFakeControl("", okReply)
+		e := controlExec(first, second)
+		c := NewControlClient(e)
+		defer func() { _ = c.(*client).cmd.close() }()
+
+		require.NoError(t, c.KillSession("a"))
+		first.hangUp()
+
+		require.NoError(t, c.KillSession("b"))
+		assert.Empty(t, e.RunCalls())
+		assert.Len(t, e.StartCalls(), 2)
+		assert.Equal(t, []string{"kill-session -t b"}, second.received())
+	})
+}
+
+func TestControlClient_AttachSessionReleasesConnection(t *testing.T) {
+	f := startFakeControl("", okReply)
+	e := controlExec(f)
+	e.RunInteractiveFunc = func(name string, args ...string) error {
+		select {
+		case <-f.done:
+		default:
+			t.Error("control connection should be closed before attaching")
+		}
+		return nil
+	}
+	c := NewControlClient(e)
+	require.NoError(t, c.KillWindow("sess", "@1"))
+	require.NoError(t, c.AttachSession("sess", "@1"))
+	assert.Equal(t, []string{"attach-session", "-t", "sess:@1"}, e.RunInteractiveCalls()[0].Args)
+}
+
+func TestControlClient_SwitchClientUsesProcess(t *testing.T) {
+	e := controlExec()
+	e.RunFunc = func(name string, args ...string) error { return nil }
+	c := NewControlClient(e)
+	require.NoError(t, c.SwitchClient("sess", "@1"))
+	assert.Empty(t, e.StartCalls())
+	assert.Equal(t, []string{"switch-client", "-t", "sess:@1"}, e.RunCalls()[0].Args)
+}
+
+func TestQuoteArg(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"sess:@1", "sess:@1"},
+		{"feat/x-1.2", "feat/x-1.2"},
+		{"", "''"},
+		{"a b", "'a b'"},
+		{"#{window_id}", "'#{window_id}'"},
+		{"it's", `'it'\''s'`},
+		{"echo hi; exec zsh", "'echo hi; exec zsh'"},
+		{"~/x", "'~/x'"},
+		{"$HOME", "'$HOME'"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, quoteArg(tt.in), tt.in)
+	}
+}
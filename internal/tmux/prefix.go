@@ -1,6 +1,11 @@
 package tmux
 
-import "strings"
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+)
 
 // DefaultPrefix is the default prefix added to tmux session and window names
 // to distinguish hashi-managed resources from others.
@@ -9,19 +14,26 @@ const DefaultPrefix = "hs/"
 var _ Client = (*prefixedClient)(nil)
 
 // prefixedClient wraps a Client and transparently adds/strips a prefix
-// on session and window names.
+// on session and window names. Windows it creates are tagged with
+// OptionBranch and OptionWorktree, and window arguments (branch names) are
+// resolved to window IDs through those tags.
 type prefixedClient struct {
 	inner  Client
 	prefix string
+
+	mu sync.Mutex
+	// windows caches the inner windows of each session, by unprefixed name,
+	// so that resolving targets in a loop does not list the windows every
+	// time. ListWindows refreshes it; calls that create, kill, move, rename
+	// or retag windows drop it.
+	windows map[string][]Window
 }
 
 // NewPrefixedClient returns a Client that prepends prefix to all session
-// and window names on outgoing calls, and strips the prefix from window
-// names returned by ListWindows.
+// and window names on outgoing calls, and reports managed windows by branch
+// name from ListWindows. An empty prefix leaves names as they are; windows
+// are still tagged, resolved and filtered by their tags.
 func NewPrefixedClient(inner Client, prefix string) Client {
-	if prefix == "" {
-		return inner
-	}
 	return &prefixedClient{inner: inner, prefix: prefix}
 }
 
@@ -33,20 +45,104 @@ func (p *prefixedClient) strip(name string) string {
 	return strings.TrimPrefix(name, p.prefix)
 }
 
+// branchOf returns the branch a window is managed for, or "" if it is not managed.
+// Tagged windows are matched by OptionBranch regardless of their name, so a
+// window renamed by the user stays managed. Untagged windows created by older
+// versions are matched by the name prefix; without a prefix, only tagged
+// windows are managed.
+func (p *prefixedClient) branchOf(w Window) string {
+	if w.Branch != "" {
+		return w.Branch
+	}
+	if p.prefix != "" && strings.HasPrefix(w.Name, p.prefix) {
+		return p.strip(w.Name)
+	}
+	return ""
+}
+
+// resolve returns the tmux target for the branch's window: its window ID if
+// found, otherwise the prefixed name. Window IDs avoid tmux's target parsing,
+// which would treat the '.' in a name such as "release/v1.2" as a pane separator.
+// Window and pane IDs are returned as is.
+func (p *prefixedClient) resolve(session, branch string) string {
+	if isWindowID(branch) || isPaneID(branch) {
+		return branch
+	}
+	for _, w := range p.cachedWindows(session) {
+		if p.branchOf(w) == branch {
+			return w.ID
+		}
+	}
+	return p.add(branch)
+}
+
+// cachedWindows returns the session's inner windows, listing them only if
+// they are not cached. A failed listing is not cached.
+func (p *prefixedClient) cachedWindows(session string) []Window {
+	p.mu.Lock()
+	windows, ok := p.windows[session]
+	p.mu.Unlock()
+	if ok {
+		return windows
+	}
+	windows, err := p.inner.ListWindows(p.add(session))
+	if err != nil {
+		return nil
+	}
+	p.remember(session, windows)
+	return windows
+}
+
+// remember caches a copy of the session's inner windows.
+func (p *prefixedClient) remember(session string, windows []Window) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.windows == nil {
+		p.windows = make(map[string][]Window)
+	}
+	p.windows[session] = slices.Clone(windows)
+}
+
+// forget drops the cached windows of every session.
+func (p *prefixedClient) forget() {
+	p.mu.Lock()
+	p.windows = nil
+	p.mu.Unlock()
+}
+
+// tag records the branch and worktree on a newly created window.
+func (p *prefixedClient) tag(session, windowID, branch, dir string) error {
+	if err := p.inner.SetWindowOption(p.add(session), windowID, OptionBranch, branch); err != nil {
+		return err
+	}
+	return p.inner.SetWindowOption(p.add(session), windowID, OptionWorktree, dir)
+}
+
 // Session operations
 
 func (p *prefixedClient) HasSession(name string) (bool, error) {
 	return p.inner.HasSession(p.add(name))
 }
 
-func (p *prefixedClient) NewSession(name, windowName, dir, initCmd string) error {
-	return p.inner.NewSession(p.add(name), p.add(windowName), dir, initCmd)
+func (p *prefixedClient) NewSession(name, windowName, dir, initCmd string) (string, error) {
+	p.forget()
+	id, err := p.inner.NewSession(p.add(name), p.add(windowName), dir, initCmd)
+	if err != nil {
+		return "", err
+	}
+	return id, p.tag(name, id, windowName, dir)
 }
 
 func (p *prefixedClient) KillSession(name string) error {
+	p.forget()
 	return p.inner.KillSession(p.add(name))
 }
 
+func (p *prefixedClient) RenameSession(old, new string) error {
+	p.forget()
+	return p.inner.RenameSession(p.add(old), p.add(new))
+}
+
 // Window operations
 
 func (p *prefixedClient) ListWindows(session string) ([]Window, error) {
@@ -54,45 +150,186 @@ func (p *prefixedClient) ListWindows(session string) ([]Window, error) {
 	if err != nil {
 		return nil, err
 	}
+	p.remember(session, windows)
+	managed := windows[:0]
+	for _, w := range windows {
+		branch := p.branchOf(w)
+		if branch == "" {
+			continue
+		}
+		w.Name = branch
+		managed = append(managed, w)
+	}
+	return managed, nil
+}
+
+// ListAllWindows returns the managed windows of sessions with the prefix,
+// with the prefix stripped from session names and windows named by branch.
+func (p *prefixedClient) ListAllWindows() ([]Window, error) {
+	windows, err := p.inner.ListAllWindows()
+	if err != nil {
+		return nil, err
+	}
 	managed := windows[:0]
 	for _, w := range windows {
-		if !strings.HasPrefix(w.Name, p.prefix) {
+		if !strings.HasPrefix(w.Session, p.prefix) {
+			continue
+		}
+		branch := p.branchOf(w)
+		if branch == "" {
 			continue
 		}
-		w.Name = p.strip(w.Name)
+		w.Session = p.strip(w.Session)
+		w.Name = branch
 		managed = append(managed, w)
 	}
 	return managed, nil
 }
 
-func (p *prefixedClient) NewWindow(session, name, dir, initCmd string) error {
-	return p.inner.NewWindow(p.add(session), p.add(name), dir, initCmd)
+// ListUnmanagedWindows returns the session's windows that are neither tagged
+// nor named with the prefix. The session name is passed as is.
+func (p *prefixedClient) ListUnmanagedWindows(session string) ([]Window, error) {
+	windows, err := p.inner.ListWindows(session)
+	if err != nil {
+		return nil, err
+	}
+	unmanaged := windows[:0]
+	for _, w := range windows {
+		if p.branchOf(w) == "" {
+			unmanaged = append(unmanaged, w)
+		}
+	}
+	return unmanaged, nil
+}
+
+func (p *prefixedClient) NewWindow(session, name, dir, initCmd string) (string, error) {
+	p.forget()
+	id, err := p.inner.NewWindow(p.add(session), p.add(name), dir, initCmd)
+	if err != nil {
+		return "", err
+	}
+	return id, p.tag(session, id, name, dir)
 }
 
 func (p *prefixedClient) KillWindow(session, window string) error {
-	return p.inner.KillWindow(p.add(session), p.add(window))
+	target := p.resolve(session, window)
+	p.forget()
+	return p.inner.KillWindow(p.add(session), target)
 }
 
 func (p *prefixedClient) RenameWindow(session, old, new string) error {
-	return p.inner.RenameWindow(p.add(session), p.add(old), p.add(new))
+	target := p.resolve(session, old)
+	p.forget()
+	if err := p.inner.RenameWindow(p.add(session), target, p.add(new)); err != nil {
+		return err
+	}
+	return p.inner.SetWindowOption(p.add(session), target, OptionBranch, new)
 }
 
 func (p *prefixedClient) SendKeys(session, window string, keys ...string) error {
-	return p.inner.SendKeys(p.add(session), p.add(window), keys...)
+	return p.inner.SendKeys(p.add(session), p.resolve(session, window), keys...)
 }
 
 func (p *prefixedClient) PaneCurrentCommand(session, window string) (string, error) {
-	return p.inner.PaneCurrentCommand(p.add(session), p.add(window))
+	return p.inner.PaneCurrentCommand(p.add(session), p.resolve(session, window))
+}
+
+func (p *prefixedClient) SetWindowOption(session, window, key, value string) error {
+	target := p.resolve(session, window)
+	if key == OptionBranch {
+		p.forget()
+	}
+	return p.inner.SetWindowOption(p.add(session), target, key, value)
+}
+
+func (p *prefixedClient) SwapWindow(session, window string, index int) error {
+	return p.inner.SwapWindow(p.add(session), p.resolve(session, window), index)
+}
+
+func (p *prefixedClient) SelectWindow(session, window string) error {
+	return p.inner.SelectWindow(p.add(session), p.resolve(session, window))
+}
+
+func (p *prefixedClient) MoveWindow(window, session string) error {
+	p.forget()
+	return p.inner.MoveWindow(window, p.add(session))
+}
+
+// Pane operations
+
+func (p *prefixedClient) ListPanes(session, window string) ([]Pane, error) {
+	return p.inner.ListPanes(p.add(session), p.resolve(session, window))
+}
+
+func (p *prefixedClient) SplitPane(pane string, opts SplitOptions) (string, error) {
+	return p.inner.SplitPane(pane, opts)
+}
+
+func (p *prefixedClient) SelectPane(pane string) error {
+	return p.inner.SelectPane(pane)
+}
+
+func (p *prefixedClient) CapturePane(pane string, lines int) (string, error) {
+	return p.inner.CapturePane(pane, lines)
+}
+
+func (p *prefixedClient) PipePane(pane, command string) error {
+	return p.inner.PipePane(pane, command)
+}
+
+func (p *prefixedClient) SelectLayout(session, window, layout string) error {
+	return p.inner.SelectLayout(p.add(session), p.resolve(session, window), layout)
+}
+
+func (p *prefixedClient) ForegroundCommand(pane string) (string, error) {
+	return p.inner.ForegroundCommand(pane)
+}
+
+// Options and hooks
+
+func (p *prefixedClient) SetSessionOption(session, key, value string) error {
+	return p.inner.SetSessionOption(p.add(session), key, value)
+}
+
+func (p *prefixedClient) SetGlobalHook(hook, command string) error {
+	return p.inner.SetGlobalHook(hook, command)
+}
+
+func (p *prefixedClient) DisplayMessage(message string) error {
+	return p.inner.DisplayMessage(message)
 }
 
 // Connection
 
 func (p *prefixedClient) AttachSession(session, window string) error {
-	return p.inner.AttachSession(p.add(session), p.add(window))
+	return p.inner.AttachSession(p.add(session), p.resolve(session, window))
 }
 
 func (p *prefixedClient) SwitchClient(session, window string) error {
-	return p.inner.SwitchClient(p.add(session), p.add(window))
+	return p.inner.SwitchClient(p.add(session), p.resolve(session, window))
+}
+
+func (p *prefixedClient) AttachGrouped(session, name, window string) error {
+	return p.inner.AttachGrouped(p.add(session), p.add(name), p.resolveGrouped(session, window))
+}
+
+func (p *prefixedClient) SwitchClientGrouped(session, name, window string) error {
+	return p.inner.SwitchClientGrouped(p.add(session), p.add(name), p.resolveGrouped(session, window))
+}
+
+// resolveGrouped resolves the window of a grouped session through the
+// session it is grouped with, since the new session does not exist yet.
+func (p *prefixedClient) resolveGrouped(session, window string) string {
+	if window == "" {
+		return ""
+	}
+	return p.resolve(session, window)
+}
+
+// CurrentSession returns the client's session without the prefix.
+func (p *prefixedClient) CurrentSession() (string, error) {
+	name, err := p.inner.CurrentSession()
+	return p.strip(name), err
 }
 
 // Environment
@@ -100,3 +337,103 @@ func (p *prefixedClient) SwitchClient(session, window string) error {
 func (p *prefixedClient) IsInsideTmux() bool {
 	return p.inner.IsInsideTmux()
 }
+
+func (p *prefixedClient) CurrentWindow() (string, error) {
+	return p.inner.CurrentWindow()
+}
+
+// Batch
+
+func (p *prefixedClient) NewBatch() Batch {
+	return &prefixedBatch{p: p, inner: NewBatch(p.inner), created: map[string]string{}}
+}
+
+// prefixedBatch adds the prefix to a Batch of the inner client. A window
+// created earlier in the batch is targeted as the session's current window,
+// since its ID is not known until the batch runs.
+type prefixedBatch struct {
+	p     *prefixedClient
+	inner Batch
+	// owner maps each inner command to the index of the caller's command that queued it.
+	owner   []int
+	n       int
+	created map[string]string
+	// retagged is set once the batch creates or retags a window, which makes
+	// the client's cached windows stale after Run.
+	retagged bool
+}
+
+// queue records the inner commands queued by f as belonging to the next caller command.
+func (b *prefixedBatch) queue(f func()) {
+	before := b.inner.Len()
+	f()
+	for range b.inner.Len() - before {
+		b.owner = append(b.owner, b.n)
+	}
+	b.n++
+}
+
+func (b *prefixedBatch) resolve(session, window string) string {
+	if window == "" || b.created[session] == window {
+		return ""
+	}
+	if isWindowID(window) || isPaneID(window) {
+		return window
+	}
+	for _, w := range b.p.cachedWindows(session) {
+		if b.p.branchOf(w) == window {
+			return w.ID
+		}
+	}
+	return b.p.add(window)
+}
+
+func (b *prefixedBatch) tag(session, branch, dir string) {
+	b.inner.SetWindowOption(b.p.add(session), "", OptionBranch, branch)
+	b.inner.SetWindowOption(b.p.add(session), "", OptionWorktree, dir)
+	b.created[session] = branch
+	b.retagged = true
+}
+
+func (b *prefixedBatch) NewSession(name, windowName, dir, initCmd string) {
+	b.queue(func() {
+		b.inner.NewSession(b.p.add(name), b.p.add(windowName), dir, initCmd)
+		b.tag(name, windowName, dir)
+	})
+}
+
+func (b *prefixedBatch) NewWindow(session, name, dir, initCmd string) {
+	b.queue(func() {
+		b.inner.NewWindow(b.p.add(session), b.p.add(name), dir, initCmd)
+		b.tag(session, name, dir)
+	})
+}
+
+func (b *prefixedBatch) SendKeys(session, window string, keys ...string) {
+	b.queue(func() { b.inner.SendKeys(b.p.add(session), b.resolve(session, window), keys...) })
+}
+
+func (b *prefixedBatch) SetWindowOption(session, window, key, value string) {
+	if key == OptionBranch {
+		b.retagged = true
+	}
+	b.queue(func() { b.inner.SetWindowOption(b.p.add(session), b.resolve(session, window), key, value) })
+}
+
+func (b *prefixedBatch) SwitchClient(session, window string) {
+	b.queue(func() { b.inner.SwitchClient(b.p.add(session), b.resolve(session, window)) })
+}
+
+func (b *prefixedBatch) Len() int { return b.n }
+
+func (b *prefixedBatch) Run() error {
+	if b.retagged {
+		defer b.p.forget()
+	}
+	err := b.inner.Run()
+	var be *BatchError
+	if errors.As(err, &be) && be.Index < len(b.owner) {
+		return &BatchError{Index: b.owner[be.Index], Command: be.Command, Err: be.Err}
+	}
+	return err
+}
@@ -1,6 +1,7 @@
 package tmux
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,13 +9,72 @@ import (
 )
 
 func newMock() *ClientMock {
-	return &ClientMock{}
+	return &ClientMock{
+		// No windows: targets fall back to the prefixed name.
+		ListWindowsFunc: func(session string) ([]Window, error) { return nil, nil },
+	}
 }
 
-func TestNewPrefixedClient_emptyPrefix(t *testing.T) {
+// taggedMock returns a mock whose session holds one tagged window ("@7", branch "win")
+// and records SetWindowOption calls.
+func taggedMock() *ClientMock {
 	inner := newMock()
-	got := NewPrefixedClient(inner, "")
-	assert.Same(t, inner, got, "empty prefix should return inner client as-is")
+	inner.ListWindowsFunc = func(session string) ([]Window, error) {
+		return []Window{{ID: "@7", Name: "renamed by user", Branch: "win"}}, nil
+	}
+	inner.SetWindowOptionFunc = func(session, window, key, value string) error { return nil }
+	return inner
+}
+
+func TestNewPrefixedClient_emptyPrefix(t *testing.T) {
+	t.Run("names are left as they are", func(t *testing.T) {
+		inner := newMock()
+		inner.HasSessionFunc = func(name string) (bool, error) {
+			assert.Equal(t, "sess", name)
+			return true, nil
+		}
+		_, err := NewPrefixedClient(inner, "").HasSession("sess")
+		require.NoError(t, err)
+	})
+
+	t.Run("new windows are tagged", func(t *testing.T) {
+		inner := taggedMock()
+		inner.NewWindowFunc = func(session, name, dir, initCmd string) (string, error) {
+			assert.Equal(t, "sess", session)
+			assert.Equal(t, "release/v1.2", name)
+			return "@9", nil
+		}
+		_, err := NewPrefixedClient(inner, "").NewWindow("sess", "release/v1.2", "/dir", "")
+		require.NoError(t, err)
+		require.Len(t, inner.SetWindowOptionCalls(), 2)
+		assert.Equal(t, OptionBranch, inner.SetWindowOptionCalls()[0].Key)
+		assert.Equal(t, "release/v1.2", inner.SetWindowOptionCalls()[0].Value)
+	})
+
+	t.Run("targets resolve to window IDs", func(t *testing.T) {
+		inner := taggedMock()
+		inner.SelectWindowFunc = func(session, window string) error {
+			assert.Equal(t, "@7", window)
+			return nil
+		}
+		require.NoError(t, NewPrefixedClient(inner, "").SelectWindow("sess", "win"))
+	})
+
+	t.Run("only tagged windows are managed", func(t *testing.T) {
+		inner := newMock()
+		inner.ListWindowsFunc = func(session string) ([]Window, error) {
+			return []Window{{ID: "@1", Name: "main", Branch: "main"}, {ID: "@2", Name: "zsh"}}, nil
+		}
+		windows, err := NewPrefixedClient(inner, "").ListWindows("sess")
+		require.NoError(t, err)
+		require.Len(t, windows, 1)
+		assert.Equal(t, "main", windows[0].Name)
+
+		unmanaged, err := NewPrefixedClient(inner, "").ListUnmanagedWindows("sess")
+		require.NoError(t, err)
+		require.Len(t, unmanaged, 1)
+		assert.Equal(t, "zsh", unmanaged[0].Name)
+	})
 }
 
 func TestPrefixedClient_HasSession(t *testing.T) {
@@ -31,15 +91,38 @@ func TestPrefixedClient_HasSession(t *testing.T) {
 
 func TestPrefixedClient_NewSession(t *testing.T) {
 	inner := newMock()
-	inner.NewSessionFunc = func(name, windowName, dir, initCmd string) error {
+	inner.NewSessionFunc = func(name, windowName, dir, initCmd string) (string, error) {
 		assert.Equal(t, "hs/sess", name)
 		assert.Equal(t, "hs/win", windowName)
 		assert.Equal(t, "/dir", dir)
 		assert.Equal(t, "echo hi", initCmd)
-		return nil
+		return "@1", nil
+	}
+	inner.SetWindowOptionFunc = func(session, window, key, value string) error { return nil }
+	c := NewPrefixedClient(inner, "hs/")
+	id, err := c.NewSession("sess", "win", "/dir", "echo hi")
+	require.NoError(t, err)
+	assert.Equal(t, "@1", id)
+
+	calls := inner.SetWindowOptionCalls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "hs/sess", calls[0].Session)
+	assert.Equal(t, "@1", calls[0].Window)
+	assert.Equal(t, OptionBranch, calls[0].Key)
+	assert.Equal(t, "win", calls[0].Value)
+	assert.Equal(t, OptionWorktree, calls[1].Key)
+	assert.Equal(t, "/dir", calls[1].Value)
+}
+
+func TestPrefixedClient_NewSession_error(t *testing.T) {
+	inner := newMock()
+	inner.NewSessionFunc = func(name, windowName, dir, initCmd string) (string, error) {
+		return "", fmt.Errorf("fail")
 	}
 	c := NewPrefixedClient(inner, "hs/")
-	require.NoError(t, c.NewSession("sess", "win", "/dir", "echo hi"))
+	_, err := c.NewSession("sess", "win", "/dir", "")
+	assert.Error(t, err)
+	assert.Empty(t, inner.SetWindowOptionCalls())
 }
 
 func TestPrefixedClient_KillSession(t *testing.T) {
@@ -52,6 +135,17 @@ func TestPrefixedClient_KillSession(t *testing.T) {
 	require.NoError(t, c.KillSession("sess"))
 }
 
+func TestPrefixedClient_RenameSession(t *testing.T) {
+	inner := newMock()
+	inner.RenameSessionFunc = func(old, new string) error {
+		assert.Equal(t, "hs/old", old)
+		assert.Equal(t, "hs/new", new)
+		return nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.RenameSession("old", "new"))
+}
+
 func TestPrefixedClient_ListWindows(t *testing.T) {
 	inner := newMock()
 	inner.ListWindowsFunc = func(session string) ([]Window, error) {
@@ -89,17 +183,166 @@ func TestPrefixedClient_ListWindows_filtersUnmanaged(t *testing.T) {
 	assert.Equal(t, "feat", ws[1].Name)
 }
 
+func TestPrefixedClient_ListWindows_tagged(t *testing.T) {
+	inner := newMock()
+	inner.ListWindowsFunc = func(session string) ([]Window, error) {
+		return []Window{
+			{ID: "@1", Name: "editor", Branch: "feat"},
+			{ID: "@2", Name: "hs/legacy"},
+			{ID: "@3", Name: "htop"},
+		}, nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	ws, err := c.ListWindows("sess")
+	require.NoError(t, err)
+	require.Len(t, ws, 2)
+	assert.Equal(t, "feat", ws[0].Name, "tagged window is reported by branch even when renamed")
+	assert.Equal(t, "@1", ws[0].ID)
+	assert.Equal(t, "legacy", ws[1].Name, "untagged window falls back to the name prefix")
+}
+
+func TestPrefixedClient_ListAllWindows(t *testing.T) {
+	inner := newMock()
+	inner.ListAllWindowsFunc = func() ([]Window, error) {
+		return []Window{
+			{ID: "@1", Name: "hs/main", Session: "hs/org/repo"},
+			{ID: "@2", Name: "editor", Branch: "feat", Session: "hs/org/repo/feat"},
+			{ID: "@3", Name: "htop", Session: "hs/org/repo/feat"},
+			{ID: "@4", Name: "hs/x", Branch: "x", Session: "personal"},
+		}, nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	ws, err := c.ListAllWindows()
+	require.NoError(t, err)
+	require.Len(t, ws, 2, "unmanaged windows and sessions without the prefix are excluded")
+	assert.Equal(t, Window{ID: "@1", Name: "main", Session: "org/repo"}, ws[0])
+	assert.Equal(t, Window{ID: "@2", Name: "feat", Branch: "feat", Session: "org/repo/feat"}, ws[1])
+}
+
+func TestPrefixedClient_ListUnmanagedWindows(t *testing.T) {
+	inner := newMock()
+	inner.ListWindowsFunc = func(session string) ([]Window, error) {
+		assert.Equal(t, "work", session, "the session name is passed as is")
+		return []Window{
+			{ID: "@1", Name: "hs/main"},
+			{ID: "@2", Name: "editor", Branch: "feat"},
+			{ID: "@3", Name: "htop", Dir: "/repo"},
+		}, nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	ws, err := c.ListUnmanagedWindows("work")
+	require.NoError(t, err)
+	assert.Equal(t, []Window{{ID: "@3", Name: "htop", Dir: "/repo"}}, ws)
+}
+
+func TestPrefixedClient_MoveWindow(t *testing.T) {
+	inner := newMock()
+	inner.MoveWindowFunc = func(window, session string) error {
+		assert.Equal(t, "@3", window)
+		assert.Equal(t, "hs/sess", session)
+		return nil
+	}
+	require.NoError(t, NewPrefixedClient(inner, "hs/").MoveWindow("@3", "sess"))
+}
+
 func TestPrefixedClient_NewWindow(t *testing.T) {
 	inner := newMock()
-	inner.NewWindowFunc = func(session, name, dir, initCmd string) error {
+	inner.NewWindowFunc = func(session, name, dir, initCmd string) (string, error) {
 		assert.Equal(t, "hs/sess", session)
 		assert.Equal(t, "hs/win", name)
 		assert.Equal(t, "/dir", dir)
 		assert.Equal(t, "echo hi", initCmd)
+		return "@2", nil
+	}
+	inner.SetWindowOptionFunc = func(session, window, key, value string) error { return nil }
+	c := NewPrefixedClient(inner, "hs/")
+	id, err := c.NewWindow("sess", "win", "/dir", "echo hi")
+	require.NoError(t, err)
+	assert.Equal(t, "@2", id)
+	require.Len(t, inner.SetWindowOptionCalls(), 2)
+	assert.Equal(t, "@2", inner.SetWindowOptionCalls()[0].Window)
+}
+
+func TestPrefixedClient_resolvesWindowID(t *testing.T) {
+	inner := taggedMock()
+	inner.KillWindowFunc = func(session, window string) error {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "@7", window)
+		return nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.KillWindow("sess", "win"))
+}
+
+func TestPrefixedClient_resolveCachesWindows(t *testing.T) {
+	t.Run("windows are listed once for a loop", func(t *testing.T) {
+		inner := taggedMock()
+		c := NewPrefixedClient(inner, "hs/")
+		for range 3 {
+			require.NoError(t, c.SetWindowOption("sess", "win", OptionWorktree, "/wt"))
+		}
+		assert.Len(t, inner.ListWindowsCalls(), 1)
+		for _, call := range inner.SetWindowOptionCalls() {
+			assert.Equal(t, "@7", call.Window)
+		}
+	})
+
+	t.Run("ListWindows fills the cache", func(t *testing.T) {
+		inner := taggedMock()
+		c := NewPrefixedClient(inner, "hs/")
+		_, err := c.ListWindows("sess")
+		require.NoError(t, err)
+		require.NoError(t, c.SetWindowOption("sess", "win", OptionWorktree, "/wt"))
+		assert.Len(t, inner.ListWindowsCalls(), 1)
+		assert.Equal(t, "@7", inner.SetWindowOptionCalls()[0].Window)
+	})
+
+	t.Run("changing windows drops the cache", func(t *testing.T) {
+		inner := taggedMock()
+		inner.KillWindowFunc = func(session, window string) error { return nil }
+		c := NewPrefixedClient(inner, "hs/")
+		require.NoError(t, c.KillWindow("sess", "win"))
+		require.NoError(t, c.SetWindowOption("sess", "win", OptionBranch, "other"))
+		require.NoError(t, c.SetWindowOption("sess", "win", OptionWorktree, "/wt"))
+		assert.Len(t, inner.ListWindowsCalls(), 3)
+	})
+
+	t.Run("listing errors are not cached", func(t *testing.T) {
+		inner := taggedMock()
+		inner.ListWindowsFunc = func(session string) ([]Window, error) { return nil, fmt.Errorf("tmux error") }
+		c := NewPrefixedClient(inner, "hs/")
+		require.NoError(t, c.SetWindowOption("sess", "win", OptionWorktree, "/wt"))
+		require.NoError(t, c.SetWindowOption("sess", "win", OptionWorktree, "/wt"))
+		assert.Len(t, inner.ListWindowsCalls(), 2)
+		assert.Equal(t, "hs/win", inner.SetWindowOptionCalls()[0].Window)
+	})
+}
+
+func TestPrefixedClient_RenameWindow_retags(t *testing.T) {
+	inner := taggedMock()
+	inner.RenameWindowFunc = func(session, old, new string) error {
+		assert.Equal(t, "@7", old)
+		assert.Equal(t, "hs/new", new)
 		return nil
 	}
 	c := NewPrefixedClient(inner, "hs/")
-	require.NoError(t, c.NewWindow("sess", "win", "/dir", "echo hi"))
+	require.NoError(t, c.RenameWindow("sess", "win", "new"))
+
+	calls := inner.SetWindowOptionCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "@7", calls[0].Window)
+	assert.Equal(t, OptionBranch, calls[0].Key)
+	assert.Equal(t, "new", calls[0].Value)
+}
+
+func TestPrefixedClient_SetWindowOption(t *testing.T) {
+	inner := taggedMock()
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.SetWindowOption("sess", "win", OptionWorktree, "/new"))
+	calls := inner.SetWindowOptionCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "hs/sess", calls[0].Session)
+	assert.Equal(t, "@7", calls[0].Window)
 }
 
 func TestPrefixedClient_KillWindow(t *testing.T) {
@@ -121,6 +364,7 @@ func TestPrefixedClient_RenameWindow(t *testing.T) {
 		assert.Equal(t, "hs/new", new)
 		return nil
 	}
+	inner.SetWindowOptionFunc = func(session, window, key, value string) error { return nil }
 	c := NewPrefixedClient(inner, "hs/")
 	require.NoError(t, c.RenameWindow("sess", "old", "new"))
 }
@@ -137,6 +381,89 @@ func TestPrefixedClient_SendKeys(t *testing.T) {
 	require.NoError(t, c.SendKeys("sess", "win", "C-u", "cd /dir", "Enter"))
 }
 
+func TestPrefixedClient_SendKeys_paneID(t *testing.T) {
+	inner := newMock()
+	inner.SendKeysFunc = func(session, window string, keys ...string) error {
+		assert.Equal(t, "%3", window)
+		return nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.SendKeys("sess", "%3", "Enter"))
+	assert.Empty(t, inner.ListWindowsCalls(), "pane IDs are not resolved")
+}
+
+func TestPrefixedClient_SwapWindow(t *testing.T) {
+	inner := newMock()
+	inner.SwapWindowFunc = func(session, window string, index int) error {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "@3", window)
+		assert.Equal(t, 2, index)
+		return nil
+	}
+	inner.SelectWindowFunc = func(session, window string) error {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "@3", window)
+		return nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.SwapWindow("sess", "@3", 2))
+	require.NoError(t, c.SelectWindow("sess", "@3"))
+	assert.Empty(t, inner.ListWindowsCalls(), "window IDs are not resolved")
+}
+
+func TestPrefixedClient_Panes(t *testing.T) {
+	inner := newMock()
+	inner.ListPanesFunc = func(session, window string) ([]Pane, error) {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "hs/win", window)
+		return []Pane{{ID: "%1"}}, nil
+	}
+	inner.SplitPaneFunc = func(pane string, opts SplitOptions) (string, error) {
+		assert.Equal(t, "%1", pane)
+		return "%2", nil
+	}
+	inner.SelectPaneFunc = func(pane string) error {
+		assert.Equal(t, "%2", pane)
+		return nil
+	}
+	inner.SelectLayoutFunc = func(session, window, layout string) error {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "hs/win", window)
+		assert.Equal(t, "tiled", layout)
+		return nil
+	}
+	inner.ForegroundCommandFunc = func(pane string) (string, error) {
+		assert.Equal(t, "%2", pane)
+		return "vim", nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	panes, err := c.ListPanes("sess", "win")
+	require.NoError(t, err)
+	id, err := c.SplitPane(panes[0].ID, SplitOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.SelectPane(id))
+	require.NoError(t, c.SelectLayout("sess", "win", "tiled"))
+	cmd, err := c.ForegroundCommand(id)
+	require.NoError(t, err)
+	assert.Equal(t, "vim", cmd)
+}
+
+func TestPrefixedClient_OptionsAndHooks(t *testing.T) {
+	inner := newMock()
+	inner.SetSessionOptionFunc = func(session, key, value string) error {
+		assert.Equal(t, "hs/sess", session)
+		return nil
+	}
+	inner.SetGlobalHookFunc = func(hook, command string) error { return nil }
+	inner.DisplayMessageFunc = func(message string) error { return nil }
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.SetSessionOption("sess", "@hashi_hooks", "1"))
+	require.NoError(t, c.SetGlobalHook("window-renamed[42]", "true"))
+	require.NoError(t, c.DisplayMessage("hello"))
+	assert.Equal(t, "window-renamed[42]", inner.SetGlobalHookCalls()[0].Hook)
+	assert.Equal(t, "hello", inner.DisplayMessageCalls()[0].Message)
+}
+
 func TestPrefixedClient_PaneCurrentCommand(t *testing.T) {
 	inner := newMock()
 	inner.PaneCurrentCommandFunc = func(session, window string) (string, error) {
@@ -172,6 +499,46 @@ func TestPrefixedClient_SwitchClient(t *testing.T) {
 	require.NoError(t, c.SwitchClient("sess", "win"))
 }
 
+func TestPrefixedClient_AttachGrouped(t *testing.T) {
+	inner := newMock()
+	inner.AttachGroupedFunc = func(session, name, window string) error {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "hs/sess~1", name)
+		assert.Empty(t, window, "the current window is kept")
+		return nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.AttachGrouped("sess", "sess~1", ""))
+}
+
+func TestPrefixedClient_SwitchClientGrouped(t *testing.T) {
+	inner := newMock()
+	inner.SwitchClientGroupedFunc = func(session, name, window string) error {
+		assert.Equal(t, "hs/sess", session)
+		assert.Equal(t, "hs/sess~1", name)
+		assert.Equal(t, "hs/win", window)
+		return nil
+	}
+	c := NewPrefixedClient(inner, "hs/")
+	require.NoError(t, c.SwitchClientGrouped("sess", "sess~1", "win"))
+}
+
+func TestPrefixedClient_CurrentSession(t *testing.T) {
+	inner := newMock()
+	inner.CurrentSessionFunc = func() (string, error) { return "hs/sess~1", nil }
+	name, err := NewPrefixedClient(inner, "hs/").CurrentSession()
+	require.NoError(t, err)
+	assert.Equal(t, "sess~1", name)
+}
+
+func TestPrefixedClient_CurrentWindow(t *testing.T) {
+	inner := newMock()
+	inner.CurrentWindowFunc = func() (string, error) { return "@2", nil }
+	id, err := NewPrefixedClient(inner, "hs/").CurrentWindow()
+	require.NoError(t, err)
+	assert.Equal(t, "@2", id)
+}
+
 func TestPrefixedClient_IsInsideTmux(t *testing.T) {
 	inner := newMock()
 	inner.IsInsideTmuxFunc = func() bool { return true }
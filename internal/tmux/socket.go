@@ -0,0 +1,85 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Option configures a tmux Client.
+type Option func(*options)
+
+type options struct {
+	socket string
+}
+
+// WithSocket makes the Client use a dedicated tmux server instead of the
+// user's default one. socket is a socket name (tmux -L) or, if it contains a
+// "/", a socket path (tmux -S). An empty socket uses the default server.
+func WithSocket(socket string) Option {
+	return func(o *options) { o.socket = socket }
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// serverArgs returns the global tmux arguments selecting the server for socket.
+func serverArgs(socket string) []string {
+	switch {
+	case socket == "":
+		return nil
+	case strings.Contains(socket, "/"):
+		return []string{"-S", socket}
+	default:
+		return []string{"-L", socket}
+	}
+}
+
+// socketPath returns the path of the server socket for socket, resolving a
+// socket name the way tmux does: $TMUX_TMPDIR (or /tmp) + /tmux-UID/name.
+func socketPath(socket string) string {
+	if strings.Contains(socket, "/") {
+		return socket
+	}
+	dir := os.Getenv("TMUX_TMPDIR")
+	if dir == "" {
+		dir = "/tmp"
+	}
+	return filepath.Join(dir, fmt.Sprintf("tmux-%d", os.Getuid()), socket)
+}
+
+// sessionID returns the ID (e.g. "$3") of the session named by $TMUX.
+func sessionID(tmuxEnv string) (string, bool) {
+	parts := strings.Split(tmuxEnv, ",")
+	if len(parts) != 3 || parts[2] == "" {
+		return "", false
+	}
+	return "$" + parts[2], true
+}
+
+// insideServer reports whether $TMUX (socket path, server PID and session,
+// separated by commas) belongs to the server at socket. An empty socket
+// matches any server.
+func insideServer(tmuxEnv, socket string) bool {
+	if tmuxEnv == "" {
+		return false
+	}
+	if socket == "" {
+		return true
+	}
+	current, _, _ := strings.Cut(tmuxEnv, ",")
+	want := socketPath(socket)
+	if filepath.Clean(current) == filepath.Clean(want) {
+		return true
+	}
+	// The paths may differ only by symlinks (e.g. /tmp on macOS).
+	a, errA := os.Stat(current)
+	b, errB := os.Stat(want)
+	return errA == nil && errB == nil && os.SameFile(a, b)
+}
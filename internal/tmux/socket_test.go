@@ -0,0 +1,119 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerArgs(t *testing.T) {
+	assert.Nil(t, serverArgs(""))
+	assert.Equal(t, []string{"-L", "hashi"}, serverArgs("hashi"))
+	assert.Equal(t, []string{"-S", "/run/hashi.sock"}, serverArgs("/run/hashi.sock"))
+	assert.Equal(t, []string{"-S", "./hashi.sock"}, serverArgs("./hashi.sock"))
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Setenv("TMUX_TMPDIR", "/var/tmp")
+	assert.Equal(t, fmt.Sprintf("/var/tmp/tmux-%d/hashi", os.Getuid()), socketPath("hashi"))
+	assert.Equal(t, "/run/hashi.sock", socketPath("/run/hashi.sock"))
+
+	t.Setenv("TMUX_TMPDIR", "")
+	assert.Equal(t, fmt.Sprintf("/tmp/tmux-%d/hashi", os.Getuid()), socketPath("hashi"))
+}
+
+func TestSessionID(t *testing.T) {
+	id, ok := sessionID("/tmp/tmux-1000/default,12345,3")
+	assert.True(t, ok)
+	assert.Equal(t, "$3", id)
+
+	_, ok = sessionID("")
+	assert.False(t, ok)
+	_, ok = sessionID("/tmp/tmux-1000/default,12345")
+	assert.False(t, ok)
+}
+
+func TestInsideServer(t *testing.T) {
+	t.Setenv("TMUX_TMPDIR", "/tmp")
+	named := fmt.Sprintf("/tmp/tmux-%d/hashi", os.Getuid())
+
+	tests := []struct {
+		name    string
+		tmuxEnv string
+		socket  string
+		want    bool
+	}{
+		{"outside tmux", "", "", false},
+		{"any server without socket", "/tmp/tmux-1000/default,1,0", "", true},
+		{"same named server", named + ",1,0", "hashi", true},
+		{"other named server", fmt.Sprintf("/tmp/tmux-%d/default,1,0", os.Getuid()), "hashi", false},
+		{"same socket path", "/run/hashi.sock,1,0", "/run/hashi.sock", true},
+		{"other socket path", "/run/other.sock,1,0", "/run/hashi.sock", false},
+		{"outside tmux with socket", "", "hashi", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, insideServer(tt.tmuxEnv, tt.socket))
+		})
+	}
+
+	t.Run("same socket through a symlink", func(t *testing.T) {
+		dir := t.TempDir()
+		sock := filepath.Join(dir, "sock")
+		require.NoError(t, os.WriteFile(sock, nil, 0o600))
+		link := filepath.Join(dir, "link")
+		require.NoError(t, os.Symlink(dir, link))
+		assert.True(t, insideServer(filepath.Join(link, "sock")+",1,0", sock))
+	})
+}
+
+func TestClient_WithSocket(t *testing.T) {
+	e := mockExec()
+	e.RunFunc = func(name string, args ...string) error { return nil }
+	e.OutputFunc = func(name string, args ...string) (string, error) { return "", nil }
+	e.RunInteractiveFunc = func(name string, args ...string) error { return nil }
+	c := NewClient(e, WithSocket("hashi"))
+
+	require.NoError(t, c.KillSession("sess"))
+	_, err := c.ListWindows("sess")
+	require.NoError(t, err)
+	require.NoError(t, c.SwitchClient("sess", "@1"))
+	require.NoError(t, c.AttachSession("sess", "@1"))
+	b := NewBatch(c)
+	b.SendKeys("sess", "@1", "Enter")
+	require.NoError(t, b.Run())
+
+	assert.Equal(t, []string{"-L", "hashi", "kill-session", "-t", "sess"}, e.RunCalls()[0].Args)
+	assert.Equal(t, []string{"-L", "hashi", "switch-client", "-t", "sess:@1"}, e.RunCalls()[1].Args)
+	assert.Equal(t, []string{"-L", "hashi", "list-windows", "-t", "sess", "-F", windowListFormat}, e.OutputCalls()[0].Args)
+	assert.Equal(t, []string{"-L", "hashi", "send-keys", "-t", "sess:@1", "Enter"}, e.OutputCalls()[1].Args)
+	assert.Equal(t, []string{"-L", "hashi", "attach-session", "-t", "sess:@1"}, e.RunInteractiveCalls()[0].Args)
+}
+
+func TestControlClient_WithSocket(t *testing.T) {
+	e := controlExec()
+	e.RunFunc = func(name string, args ...string) error { return nil }
+	c := NewControlClient(e, WithSocket("/run/hashi.sock"))
+
+	// The connection cannot be started, so the command falls back to a process.
+	require.NoError(t, c.KillSession("sess"))
+	assert.Equal(t, []string{"-S", "/run/hashi.sock", "-C", "attach-session"}, e.StartCalls()[0].Args)
+	assert.Equal(t, []string{"-S", "/run/hashi.sock", "kill-session", "-t", "sess"}, e.RunCalls()[0].Args)
+}
+
+func TestClient_WithSocket_attachFromOtherServer(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	e := mockExec()
+	e.RunInteractiveFunc = func(name string, args ...string) error {
+		assert.Empty(t, os.Getenv("TMUX"), "tmux refuses to attach nested while $TMUX is set")
+		return nil
+	}
+	c := NewClient(e, WithSocket("hashi"))
+	assert.False(t, c.IsInsideTmux())
+	require.NoError(t, c.AttachSession("sess", "@1"))
+	assert.Len(t, e.RunInteractiveCalls(), 1)
+}
@@ -1,32 +1,163 @@
 package tmux
 
-//go:generate moq -out tmux_mock.go . Client
+//go:generate moq -out tmux_mock.go . Client Batch
 
 // Client abstracts tmux operations for testing.
+// NewSession and NewWindow return the ID of the window they create.
+// A window argument may also be a pane ID (e.g. "%3") to target that pane.
 type Client interface {
 	// Session operations
 	HasSession(name string) (bool, error)
-	NewSession(name, windowName, dir, initCmd string) error
+	NewSession(name, windowName, dir, initCmd string) (string, error)
 	KillSession(name string) error
+	RenameSession(old, new string) error
 
 	// Window operations
 	ListWindows(session string) ([]Window, error)
-	NewWindow(session, name, dir, initCmd string) error
+	// ListAllWindows lists the windows of every session.
+	ListAllWindows() ([]Window, error)
+	// ListUnmanagedWindows lists the windows of the session that hashi does
+	// not manage. Unlike the other methods, session is the name tmux gives
+	// it, since such windows may be in any session.
+	ListUnmanagedWindows(session string) ([]Window, error)
+	NewWindow(session, name, dir, initCmd string) (string, error)
 	KillWindow(session, window string) error
 	RenameWindow(session, old, new string) error
 	SendKeys(session, window string, keys ...string) error
 	PaneCurrentCommand(session, window string) (string, error)
+	SetWindowOption(session, window, key, value string) error
+	// SwapWindow swaps the window with the window at index in its session.
+	// The session's current window index does not change.
+	SwapWindow(session, window string, index int) error
+	SelectWindow(session, window string) error
+	// MoveWindow moves the window with the given ID to the end of the session.
+	MoveWindow(window, session string) error
+
+	// Pane operations
+	ListPanes(session, window string) ([]Pane, error)
+	// SplitPane splits the pane with the given ID and returns the new pane's ID.
+	SplitPane(pane string, opts SplitOptions) (string, error)
+	SelectPane(pane string) error
+	// CapturePane returns the text of the pane with the given ID, wrapped
+	// lines joined: the visible part, plus up to lines lines of history.
+	CapturePane(pane string, lines int) (string, error)
+	// PipePane pipes the output of the pane with the given ID to the stdin
+	// of a shell command, replacing any previous pipe. An empty command
+	// closes the pipe.
+	PipePane(pane, command string) error
+	// SelectLayout arranges the window's panes by a layout string as printed
+	// in #{window_layout}.
+	SelectLayout(session, window, layout string) error
+	// ForegroundCommand returns the command line of the job in the foreground
+	// of the pane, e.g. "npm run dev". At a prompt, that is the shell's.
+	ForegroundCommand(pane string) (string, error)
+
+	// Options and hooks
+	// SetSessionOption sets a session option; an empty value unsets it.
+	SetSessionOption(session, key, value string) error
+	// SetGlobalHook sets a global hook. The hook may name an index of the
+	// hook's array, e.g. "window-renamed[42]", to keep the other commands.
+	SetGlobalHook(hook, command string) error
+	// DisplayMessage shows a message in the status line of the current client.
+	DisplayMessage(message string) error
 
 	// Connection
 	AttachSession(session, window string) error
 	SwitchClient(session, window string) error
+	// AttachGrouped attaches the terminal to a new session named name,
+	// grouped with session: it shares the session's windows but has its own
+	// current window, and is destroyed when the client detaches. An empty
+	// window keeps the session's current window.
+	AttachGrouped(session, name, window string) error
+	// SwitchClientGrouped switches the client to a new grouped session, like
+	// AttachGrouped. The session is destroyed when the client leaves it.
+	SwitchClientGrouped(session, name, window string) error
+	// CurrentSession returns the session of the client running hashi.
+	CurrentSession() (string, error)
 
 	// Environment
 	IsInsideTmux() bool
+	// CurrentWindow returns the ID of the current window of the session named
+	// by $TMUX: inside a popup, the window the popup was opened from.
+	CurrentWindow() (string, error)
+}
+
+// Batch queues tmux commands and sends them to the server in one invocation,
+// chained with ";". tmux runs them in order and stops at the first failure,
+// which Run reports as a *BatchError.
+//
+// An empty window targets the window created by a preceding NewSession or
+// NewWindow for the same session in the batch.
+type Batch interface {
+	NewSession(name, windowName, dir, initCmd string)
+	NewWindow(session, name, dir, initCmd string)
+	SendKeys(session, window string, keys ...string)
+	SetWindowOption(session, window, key, value string)
+	SwitchClient(session, window string)
+	// Len returns the number of queued commands.
+	Len() int
+	Run() error
+}
+
+// Batcher is implemented by Clients that can send a Batch in one invocation.
+type Batcher interface {
+	NewBatch() Batch
+}
+
+// Window user options that tag hashi-managed windows.
+// They keep a window associated with its branch even if the window is renamed.
+const (
+	OptionBranch   = "@hashi_branch"
+	OptionWorktree = "@hashi_worktree"
+)
+
+// Window user options that describe the branch of a managed window, for use
+// in window-status-format. Dirty is "1" or "0", Ahead the number of commits
+// ahead of the upstream, and Status the branch's status as in hashi list.
+const (
+	OptionDirty  = "@hashi_dirty"
+	OptionAhead  = "@hashi_ahead"
+	OptionStatus = "@hashi_status"
+)
+
+// Pane represents a tmux pane entry.
+type Pane struct {
+	ID      string // tmux pane ID (e.g. "%5"), unique across the server
+	Active  bool
+	Command string // pane_current_command
+	Dir     string // pane_current_path
+	// Piped is set while the pane's output is piped to a command with pipe-pane.
+	Piped bool
+}
+
+// SplitOptions configures SplitPane. The new pane does not take the focus.
+type SplitOptions struct {
+	// Horizontal places the new pane beside the split pane; otherwise below it.
+	Horizontal bool
+	// Size is the new pane's size in lines/columns or a percentage (e.g. "30%"); empty for half.
+	Size    string
+	Dir     string
+	Command string
 }
 
 // Window represents a tmux window entry.
 type Window struct {
+	ID     string // tmux window ID (e.g. "@3"), stable for the window's lifetime
 	Name   string
 	Active bool
+	// Branch and Worktree are the values of OptionBranch and OptionWorktree ("" if unset).
+	Branch   string
+	Worktree string
+	// Session is the name of the window's session, and SessionAttached
+	// whether any client is attached to it.
+	Session         string
+	SessionAttached bool
+	// Index is the window's position in its session, and Activity the Unix
+	// time of its last activity.
+	Index    int
+	Activity int64
+	// Layout is the window's layout string, as accepted by SelectLayout.
+	Layout string
+	// Dir is the current directory of the window's active pane.
+	Dir string
 }
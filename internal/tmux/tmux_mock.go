@@ -17,9 +17,27 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
+//			AttachGroupedFunc: func(session string, name string, window string) error {
+//				panic("mock out the AttachGrouped method")
+//			},
 //			AttachSessionFunc: func(session string, window string) error {
 //				panic("mock out the AttachSession method")
 //			},
+//			CapturePaneFunc: func(pane string, lines int) (string, error) {
+//				panic("mock out the CapturePane method")
+//			},
+//			CurrentSessionFunc: func() (string, error) {
+//				panic("mock out the CurrentSession method")
+//			},
+//			CurrentWindowFunc: func() (string, error) {
+//				panic("mock out the CurrentWindow method")
+//			},
+//			DisplayMessageFunc: func(message string) error {
+//				panic("mock out the DisplayMessage method")
+//			},
+//			ForegroundCommandFunc: func(pane string) (string, error) {
+//				panic("mock out the ForegroundCommand method")
+//			},
 //			HasSessionFunc: func(name string) (bool, error) {
 //				panic("mock out the HasSession method")
 //			},
@@ -32,27 +50,72 @@ var _ Client = &ClientMock{}
 //			KillWindowFunc: func(session string, window string) error {
 //				panic("mock out the KillWindow method")
 //			},
+//			ListAllWindowsFunc: func() ([]Window, error) {
+//				panic("mock out the ListAllWindows method")
+//			},
+//			ListPanesFunc: func(session string, window string) ([]Pane, error) {
+//				panic("mock out the ListPanes method")
+//			},
+//			ListUnmanagedWindowsFunc: func(session string) ([]Window, error) {
+//				panic("mock out the ListUnmanagedWindows method")
+//			},
 //			ListWindowsFunc: func(session string) ([]Window, error) {
 //				panic("mock out the ListWindows method")
 //			},
-//			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) error {
+//			MoveWindowFunc: func(window string, session string) error {
+//				panic("mock out the MoveWindow method")
+//			},
+//			NewSessionFunc: func(name string, windowName string, dir string, initCmd string) (string, error) {
 //				panic("mock out the NewSession method")
 //			},
-//			NewWindowFunc: func(session string, name string, dir string, initCmd string) error {
+//			NewWindowFunc: func(session string, name string, dir string, initCmd string) (string, error) {
 //				panic("mock out the NewWindow method")
 //			},
 //			PaneCurrentCommandFunc: func(session string, window string) (string, error) {
 //				panic("mock out the PaneCurrentCommand method")
 //			},
+//			PipePaneFunc: func(pane string, command string) error {
+//				panic("mock out the PipePane method")
+//			},
+//			RenameSessionFunc: func(old string, new string) error {
+//				panic("mock out the RenameSession method")
+//			},
 //			RenameWindowFunc: func(session string, old string, new string) error {
 //				panic("mock out the RenameWindow method")
 //			},
+//			SelectLayoutFunc: func(session string, window string, layout string) error {
+//				panic("mock out the SelectLayout method")
+//			},
+//			SelectPaneFunc: func(pane string) error {
+//				panic("mock out the SelectPane method")
+//			},
+//			SelectWindowFunc: func(session string, window string) error {
+//				panic("mock out the SelectWindow method")
+//			},
 //			SendKeysFunc: func(session string, window string, keys ...string) error {
 //				panic("mock out the SendKeys method")
 //			},
+//			SetGlobalHookFunc: func(hook string, command string) error {
+//				panic("mock out the SetGlobalHook method")
+//			},
+//			SetSessionOptionFunc: func(session string, key string, value string) error {
+//				panic("mock out the SetSessionOption method")
+//			},
+//			SetWindowOptionFunc: func(session string, window string, key string, value string) error {
+//				panic("mock out the SetWindowOption method")
+//			},
+//			SplitPaneFunc: func(pane string, opts SplitOptions) (string, error) {
+//				panic("mock out the SplitPane method")
+//			},
+//			SwapWindowFunc: func(session string, window string, index int) error {
+//				panic("mock out the SwapWindow method")
+//			},
 //			SwitchClientFunc: func(session string, window string) error {
 //				panic("mock out the SwitchClient method")
 //			},
+//			SwitchClientGroupedFunc: func(session string, name string, window string) error {
+//				panic("mock out the SwitchClientGrouped method")
+//			},
 //		}
 //
 //		// use mockedClient in code that requires Client
@@ -60,9 +123,27 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// AttachGroupedFunc mocks the AttachGrouped method.
+	AttachGroupedFunc func(session string, name string, window string) error
+
 	// AttachSessionFunc mocks the AttachSession method.
 	AttachSessionFunc func(session string, window string) error
 
+	// CapturePaneFunc mocks the CapturePane method.
+	CapturePaneFunc func(pane string, lines int) (string, error)
+
+	// CurrentSessionFunc mocks the CurrentSession method.
+	CurrentSessionFunc func() (string, error)
+
+	// CurrentWindowFunc mocks the CurrentWindow method.
+	CurrentWindowFunc func() (string, error)
+
+	// DisplayMessageFunc mocks the DisplayMessage method.
+	DisplayMessageFunc func(message string) error
+
+	// ForegroundCommandFunc mocks the ForegroundCommand method.
+	ForegroundCommandFunc func(pane string) (string, error)
+
 	// HasSessionFunc mocks the HasSession method.
 	HasSessionFunc func(name string) (bool, error)
 
@@ -75,29 +156,83 @@ type ClientMock struct {
 	// KillWindowFunc mocks the KillWindow method.
 	KillWindowFunc func(session string, window string) error
 
+	// ListAllWindowsFunc mocks the ListAllWindows method.
+	ListAllWindowsFunc func() ([]Window, error)
+
+	// ListPanesFunc mocks the ListPanes method.
+	ListPanesFunc func(session string, window string) ([]Pane, error)
+
+	// ListUnmanagedWindowsFunc mocks the ListUnmanagedWindows method.
+	ListUnmanagedWindowsFunc func(session string) ([]Window, error)
+
 	// ListWindowsFunc mocks the ListWindows method.
 	ListWindowsFunc func(session string) ([]Window, error)
 
+	// MoveWindowFunc mocks the MoveWindow method.
+	MoveWindowFunc func(window string, session string) error
+
 	// NewSessionFunc mocks the NewSession method.
-	NewSessionFunc func(name string, windowName string, dir string, initCmd string) error
+	NewSessionFunc func(name string, windowName string, dir string, initCmd string) (string, error)
 
 	// NewWindowFunc mocks the NewWindow method.
-	NewWindowFunc func(session string, name string, dir string, initCmd string) error
+	NewWindowFunc func(session string, name string, dir string, initCmd string) (string, error)
 
 	// PaneCurrentCommandFunc mocks the PaneCurrentCommand method.
 	PaneCurrentCommandFunc func(session string, window string) (string, error)
 
+	// PipePaneFunc mocks the PipePane method.
+	PipePaneFunc func(pane string, command string) error
+
+	// RenameSessionFunc mocks the RenameSession method.
+	RenameSessionFunc func(old string, new string) error
+
 	// RenameWindowFunc mocks the RenameWindow method.
 	RenameWindowFunc func(session string, old string, new string) error
 
+	// SelectLayoutFunc mocks the SelectLayout method.
+	SelectLayoutFunc func(session string, window string, layout string) error
+
+	// SelectPaneFunc mocks the SelectPane method.
+	SelectPaneFunc func(pane string) error
+
+	// SelectWindowFunc mocks the SelectWindow method.
+	SelectWindowFunc func(session string, window string) error
+
 	// SendKeysFunc mocks the SendKeys method.
 	SendKeysFunc func(session string, window string, keys ...string) error
 
+	// SetGlobalHookFunc mocks the SetGlobalHook method.
+	SetGlobalHookFunc func(hook string, command string) error
+
+	// SetSessionOptionFunc mocks the SetSessionOption method.
+	SetSessionOptionFunc func(session string, key string, value string) error
+
+	// SetWindowOptionFunc mocks the SetWindowOption method.
+	SetWindowOptionFunc func(session string, window string, key string, value string) error
+
+	// SplitPaneFunc mocks the SplitPane method.
+	SplitPaneFunc func(pane string, opts SplitOptions) (string, error)
+
+	// SwapWindowFunc mocks the SwapWindow method.
+	SwapWindowFunc func(session string, window string, index int) error
+
 	// SwitchClientFunc mocks the SwitchClient method.
 	SwitchClientFunc func(session string, window string) error
 
+	// SwitchClientGroupedFunc mocks the SwitchClientGrouped method.
+	SwitchClientGroupedFunc func(session string, name string, window string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AttachGrouped holds details about calls to the AttachGrouped method.
+		AttachGrouped []struct {
+			// Session is the session argument value.
+			Session string
+			// Name is the name argument value.
+			Name string
+			// Window is the window argument value.
+			Window string
+		}
 		// AttachSession holds details about calls to the AttachSession method.
 		AttachSession []struct {
 			// Session is the session argument value.
@@ -105,6 +240,29 @@ type ClientMock struct {
 			// Window is the window argument value.
 			Window string
 		}
+		// CapturePane holds details about calls to the CapturePane method.
+		CapturePane []struct {
+			// Pane is the pane argument value.
+			Pane string
+			// Lines is the lines argument value.
+			Lines int
+		}
+		// CurrentSession holds details about calls to the CurrentSession method.
+		CurrentSession []struct {
+		}
+		// CurrentWindow holds details about calls to the CurrentWindow method.
+		CurrentWindow []struct {
+		}
+		// DisplayMessage holds details about calls to the DisplayMessage method.
+		DisplayMessage []struct {
+			// Message is the message argument value.
+			Message string
+		}
+		// ForegroundCommand holds details about calls to the ForegroundCommand method.
+		ForegroundCommand []struct {
+			// Pane is the pane argument value.
+			Pane string
+		}
 		// HasSession holds details about calls to the HasSession method.
 		HasSession []struct {
 			// Name is the name argument value.
@@ -125,11 +283,33 @@ type ClientMock struct {
 			// Window is the window argument value.
 			Window string
 		}
+		// ListAllWindows holds details about calls to the ListAllWindows method.
+		ListAllWindows []struct {
+		}
+		// ListPanes holds details about calls to the ListPanes method.
+		ListPanes []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+		}
+		// ListUnmanagedWindows holds details about calls to the ListUnmanagedWindows method.
+		ListUnmanagedWindows []struct {
+			// Session is the session argument value.
+			Session string
+		}
 		// ListWindows holds details about calls to the ListWindows method.
 		ListWindows []struct {
 			// Session is the session argument value.
 			Session string
 		}
+		// MoveWindow holds details about calls to the MoveWindow method.
+		MoveWindow []struct {
+			// Window is the window argument value.
+			Window string
+			// Session is the session argument value.
+			Session string
+		}
 		// NewSession holds details about calls to the NewSession method.
 		NewSession []struct {
 			// Name is the name argument value.
@@ -159,6 +339,20 @@ type ClientMock struct {
 			// Window is the window argument value.
 			Window string
 		}
+		// PipePane holds details about calls to the PipePane method.
+		PipePane []struct {
+			// Pane is the pane argument value.
+			Pane string
+			// Command is the command argument value.
+			Command string
+		}
+		// RenameSession holds details about calls to the RenameSession method.
+		RenameSession []struct {
+			// Old is the old argument value.
+			Old string
+			// New is the new argument value.
+			New string
+		}
 		// RenameWindow holds details about calls to the RenameWindow method.
 		RenameWindow []struct {
 			// Session is the session argument value.
@@ -168,6 +362,27 @@ type ClientMock struct {
 			// New is the new argument value.
 			New string
 		}
+		// SelectLayout holds details about calls to the SelectLayout method.
+		SelectLayout []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+			// Layout is the layout argument value.
+			Layout string
+		}
+		// SelectPane holds details about calls to the SelectPane method.
+		SelectPane []struct {
+			// Pane is the pane argument value.
+			Pane string
+		}
+		// SelectWindow holds details about calls to the SelectWindow method.
+		SelectWindow []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+		}
 		// SendKeys holds details about calls to the SendKeys method.
 		SendKeys []struct {
 			// Session is the session argument value.
@@ -177,6 +392,49 @@ type ClientMock struct {
 			// Keys is the keys argument value.
 			Keys []string
 		}
+		// SetGlobalHook holds details about calls to the SetGlobalHook method.
+		SetGlobalHook []struct {
+			// Hook is the hook argument value.
+			Hook string
+			// Command is the command argument value.
+			Command string
+		}
+		// SetSessionOption holds details about calls to the SetSessionOption method.
+		SetSessionOption []struct {
+			// Session is the session argument value.
+			Session string
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value string
+		}
+		// SetWindowOption holds details about calls to the SetWindowOption method.
+		SetWindowOption []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value string
+		}
+		// SplitPane holds details about calls to the SplitPane method.
+		SplitPane []struct {
+			// Pane is the pane argument value.
+			Pane string
+			// Opts is the opts argument value.
+			Opts SplitOptions
+		}
+		// SwapWindow holds details about calls to the SwapWindow method.
+		SwapWindow []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+			// Index is the index argument value.
+			Index int
+		}
 		// SwitchClient holds details about calls to the SwitchClient method.
 		SwitchClient []struct {
 			// Session is the session argument value.
@@ -184,19 +442,89 @@ type ClientMock struct {
 			// Window is the window argument value.
 			Window string
 		}
+		// SwitchClientGrouped holds details about calls to the SwitchClientGrouped method.
+		SwitchClientGrouped []struct {
+			// Session is the session argument value.
+			Session string
+			// Name is the name argument value.
+			Name string
+			// Window is the window argument value.
+			Window string
+		}
+	}
+	lockAttachGrouped        sync.RWMutex
+	lockAttachSession        sync.RWMutex
+	lockCapturePane          sync.RWMutex
+	lockCurrentSession       sync.RWMutex
+	lockCurrentWindow        sync.RWMutex
+	lockDisplayMessage       sync.RWMutex
+	lockForegroundCommand    sync.RWMutex
+	lockHasSession           sync.RWMutex
+	lockIsInsideTmux         sync.RWMutex
+	lockKillSession          sync.RWMutex
+	lockKillWindow           sync.RWMutex
+	lockListAllWindows       sync.RWMutex
+	lockListPanes            sync.RWMutex
+	lockListUnmanagedWindows sync.RWMutex
+	lockListWindows          sync.RWMutex
+	lockMoveWindow           sync.RWMutex
+	lockNewSession           sync.RWMutex
+	lockNewWindow            sync.RWMutex
+	lockPaneCurrentCommand   sync.RWMutex
+	lockPipePane             sync.RWMutex
+	lockRenameSession        sync.RWMutex
+	lockRenameWindow         sync.RWMutex
+	lockSelectLayout         sync.RWMutex
+	lockSelectPane           sync.RWMutex
+	lockSelectWindow         sync.RWMutex
+	lockSendKeys             sync.RWMutex
+	lockSetGlobalHook        sync.RWMutex
+	lockSetSessionOption     sync.RWMutex
+	lockSetWindowOption      sync.RWMutex
+	lockSplitPane            sync.RWMutex
+	lockSwapWindow           sync.RWMutex
+	lockSwitchClient         sync.RWMutex
+	lockSwitchClientGrouped  sync.RWMutex
+}
+
+// AttachGrouped calls AttachGroupedFunc.
+func (mock *ClientMock) AttachGrouped(session string, name string, window string) error {
+	if mock.AttachGroupedFunc == nil {
+		panic("ClientMock.AttachGroupedFunc: method is nil but Client.AttachGrouped was just called")
+	}
+	callInfo := struct {
+		Session string
+		Name    string
+		Window  string
+	}{
+		Session: session,
+		Name:    name,
+		Window:  window,
+	}
+	mock.lockAttachGrouped.Lock()
+	mock.calls.AttachGrouped = append(mock.calls.AttachGrouped, callInfo)
+	mock.lockAttachGrouped.Unlock()
+	return mock.AttachGroupedFunc(session, name, window)
+}
+
+// AttachGroupedCalls gets all the calls that were made to AttachGrouped.
+// Check the length with:
+//
+//	len(mockedClient.AttachGroupedCalls())
+func (mock *ClientMock) AttachGroupedCalls() []struct {
+	Session string
+	Name    string
+	Window  string
+} {
+	var calls []struct {
+		Session string
+		Name    string
+		Window  string
 	}
-	lockAttachSession      sync.RWMutex
-	lockHasSession         sync.RWMutex
-	lockIsInsideTmux       sync.RWMutex
-	lockKillSession        sync.RWMutex
-	lockKillWindow         sync.RWMutex
-	lockListWindows        sync.RWMutex
-	lockNewSession         sync.RWMutex
-	lockNewWindow          sync.RWMutex
-	lockPaneCurrentCommand sync.RWMutex
-	lockRenameWindow       sync.RWMutex
-	lockSendKeys           sync.RWMutex
-	lockSwitchClient       sync.RWMutex
+	mock.lockAttachGrouped.RLock()
+	calls = mock.calls.AttachGrouped
+	mock.lockAttachGrouped.RUnlock()
+	return calls
 }
 
 // AttachSession calls AttachSessionFunc.
@@ -235,6 +563,160 @@ func (mock *ClientMock) AttachSessionCalls() []struct {
 	return calls
 }
 
+// CapturePane calls CapturePaneFunc.
+func (mock *ClientMock) CapturePane(pane string, lines int) (string, error) {
+	if mock.CapturePaneFunc == nil {
+		panic("ClientMock.CapturePaneFunc: method is nil but Client.CapturePane was just called")
+	}
+	callInfo := struct {
+		Pane  string
+		Lines int
+	}{
+		Pane:  pane,
+		Lines: lines,
+	}
+	mock.lockCapturePane.Lock()
+	mock.calls.CapturePane = append(mock.calls.CapturePane, callInfo)
+	mock.lockCapturePane.Unlock()
+	return mock.CapturePaneFunc(pane, lines)
+}
+
+// CapturePaneCalls gets all the calls that were made to CapturePane.
+// Check the length with:
+//
+//	len(mockedClient.CapturePaneCalls())
+func (mock *ClientMock) CapturePaneCalls() []struct {
+	Pane  string
+	Lines int
+} {
+	var calls []struct {
+		Pane  string
+		Lines int
+	}
+	mock.lockCapturePane.RLock()
+	calls = mock.calls.CapturePane
+	mock.lockCapturePane.RUnlock()
+	return calls
+}
+
+// CurrentSession calls CurrentSessionFunc.
+func (mock *ClientMock) CurrentSession() (string, error) {
+	if mock.CurrentSessionFunc == nil {
+		panic("ClientMock.CurrentSessionFunc: method is nil but Client.CurrentSession was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCurrentSession.Lock()
+	mock.calls.CurrentSession = append(mock.calls.CurrentSession, callInfo)
+	mock.lockCurrentSession.Unlock()
+	return mock.CurrentSessionFunc()
+}
+
+// CurrentSessionCalls gets all the calls that were made to CurrentSession.
+// Check the length with:
+//
+//	len(mockedClient.CurrentSessionCalls())
+func (mock *ClientMock) CurrentSessionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCurrentSession.RLock()
+	calls = mock.calls.CurrentSession
+	mock.lockCurrentSession.RUnlock()
+	return calls
+}
+
+// CurrentWindow calls CurrentWindowFunc.
+func (mock *ClientMock) CurrentWindow() (string, error) {
+	if mock.CurrentWindowFunc == nil {
+		panic("ClientMock.CurrentWindowFunc: method is nil but Client.CurrentWindow was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCurrentWindow.Lock()
+	mock.calls.CurrentWindow = append(mock.calls.CurrentWindow, callInfo)
+	mock.lockCurrentWindow.Unlock()
+	return mock.CurrentWindowFunc()
+}
+
+// CurrentWindowCalls gets all the calls that were made to CurrentWindow.
+// Check the length with:
+//
+//	len(mockedClient.CurrentWindowCalls())
+func (mock *ClientMock) CurrentWindowCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCurrentWindow.RLock()
+	calls = mock.calls.CurrentWindow
+	mock.lockCurrentWindow.RUnlock()
+	return calls
+}
+
+// DisplayMessage calls DisplayMessageFunc.
+func (mock *ClientMock) DisplayMessage(message string) error {
+	if mock.DisplayMessageFunc == nil {
+		panic("ClientMock.DisplayMessageFunc: method is nil but Client.DisplayMessage was just called")
+	}
+	callInfo := struct {
+		Message string
+	}{
+		Message: message,
+	}
+	mock.lockDisplayMessage.Lock()
+	mock.calls.DisplayMessage = append(mock.calls.DisplayMessage, callInfo)
+	mock.lockDisplayMessage.Unlock()
+	return mock.DisplayMessageFunc(message)
+}
+
+// DisplayMessageCalls gets all the calls that were made to DisplayMessage.
+// Check the length with:
+//
+//	len(mockedClient.DisplayMessageCalls())
+func (mock *ClientMock) DisplayMessageCalls() []struct {
+	Message string
+} {
+	var calls []struct {
+		Message string
+	}
+	mock.lockDisplayMessage.RLock()
+	calls = mock.calls.DisplayMessage
+	mock.lockDisplayMessage.RUnlock()
+	return calls
+}
+
+// ForegroundCommand calls ForegroundCommandFunc.
+func (mock *ClientMock) ForegroundCommand(pane string) (string, error) {
+	if mock.ForegroundCommandFunc == nil {
+		panic("ClientMock.ForegroundCommandFunc: method is nil but Client.ForegroundCommand was just called")
+	}
+	callInfo := struct {
+		Pane string
+	}{
+		Pane: pane,
+	}
+	mock.lockForegroundCommand.Lock()
+	mock.calls.ForegroundCommand = append(mock.calls.ForegroundCommand, callInfo)
+	mock.lockForegroundCommand.Unlock()
+	return mock.ForegroundCommandFunc(pane)
+}
+
+// ForegroundCommandCalls gets all the calls that were made to ForegroundCommand.
+// Check the length with:
+//
+//	len(mockedClient.ForegroundCommandCalls())
+func (mock *ClientMock) ForegroundCommandCalls() []struct {
+	Pane string
+} {
+	var calls []struct {
+		Pane string
+	}
+	mock.lockForegroundCommand.RLock()
+	calls = mock.calls.ForegroundCommand
+	mock.lockForegroundCommand.RUnlock()
+	return calls
+}
+
 // HasSession calls HasSessionFunc.
 func (mock *ClientMock) HasSession(name string) (bool, error) {
 	if mock.HasSessionFunc == nil {
@@ -362,6 +844,101 @@ func (mock *ClientMock) KillWindowCalls() []struct {
 	return calls
 }
 
+// ListAllWindows calls ListAllWindowsFunc.
+func (mock *ClientMock) ListAllWindows() ([]Window, error) {
+	if mock.ListAllWindowsFunc == nil {
+		panic("ClientMock.ListAllWindowsFunc: method is nil but Client.ListAllWindows was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListAllWindows.Lock()
+	mock.calls.ListAllWindows = append(mock.calls.ListAllWindows, callInfo)
+	mock.lockListAllWindows.Unlock()
+	return mock.ListAllWindowsFunc()
+}
+
+// ListAllWindowsCalls gets all the calls that were made to ListAllWindows.
+// Check the length with:
+//
+//	len(mockedClient.ListAllWindowsCalls())
+func (mock *ClientMock) ListAllWindowsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListAllWindows.RLock()
+	calls = mock.calls.ListAllWindows
+	mock.lockListAllWindows.RUnlock()
+	return calls
+}
+
+// ListPanes calls ListPanesFunc.
+func (mock *ClientMock) ListPanes(session string, window string) ([]Pane, error) {
+	if mock.ListPanesFunc == nil {
+		panic("ClientMock.ListPanesFunc: method is nil but Client.ListPanes was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+	}{
+		Session: session,
+		Window:  window,
+	}
+	mock.lockListPanes.Lock()
+	mock.calls.ListPanes = append(mock.calls.ListPanes, callInfo)
+	mock.lockListPanes.Unlock()
+	return mock.ListPanesFunc(session, window)
+}
+
+// ListPanesCalls gets all the calls that were made to ListPanes.
+// Check the length with:
+//
+//	len(mockedClient.ListPanesCalls())
+func (mock *ClientMock) ListPanesCalls() []struct {
+	Session string
+	Window  string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+	}
+	mock.lockListPanes.RLock()
+	calls = mock.calls.ListPanes
+	mock.lockListPanes.RUnlock()
+	return calls
+}
+
+// ListUnmanagedWindows calls ListUnmanagedWindowsFunc.
+func (mock *ClientMock) ListUnmanagedWindows(session string) ([]Window, error) {
+	if mock.ListUnmanagedWindowsFunc == nil {
+		panic("ClientMock.ListUnmanagedWindowsFunc: method is nil but Client.ListUnmanagedWindows was just called")
+	}
+	callInfo := struct {
+		Session string
+	}{
+		Session: session,
+	}
+	mock.lockListUnmanagedWindows.Lock()
+	mock.calls.ListUnmanagedWindows = append(mock.calls.ListUnmanagedWindows, callInfo)
+	mock.lockListUnmanagedWindows.Unlock()
+	return mock.ListUnmanagedWindowsFunc(session)
+}
+
+// ListUnmanagedWindowsCalls gets all the calls that were made to ListUnmanagedWindows.
+// Check the length with:
+//
+//	len(mockedClient.ListUnmanagedWindowsCalls())
+func (mock *ClientMock) ListUnmanagedWindowsCalls() []struct {
+	Session string
+} {
+	var calls []struct {
+		Session string
+	}
+	mock.lockListUnmanagedWindows.RLock()
+	calls = mock.calls.ListUnmanagedWindows
+	mock.lockListUnmanagedWindows.RUnlock()
+	return calls
+}
+
 // ListWindows calls ListWindowsFunc.
 func (mock *ClientMock) ListWindows(session string) ([]Window, error) {
 	if mock.ListWindowsFunc == nil {
@@ -394,9 +971,45 @@ func (mock *ClientMock) ListWindowsCalls() []struct {
 	return calls
 }
 
-// NewSession calls NewSessionFunc.
-func (mock *ClientMock) NewSession(name string, windowName string, dir string, initCmd string) error {
-	if mock.NewSessionFunc == nil {
+// MoveWindow calls MoveWindowFunc.
+func (mock *ClientMock) MoveWindow(window string, session string) error {
+	if mock.MoveWindowFunc == nil {
+		panic("ClientMock.MoveWindowFunc: method is nil but Client.MoveWindow was just called")
+	}
+	callInfo := struct {
+		Window  string
+		Session string
+	}{
+		Window:  window,
+		Session: session,
+	}
+	mock.lockMoveWindow.Lock()
+	mock.calls.MoveWindow = append(mock.calls.MoveWindow, callInfo)
+	mock.lockMoveWindow.Unlock()
+	return mock.MoveWindowFunc(window, session)
+}
+
+// MoveWindowCalls gets all the calls that were made to MoveWindow.
+// Check the length with:
+//
+//	len(mockedClient.MoveWindowCalls())
+func (mock *ClientMock) MoveWindowCalls() []struct {
+	Window  string
+	Session string
+} {
+	var calls []struct {
+		Window  string
+		Session string
+	}
+	mock.lockMoveWindow.RLock()
+	calls = mock.calls.MoveWindow
+	mock.lockMoveWindow.RUnlock()
+	return calls
+}
+
+// NewSession calls NewSessionFunc.
+func (mock *ClientMock) NewSession(name string, windowName string, dir string, initCmd string) (string, error) {
+	if mock.NewSessionFunc == nil {
 		panic("ClientMock.NewSessionFunc: method is nil but Client.NewSession was just called")
 	}
 	callInfo := struct {
@@ -439,7 +1052,7 @@ func (mock *ClientMock) NewSessionCalls() []struct {
 }
 
 // NewWindow calls NewWindowFunc.
-func (mock *ClientMock) NewWindow(session string, name string, dir string, initCmd string) error {
+func (mock *ClientMock) NewWindow(session string, name string, dir string, initCmd string) (string, error) {
 	if mock.NewWindowFunc == nil {
 		panic("ClientMock.NewWindowFunc: method is nil but Client.NewWindow was just called")
 	}
@@ -518,6 +1131,78 @@ func (mock *ClientMock) PaneCurrentCommandCalls() []struct {
 	return calls
 }
 
+// PipePane calls PipePaneFunc.
+func (mock *ClientMock) PipePane(pane string, command string) error {
+	if mock.PipePaneFunc == nil {
+		panic("ClientMock.PipePaneFunc: method is nil but Client.PipePane was just called")
+	}
+	callInfo := struct {
+		Pane    string
+		Command string
+	}{
+		Pane:    pane,
+		Command: command,
+	}
+	mock.lockPipePane.Lock()
+	mock.calls.PipePane = append(mock.calls.PipePane, callInfo)
+	mock.lockPipePane.Unlock()
+	return mock.PipePaneFunc(pane, command)
+}
+
+// PipePaneCalls gets all the calls that were made to PipePane.
+// Check the length with:
+//
+//	len(mockedClient.PipePaneCalls())
+func (mock *ClientMock) PipePaneCalls() []struct {
+	Pane    string
+	Command string
+} {
+	var calls []struct {
+		Pane    string
+		Command string
+	}
+	mock.lockPipePane.RLock()
+	calls = mock.calls.PipePane
+	mock.lockPipePane.RUnlock()
+	return calls
+}
+
+// RenameSession calls RenameSessionFunc.
+func (mock *ClientMock) RenameSession(old string, new string) error {
+	if mock.RenameSessionFunc == nil {
+		panic("ClientMock.RenameSessionFunc: method is nil but Client.RenameSession was just called")
+	}
+	callInfo := struct {
+		Old string
+		New string
+	}{
+		Old: old,
+		New: new,
+	}
+	mock.lockRenameSession.Lock()
+	mock.calls.RenameSession = append(mock.calls.RenameSession, callInfo)
+	mock.lockRenameSession.Unlock()
+	return mock.RenameSessionFunc(old, new)
+}
+
+// RenameSessionCalls gets all the calls that were made to RenameSession.
+// Check the length with:
+//
+//	len(mockedClient.RenameSessionCalls())
+func (mock *ClientMock) RenameSessionCalls() []struct {
+	Old string
+	New string
+} {
+	var calls []struct {
+		Old string
+		New string
+	}
+	mock.lockRenameSession.RLock()
+	calls = mock.calls.RenameSession
+	mock.lockRenameSession.RUnlock()
+	return calls
+}
+
 // RenameWindow calls RenameWindowFunc.
 func (mock *ClientMock) RenameWindow(session string, old string, new string) error {
 	if mock.RenameWindowFunc == nil {
@@ -558,6 +1243,114 @@ func (mock *ClientMock) RenameWindowCalls() []struct {
 	return calls
 }
 
+// SelectLayout calls SelectLayoutFunc.
+func (mock *ClientMock) SelectLayout(session string, window string, layout string) error {
+	if mock.SelectLayoutFunc == nil {
+		panic("ClientMock.SelectLayoutFunc: method is nil but Client.SelectLayout was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+		Layout  string
+	}{
+		Session: session,
+		Window:  window,
+		Layout:  layout,
+	}
+	mock.lockSelectLayout.Lock()
+	mock.calls.SelectLayout = append(mock.calls.SelectLayout, callInfo)
+	mock.lockSelectLayout.Unlock()
+	return mock.SelectLayoutFunc(session, window, layout)
+}
+
+// SelectLayoutCalls gets all the calls that were made to SelectLayout.
+// Check the length with:
+//
+//	len(mockedClient.SelectLayoutCalls())
+func (mock *ClientMock) SelectLayoutCalls() []struct {
+	Session string
+	Window  string
+	Layout  string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+		Layout  string
+	}
+	mock.lockSelectLayout.RLock()
+	calls = mock.calls.SelectLayout
+	mock.lockSelectLayout.RUnlock()
+	return calls
+}
+
+// SelectPane calls SelectPaneFunc.
+func (mock *ClientMock) SelectPane(pane string) error {
+	if mock.SelectPaneFunc == nil {
+		panic("ClientMock.SelectPaneFunc: method is nil but Client.SelectPane was just called")
+	}
+	callInfo := struct {
+		Pane string
+	}{
+		Pane: pane,
+	}
+	mock.lockSelectPane.Lock()
+	mock.calls.SelectPane = append(mock.calls.SelectPane, callInfo)
+	mock.lockSelectPane.Unlock()
+	return mock.SelectPaneFunc(pane)
+}
+
+// SelectPaneCalls gets all the calls that were made to SelectPane.
+// Check the length with:
+//
+//	len(mockedClient.SelectPaneCalls())
+func (mock *ClientMock) SelectPaneCalls() []struct {
+	Pane string
+} {
+	var calls []struct {
+		Pane string
+	}
+	mock.lockSelectPane.RLock()
+	calls = mock.calls.SelectPane
+	mock.lockSelectPane.RUnlock()
+	return calls
+}
+
+// SelectWindow calls SelectWindowFunc.
+func (mock *ClientMock) SelectWindow(session string, window string) error {
+	if mock.SelectWindowFunc == nil {
+		panic("ClientMock.SelectWindowFunc: method is nil but Client.SelectWindow was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+	}{
+		Session: session,
+		Window:  window,
+	}
+	mock.lockSelectWindow.Lock()
+	mock.calls.SelectWindow = append(mock.calls.SelectWindow, callInfo)
+	mock.lockSelectWindow.Unlock()
+	return mock.SelectWindowFunc(session, window)
+}
+
+// SelectWindowCalls gets all the calls that were made to SelectWindow.
+// Check the length with:
+//
+//	len(mockedClient.SelectWindowCalls())
+func (mock *ClientMock) SelectWindowCalls() []struct {
+	Session string
+	Window  string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+	}
+	mock.lockSelectWindow.RLock()
+	calls = mock.calls.SelectWindow
+	mock.lockSelectWindow.RUnlock()
+	return calls
+}
+
 // SendKeys calls SendKeysFunc.
 func (mock *ClientMock) SendKeys(session string, window string, keys ...string) error {
 	if mock.SendKeysFunc == nil {
@@ -598,6 +1391,202 @@ func (mock *ClientMock) SendKeysCalls() []struct {
 	return calls
 }
 
+// SetGlobalHook calls SetGlobalHookFunc.
+func (mock *ClientMock) SetGlobalHook(hook string, command string) error {
+	if mock.SetGlobalHookFunc == nil {
+		panic("ClientMock.SetGlobalHookFunc: method is nil but Client.SetGlobalHook was just called")
+	}
+	callInfo := struct {
+		Hook    string
+		Command string
+	}{
+		Hook:    hook,
+		Command: command,
+	}
+	mock.lockSetGlobalHook.Lock()
+	mock.calls.SetGlobalHook = append(mock.calls.SetGlobalHook, callInfo)
+	mock.lockSetGlobalHook.Unlock()
+	return mock.SetGlobalHookFunc(hook, command)
+}
+
+// SetGlobalHookCalls gets all the calls that were made to SetGlobalHook.
+// Check the length with:
+//
+//	len(mockedClient.SetGlobalHookCalls())
+func (mock *ClientMock) SetGlobalHookCalls() []struct {
+	Hook    string
+	Command string
+} {
+	var calls []struct {
+		Hook    string
+		Command string
+	}
+	mock.lockSetGlobalHook.RLock()
+	calls = mock.calls.SetGlobalHook
+	mock.lockSetGlobalHook.RUnlock()
+	return calls
+}
+
+// SetSessionOption calls SetSessionOptionFunc.
+func (mock *ClientMock) SetSessionOption(session string, key string, value string) error {
+	if mock.SetSessionOptionFunc == nil {
+		panic("ClientMock.SetSessionOptionFunc: method is nil but Client.SetSessionOption was just called")
+	}
+	callInfo := struct {
+		Session string
+		Key     string
+		Value   string
+	}{
+		Session: session,
+		Key:     key,
+		Value:   value,
+	}
+	mock.lockSetSessionOption.Lock()
+	mock.calls.SetSessionOption = append(mock.calls.SetSessionOption, callInfo)
+	mock.lockSetSessionOption.Unlock()
+	return mock.SetSessionOptionFunc(session, key, value)
+}
+
+// SetSessionOptionCalls gets all the calls that were made to SetSessionOption.
+// Check the length with:
+//
+//	len(mockedClient.SetSessionOptionCalls())
+func (mock *ClientMock) SetSessionOptionCalls() []struct {
+	Session string
+	Key     string
+	Value   string
+} {
+	var calls []struct {
+		Session string
+		Key     string
+		Value   string
+	}
+	mock.lockSetSessionOption.RLock()
+	calls = mock.calls.SetSessionOption
+	mock.lockSetSessionOption.RUnlock()
+	return calls
+}
+
+// SetWindowOption calls SetWindowOptionFunc.
+func (mock *ClientMock) SetWindowOption(session string, window string, key string, value string) error {
+	if mock.SetWindowOptionFunc == nil {
+		panic("ClientMock.SetWindowOptionFunc: method is nil but Client.SetWindowOption was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+		Key     string
+		Value   string
+	}{
+		Session: session,
+		Window:  window,
+		Key:     key,
+		Value:   value,
+	}
+	mock.lockSetWindowOption.Lock()
+	mock.calls.SetWindowOption = append(mock.calls.SetWindowOption, callInfo)
+	mock.lockSetWindowOption.Unlock()
+	return mock.SetWindowOptionFunc(session, window, key, value)
+}
+
+// SetWindowOptionCalls gets all the calls that were made to SetWindowOption.
+// Check the length with:
+//
+//	len(mockedClient.SetWindowOptionCalls())
+func (mock *ClientMock) SetWindowOptionCalls() []struct {
+	Session string
+	Window  string
+	Key     string
+	Value   string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+		Key     string
+		Value   string
+	}
+	mock.lockSetWindowOption.RLock()
+	calls = mock.calls.SetWindowOption
+	mock.lockSetWindowOption.RUnlock()
+	return calls
+}
+
+// SplitPane calls SplitPaneFunc.
+func (mock *ClientMock) SplitPane(pane string, opts SplitOptions) (string, error) {
+	if mock.SplitPaneFunc == nil {
+		panic("ClientMock.SplitPaneFunc: method is nil but Client.SplitPane was just called")
+	}
+	callInfo := struct {
+		Pane string
+		Opts SplitOptions
+	}{
+		Pane: pane,
+		Opts: opts,
+	}
+	mock.lockSplitPane.Lock()
+	mock.calls.SplitPane = append(mock.calls.SplitPane, callInfo)
+	mock.lockSplitPane.Unlock()
+	return mock.SplitPaneFunc(pane, opts)
+}
+
+// SplitPaneCalls gets all the calls that were made to SplitPane.
+// Check the length with:
+//
+//	len(mockedClient.SplitPaneCalls())
+func (mock *ClientMock) SplitPaneCalls() []struct {
+	Pane string
+	Opts SplitOptions
+} {
+	var calls []struct {
+		Pane string
+		Opts SplitOptions
+	}
+	mock.lockSplitPane.RLock()
+	calls = mock.calls.SplitPane
+	mock.lockSplitPane.RUnlock()
+	return calls
+}
+
+// SwapWindow calls SwapWindowFunc.
+func (mock *ClientMock) SwapWindow(session string, window string, index int) error {
+	if mock.SwapWindowFunc == nil {
+		panic("ClientMock.SwapWindowFunc: method is nil but Client.SwapWindow was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+		Index   int
+	}{
+		Session: session,
+		Window:  window,
+		Index:   index,
+	}
+	mock.lockSwapWindow.Lock()
+	mock.calls.SwapWindow = append(mock.calls.SwapWindow, callInfo)
+	mock.lockSwapWindow.Unlock()
+	return mock.SwapWindowFunc(session, window, index)
+}
+
+// SwapWindowCalls gets all the calls that were made to SwapWindow.
+// Check the length with:
+//
+//	len(mockedClient.SwapWindowCalls())
+func (mock *ClientMock) SwapWindowCalls() []struct {
+	Session string
+	Window  string
+	Index   int
+} {
+	var calls []struct {
+		Session string
+		Window  string
+		Index   int
+	}
+	mock.lockSwapWindow.RLock()
+	calls = mock.calls.SwapWindow
+	mock.lockSwapWindow.RUnlock()
+	return calls
+}
+
 // SwitchClient calls SwitchClientFunc.
 func (mock *ClientMock) SwitchClient(session string, window string) error {
 	if mock.SwitchClientFunc == nil {
@@ -633,3 +1622,431 @@ func (mock *ClientMock) SwitchClientCalls() []struct {
 	mock.lockSwitchClient.RUnlock()
 	return calls
 }
+
+// SwitchClientGrouped calls SwitchClientGroupedFunc.
+func (mock *ClientMock) SwitchClientGrouped(session string, name string, window string) error {
+	if mock.SwitchClientGroupedFunc == nil {
+		panic("ClientMock.SwitchClientGroupedFunc: method is nil but Client.SwitchClientGrouped was just called")
+	}
+	callInfo := struct {
+		Session string
+		Name    string
+		Window  string
+	}{
+		Session: session,
+		Name:    name,
+		Window:  window,
+	}
+	mock.lockSwitchClientGrouped.Lock()
+	mock.calls.SwitchClientGrouped = append(mock.calls.SwitchClientGrouped, callInfo)
+	mock.lockSwitchClientGrouped.Unlock()
+	return mock.SwitchClientGroupedFunc(session, name, window)
+}
+
+// SwitchClientGroupedCalls gets all the calls that were made to SwitchClientGrouped.
+// Check the length with:
+//
+//	len(mockedClient.SwitchClientGroupedCalls())
+func (mock *ClientMock) SwitchClientGroupedCalls() []struct {
+	Session string
+	Name    string
+	Window  string
+} {
+	var calls []struct {
+		Session string
+		Name    string
+		Window  string
+	}
+	mock.lockSwitchClientGrouped.RLock()
+	calls = mock.calls.SwitchClientGrouped
+	mock.lockSwitchClientGrouped.RUnlock()
+	return calls
+}
+
+// Ensure, that BatchMock does implement Batch.
+// If this is not the case, regenerate this file with moq.
+var _ Batch = &BatchMock{}
+
+// BatchMock is a mock implementation of Batch.
+//
+//	func TestSomethingThatUsesBatch(t *testing.T) {
+//
+//		// make and configure a mocked Batch
+//		mockedBatch := &BatchMock{
+//			LenFunc: func() int {
+//				panic("mock out the Len method")
+//			},
+//			NewSessionFunc: func(name string, windowName string, dir string, initCmd string)  {
+//				panic("mock out the NewSession method")
+//			},
+//			NewWindowFunc: func(session string, name string, dir string, initCmd string)  {
+//				panic("mock out the NewWindow method")
+//			},
+//			RunFunc: func() error {
+//				panic("mock out the Run method")
+//			},
+//			SendKeysFunc: func(session string, window string, keys ...string)  {
+//				panic("mock out the SendKeys method")
+//			},
+//			SetWindowOptionFunc: func(session string, window string, key string, value string)  {
+//				panic("mock out the SetWindowOption method")
+//			},
+//			SwitchClientFunc: func(session string, window string)  {
+//				panic("mock out the SwitchClient method")
+//			},
+//		}
+//
+//		// use mockedBatch in code that requires Batch
+//		// and then make assertions.
+//
+//	}
+type BatchMock struct {
+	// LenFunc mocks the Len method.
+	LenFunc func() int
+
+	// NewSessionFunc mocks the NewSession method.
+	NewSessionFunc func(name string, windowName string, dir string, initCmd string)
+
+	// NewWindowFunc mocks the NewWindow method.
+	NewWindowFunc func(session string, name string, dir string, initCmd string)
+
+	// RunFunc mocks the Run method.
+	RunFunc func() error
+
+	// SendKeysFunc mocks the SendKeys method.
+	SendKeysFunc func(session string, window string, keys ...string)
+
+	// SetWindowOptionFunc mocks the SetWindowOption method.
+	SetWindowOptionFunc func(session string, window string, key string, value string)
+
+	// SwitchClientFunc mocks the SwitchClient method.
+	SwitchClientFunc func(session string, window string)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Len holds details about calls to the Len method.
+		Len []struct {
+		}
+		// NewSession holds details about calls to the NewSession method.
+		NewSession []struct {
+			// Name is the name argument value.
+			Name string
+			// WindowName is the windowName argument value.
+			WindowName string
+			// Dir is the dir argument value.
+			Dir string
+			// InitCmd is the initCmd argument value.
+			InitCmd string
+		}
+		// NewWindow holds details about calls to the NewWindow method.
+		NewWindow []struct {
+			// Session is the session argument value.
+			Session string
+			// Name is the name argument value.
+			Name string
+			// Dir is the dir argument value.
+			Dir string
+			// InitCmd is the initCmd argument value.
+			InitCmd string
+		}
+		// Run holds details about calls to the Run method.
+		Run []struct {
+		}
+		// SendKeys holds details about calls to the SendKeys method.
+		SendKeys []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+			// Keys is the keys argument value.
+			Keys []string
+		}
+		// SetWindowOption holds details about calls to the SetWindowOption method.
+		SetWindowOption []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value string
+		}
+		// SwitchClient holds details about calls to the SwitchClient method.
+		SwitchClient []struct {
+			// Session is the session argument value.
+			Session string
+			// Window is the window argument value.
+			Window string
+		}
+	}
+	lockLen             sync.RWMutex
+	lockNewSession      sync.RWMutex
+	lockNewWindow       sync.RWMutex
+	lockRun             sync.RWMutex
+	lockSendKeys        sync.RWMutex
+	lockSetWindowOption sync.RWMutex
+	lockSwitchClient    sync.RWMutex
+}
+
+// Len calls LenFunc.
+func (mock *BatchMock) Len() int {
+	if mock.LenFunc == nil {
+		panic("BatchMock.LenFunc: method is nil but Batch.Len was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockLen.Lock()
+	mock.calls.Len = append(mock.calls.Len, callInfo)
+	mock.lockLen.Unlock()
+	return mock.LenFunc()
+}
+
+// LenCalls gets all the calls that were made to Len.
+// Check the length with:
+//
+//	len(mockedBatch.LenCalls())
+func (mock *BatchMock) LenCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockLen.RLock()
+	calls = mock.calls.Len
+	mock.lockLen.RUnlock()
+	return calls
+}
+
+// NewSession calls NewSessionFunc.
+func (mock *BatchMock) NewSession(name string, windowName string, dir string, initCmd string) {
+	if mock.NewSessionFunc == nil {
+		panic("BatchMock.NewSessionFunc: method is nil but Batch.NewSession was just called")
+	}
+	callInfo := struct {
+		Name       string
+		WindowName string
+		Dir        string
+		InitCmd    string
+	}{
+		Name:       name,
+		WindowName: windowName,
+		Dir:        dir,
+		InitCmd:    initCmd,
+	}
+	mock.lockNewSession.Lock()
+	mock.calls.NewSession = append(mock.calls.NewSession, callInfo)
+	mock.lockNewSession.Unlock()
+	mock.NewSessionFunc(name, windowName, dir, initCmd)
+}
+
+// NewSessionCalls gets all the calls that were made to NewSession.
+// Check the length with:
+//
+//	len(mockedBatch.NewSessionCalls())
+func (mock *BatchMock) NewSessionCalls() []struct {
+	Name       string
+	WindowName string
+	Dir        string
+	InitCmd    string
+} {
+	var calls []struct {
+		Name       string
+		WindowName string
+		Dir        string
+		InitCmd    string
+	}
+	mock.lockNewSession.RLock()
+	calls = mock.calls.NewSession
+	mock.lockNewSession.RUnlock()
+	return calls
+}
+
+// NewWindow calls NewWindowFunc.
+func (mock *BatchMock) NewWindow(session string, name string, dir string, initCmd string) {
+	if mock.NewWindowFunc == nil {
+		panic("BatchMock.NewWindowFunc: method is nil but Batch.NewWindow was just called")
+	}
+	callInfo := struct {
+		Session string
+		Name    string
+		Dir     string
+		InitCmd string
+	}{
+		Session: session,
+		Name:    name,
+		Dir:     dir,
+		InitCmd: initCmd,
+	}
+	mock.lockNewWindow.Lock()
+	mock.calls.NewWindow = append(mock.calls.NewWindow, callInfo)
+	mock.lockNewWindow.Unlock()
+	mock.NewWindowFunc(session, name, dir, initCmd)
+}
+
+// NewWindowCalls gets all the calls that were made to NewWindow.
+// Check the length with:
+//
+//	len(mockedBatch.NewWindowCalls())
+func (mock *BatchMock) NewWindowCalls() []struct {
+	Session string
+	Name    string
+	Dir     string
+	InitCmd string
+} {
+	var calls []struct {
+		Session string
+		Name    string
+		Dir     string
+		InitCmd string
+	}
+	mock.lockNewWindow.RLock()
+	calls = mock.calls.NewWindow
+	mock.lockNewWindow.RUnlock()
+	return calls
+}
+
+// Run calls RunFunc.
+func (mock *BatchMock) Run() error {
+	if mock.RunFunc == nil {
+		panic("BatchMock.RunFunc: method is nil but Batch.Run was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRun.Lock()
+	mock.calls.Run = append(mock.calls.Run, callInfo)
+	mock.lockRun.Unlock()
+	return mock.RunFunc()
+}
+
+// RunCalls gets all the calls that were made to Run.
+// Check the length with:
+//
+//	len(mockedBatch.RunCalls())
+func (mock *BatchMock) RunCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRun.RLock()
+	calls = mock.calls.Run
+	mock.lockRun.RUnlock()
+	return calls
+}
+
+// SendKeys calls SendKeysFunc.
+func (mock *BatchMock) SendKeys(session string, window string, keys ...string) {
+	if mock.SendKeysFunc == nil {
+		panic("BatchMock.SendKeysFunc: method is nil but Batch.SendKeys was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+		Keys    []string
+	}{
+		Session: session,
+		Window:  window,
+		Keys:    keys,
+	}
+	mock.lockSendKeys.Lock()
+	mock.calls.SendKeys = append(mock.calls.SendKeys, callInfo)
+	mock.lockSendKeys.Unlock()
+	mock.SendKeysFunc(session, window, keys...)
+}
+
+// SendKeysCalls gets all the calls that were made to SendKeys.
+// Check the length with:
+//
+//	len(mockedBatch.SendKeysCalls())
+func (mock *BatchMock) SendKeysCalls() []struct {
+	Session string
+	Window  string
+	Keys    []string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+		Keys    []string
+	}
+	mock.lockSendKeys.RLock()
+	calls = mock.calls.SendKeys
+	mock.lockSendKeys.RUnlock()
+	return calls
+}
+
+// SetWindowOption calls SetWindowOptionFunc.
+func (mock *BatchMock) SetWindowOption(session string, window string, key string, value string) {
+	if mock.SetWindowOptionFunc == nil {
+		panic("BatchMock.SetWindowOptionFunc: method is nil but Batch.SetWindowOption was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+		Key     string
+		Value   string
+	}{
+		Session: session,
+		Window:  window,
+		Key:     key,
+		Value:   value,
+	}
+	mock.lockSetWindowOption.Lock()
+	mock.calls.SetWindowOption = append(mock.calls.SetWindowOption, callInfo)
+	mock.lockSetWindowOption.Unlock()
+	mock.SetWindowOptionFunc(session, window, key, value)
+}
+
+// SetWindowOptionCalls gets all the calls that were made to SetWindowOption.
+// Check the length with:
+//
+//	len(mockedBatch.SetWindowOptionCalls())
+func (mock *BatchMock) SetWindowOptionCalls() []struct {
+	Session string
+	Window  string
+	Key     string
+	Value   string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+		Key     string
+		Value   string
+	}
+	mock.lockSetWindowOption.RLock()
+	calls = mock.calls.SetWindowOption
+	mock.lockSetWindowOption.RUnlock()
+	return calls
+}
+
+// SwitchClient calls SwitchClientFunc.
+func (mock *BatchMock) SwitchClient(session string, window string) {
+	if mock.SwitchClientFunc == nil {
+		panic("BatchMock.SwitchClientFunc: method is nil but Batch.SwitchClient was just called")
+	}
+	callInfo := struct {
+		Session string
+		Window  string
+	}{
+		Session: session,
+		Window:  window,
+	}
+	mock.lockSwitchClient.Lock()
+	mock.calls.SwitchClient = append(mock.calls.SwitchClient, callInfo)
+	mock.lockSwitchClient.Unlock()
+	mock.SwitchClientFunc(session, window)
+}
+
+// SwitchClientCalls gets all the calls that were made to SwitchClient.
+// Check the length with:
+//
+//	len(mockedBatch.SwitchClientCalls())
+func (mock *BatchMock) SwitchClientCalls() []struct {
+	Session string
+	Window  string
+} {
+	var calls []struct {
+		Session string
+		Window  string
+	}
+	mock.lockSwitchClient.RLock()
+	calls = mock.calls.SwitchClient
+	mock.lockSwitchClient.RUnlock()
+	return calls
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Score bonuses of fuzzyScore.
+const (
+	scoreMatch       = 1
+	bonusConsecutive = 4
+	bonusBoundary    = 3
+	penaltyGap       = 1
+)
+
+// fuzzyScore reports whether the runes of query appear in s in order and, if
+// so, how well they match. Consecutive runes and runes at the start of a word
+// (after '/', '-', '_', '.' or a space) score higher; gaps score lower. The
+// match ignores case unless query contains an upper-case letter.
+func fuzzyScore(query, s string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+	if !strings.ContainsFunc(query, unicode.IsUpper) {
+		s = strings.ToLower(s)
+	}
+	q := []rune(query)
+	r := []rune(s)
+
+	// Prefer the match that ends earliest, then extend it backwards to the
+	// latest start so that the matched runes are as close together as possible.
+	end, qi := -1, 0
+	for i := 0; i < len(r) && qi < len(q); i++ {
+		if r[i] == q[qi] {
+			qi++
+			end = i
+		}
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	positions := make([]int, len(q))
+	qi = len(q) - 1
+	for i := end; i >= 0 && qi >= 0; i-- {
+		if r[i] == q[qi] {
+			positions[qi] = i
+			qi--
+		}
+	}
+
+	score := 0
+	for k, i := range positions {
+		score += scoreMatch
+		if i == 0 || isWordSeparator(r[i-1]) {
+			score += bonusBoundary
+		}
+		if k > 0 {
+			if gap := i - positions[k-1] - 1; gap == 0 {
+				score += bonusConsecutive
+			} else {
+				score -= penaltyGap
+			}
+		}
+	}
+	return score, true
+}
+
+func isWordSeparator(r rune) bool {
+	return r == '/' || r == '-' || r == '_' || r == '.' || r == ' '
+}
+
+// filterItems returns the indices of the labels matching query, best first.
+// Labels that score equally keep their order.
+func filterItems(query string, labels []string) []int {
+	type match struct{ index, score int }
+	var matches []match
+	for i, l := range labels {
+		if score, ok := fuzzyScore(query, l); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	indices := make([]int, len(matches))
+	for i, m := range matches {
+		indices[i] = m.index
+	}
+	return indices
+}
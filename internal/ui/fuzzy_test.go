@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, s string
+		ok       bool
+	}{
+		{"", "anything", true},
+		{"fl", "feature/login", true},
+		{"FL", "feature/login", false},
+		{"Fl", "Feature/login", true},
+		{"login", "feature/LOGIN", true},
+		{"lf", "feature/login", false},
+		{"featx", "feature", false},
+	}
+	for _, tt := range tests {
+		_, ok := fuzzyScore(tt.query, tt.s)
+		assert.Equal(t, tt.ok, ok, "%q in %q", tt.query, tt.s)
+	}
+
+	consecutive, _ := fuzzyScore("log", "feature/login")
+	scattered, ok := fuzzyScore("log", "fix/lots-of-graphs")
+	assert.True(t, ok)
+	assert.Greater(t, consecutive, scattered)
+
+	boundary, _ := fuzzyScore("fl", "fix/login")
+	inner, ok := fuzzyScore("fl", "conflict")
+	assert.True(t, ok)
+	assert.Greater(t, boundary, inner)
+
+	// The closest occurrence counts, not the first one.
+	nearest, _ := fuzzyScore("ab", "a-x-ab")
+	assert.Equal(t, 2*scoreMatch+bonusBoundary+bonusConsecutive, nearest)
+}
+
+func TestFilterItems(t *testing.T) {
+	labels := []string{"main", "fix/lots-of-graphs", "feature/login", "docs"}
+	assert.Equal(t, []int{0, 1, 2, 3}, filterItems("", labels))
+	assert.Equal(t, []int{2, 1}, filterItems("log", labels))
+	assert.Empty(t, filterItems("zzz", labels))
+}
@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"golang.org/x/term"
+)
+
+// Keys that can end a Picker.
+const (
+	KeyEnter byte = '\r'
+	KeyCtrlO byte = 0x0f
+	KeyCtrlX byte = 0x18
+)
+
+// Terminal control sequences used by the Picker.
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+	clearLine      = "\x1b[K"
+	clearBelow     = "\x1b[J"
+	cursorHome     = "\x1b[H"
+)
+
+// minPreviewWidth is the terminal width below which the preview is hidden.
+const minPreviewWidth = 60
+
+// PickerItem is an entry of a Picker.
+type PickerItem struct {
+	// Label is matched against the query.
+	Label string
+	// Detail is shown dimmed after the label.
+	Detail string
+}
+
+// PickerAction is a key that, like Enter, picks the selected item.
+type PickerAction struct {
+	// Key is a control character such as KeyCtrlO.
+	Key byte
+	// Name describes the action in the footer.
+	Name string
+}
+
+// Picker is a full-screen fuzzy finder. Typing filters the items, the arrow
+// keys or Ctrl-P/Ctrl-N move the selection, and Enter or an action key picks
+// the selected item. Esc or Ctrl-C cancels.
+type Picker struct {
+	Items []PickerItem
+	// Enter describes the Enter key in the footer.
+	Enter   string
+	Actions []PickerAction
+	// Preview returns the lines shown beside the selected item. Nil disables
+	// the preview; results are cached per item.
+	Preview func(item int) []string
+}
+
+// Pick runs the picker on the terminal in, drawing to out, and returns the
+// index of the picked item and the key that picked it. The index is -1 if
+// the picker was cancelled.
+func (p *Picker) Pick(in *os.File, out io.Writer) (int, byte, error) {
+	fd := int(in.Fd())
+	old, err := term.MakeRaw(fd)
+	if err != nil {
+		return -1, 0, fmt.Errorf("setting up the terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, old) }()
+	_, _ = io.WriteString(out, enterAltScreen)
+	defer func() { _, _ = io.WriteString(out, exitAltScreen) }()
+
+	s := newPickerState(p)
+	buf := make([]byte, 256)
+	for {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 80, 24
+		}
+		_, _ = io.WriteString(out, s.render(width, height))
+		n, err := in.Read(buf)
+		if err != nil {
+			return -1, 0, err
+		}
+		if s.handle(buf[:n]) {
+			return s.picked, s.key, nil
+		}
+	}
+}
+
+// pickerState is the state of a running Picker, separate from the terminal.
+type pickerState struct {
+	p        *Picker
+	labels   []string
+	query    []rune
+	matches  []int // indices into p.Items
+	cursor   int   // index into matches
+	offset   int   // first visible match
+	previews map[int][]string
+
+	done   bool
+	picked int
+	key    byte
+}
+
+func newPickerState(p *Picker) *pickerState {
+	s := &pickerState{p: p, previews: map[int][]string{}, picked: -1}
+	for _, it := range p.Items {
+		s.labels = append(s.labels, it.Label)
+	}
+	s.filter()
+	return s
+}
+
+func (s *pickerState) filter() {
+	s.matches = filterItems(string(s.query), s.labels)
+	s.cursor, s.offset = 0, 0
+}
+
+// handle processes the keys in b and reports whether the picker is done.
+func (s *pickerState) handle(b []byte) bool {
+	for len(b) > 0 && !s.done {
+		b = b[s.handleKey(b):]
+	}
+	return s.done
+}
+
+// handleKey processes the key at the start of b and returns its length.
+func (s *pickerState) handleKey(b []byte) int {
+	c := b[0]
+	for _, a := range s.p.Actions {
+		if a.Key == c {
+			s.pick(c)
+			return 1
+		}
+	}
+	switch c {
+	case 0x1b: // Esc or an escape sequence
+		return s.handleEscape(b)
+	case '\r', '\n':
+		s.pick(KeyEnter)
+	case 0x03, 0x07: // Ctrl-C, Ctrl-G
+		s.cancel()
+	case 0x10: // Ctrl-P
+		s.move(-1)
+	case 0x0e: // Ctrl-N
+		s.move(1)
+	case 0x7f, 0x08: // Backspace
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			s.filter()
+		}
+	case 0x15: // Ctrl-U
+		s.query = nil
+		s.filter()
+	case 0x17: // Ctrl-W
+		q := strings.TrimRight(string(s.query), " /-_.")
+		s.query = []rune(q[:strings.LastIndexAny(q, " /-_.")+1])
+		s.filter()
+	default:
+		if c < 0x20 {
+			return 1
+		}
+		r, n := utf8.DecodeRune(b)
+		if r != utf8.RuneError {
+			s.query = append(s.query, r)
+			s.filter()
+		}
+		return n
+	}
+	return 1
+}
+
+// handleEscape handles a lone Esc, which cancels, and the arrow keys. Other
+// escape sequences are ignored.
+func (s *pickerState) handleEscape(b []byte) int {
+	if len(b) == 1 {
+		s.cancel()
+		return 1
+	}
+	if len(b) < 3 || (b[1] != '[' && b[1] != 'O') {
+		return 1
+	}
+	switch b[2] {
+	case 'A':
+		s.move(-1)
+	case 'B':
+		s.move(1)
+	}
+	for i := 2; i < len(b); i++ {
+		if b[i] >= 0x40 && b[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(b)
+}
+
+func (s *pickerState) move(delta int) {
+	s.cursor = max(0, min(s.cursor+delta, len(s.matches)-1))
+}
+
+func (s *pickerState) pick(key byte) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.done, s.picked, s.key = true, s.matches[s.cursor], key
+}
+
+func (s *pickerState) cancel() {
+	s.done, s.picked, s.key = true, -1, 0
+}
+
+// preview returns the preview lines of the selected item.
+func (s *pickerState) preview() []string {
+	if s.p.Preview == nil || len(s.matches) == 0 {
+		return nil
+	}
+	item := s.matches[s.cursor]
+	lines, ok := s.previews[item]
+	if !ok {
+		lines = s.p.Preview(item)
+		s.previews[item] = lines
+	}
+	return lines
+}
+
+// render returns the escape sequences that draw the picker on a terminal of
+// the given size: the query, the match count, the matches with the preview
+// beside them, and a footer listing the keys.
+func (s *pickerState) render(width, height int) string {
+	listHeight := max(height-3, 1)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+listHeight {
+		s.offset = s.cursor - listHeight + 1
+	}
+	listWidth, previewWidth := width, 0
+	var preview []string
+	if s.p.Preview != nil && width >= minPreviewWidth {
+		listWidth = width * 2 / 5
+		previewWidth = width - listWidth - 3
+		preview = s.preview()
+	}
+
+	lines := []string{
+		fit("> "+string(s.query), width),
+		faint(fit(fmt.Sprintf("  %d/%d", len(s.matches), len(s.p.Items)), width)),
+	}
+	for row := range listHeight {
+		line := strings.Repeat(" ", listWidth)
+		if i := s.offset + row; i < len(s.matches) {
+			line = s.renderItem(s.matches[i], i == s.cursor, listWidth)
+		}
+		if previewWidth > 0 {
+			var p string
+			if row < len(preview) {
+				p = preview[row]
+			}
+			line += faint(" │ ") + fit(p, previewWidth)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, faint(fit(s.footer(), width)))
+
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	b.WriteString(strings.Join(lines, clearLine+"\r\n"))
+	b.WriteString(clearLine + clearBelow)
+	fmt.Fprintf(&b, "\x1b[1;%dH", 3+text.StringWidthWithoutEscSequences(string(s.query)))
+	return b.String()
+}
+
+// renderItem renders an item as a line of the given width.
+func (s *pickerState) renderItem(item int, selected bool, width int) string {
+	it := s.p.Items[item]
+	label := "  " + it.Label
+	if selected {
+		label = "> " + it.Label
+	}
+	label = fit(label, min(width, text.StringWidthWithoutEscSequences(label)))
+	rest := fit(" "+it.Detail, width-text.StringWidthWithoutEscSequences(label))
+	if selected {
+		label = styled(label, text.Bold)
+	}
+	return label + faint(rest)
+}
+
+func (s *pickerState) footer() string {
+	parts := []string{"enter " + s.p.Enter}
+	for _, a := range s.p.Actions {
+		parts = append(parts, keyName(a.Key)+" "+a.Name)
+	}
+	parts = append(parts, "esc cancel")
+	return "  " + strings.Join(parts, " · ")
+}
+
+// keyName returns the name of a control character, e.g. "ctrl-o".
+func keyName(key byte) string {
+	return "ctrl-" + string(rune('a'+key-1))
+}
+
+// fit truncates or pads s with spaces to the given display width.
+func fit(s string, width int) string {
+	var b strings.Builder
+	w := 0
+	for _, r := range s {
+		rw := text.RuneWidth(r)
+		if w+rw > width {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	if w < width {
+		b.WriteString(strings.Repeat(" ", width-w))
+	}
+	return b.String()
+}
+
+func faint(s string) string {
+	return styled(s, text.Faint)
+}
+
+func styled(s string, c text.Color) string {
+	if isColorDisabled() || strings.TrimSpace(s) == "" {
+		return s
+	}
+	return c.Sprint(s)
+}
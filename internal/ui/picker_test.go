@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPicker() *Picker {
+	return &Picker{
+		Items: []PickerItem{
+			{Label: "main", Detail: "(default)"},
+			{Label: "feature/login"},
+			{Label: "fix/large-graph", Detail: "(no worktree)"},
+		},
+		Enter:   "switch",
+		Actions: []PickerAction{{Key: KeyCtrlO, Name: "new"}, {Key: KeyCtrlX, Name: "remove"}},
+	}
+}
+
+func TestPickerState_handle(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		picked int
+		key    byte
+	}{
+		{"enter picks the first item", "\r", 0, KeyEnter},
+		{"query filters", "login\r", 1, KeyEnter},
+		{"arrow down", "\x1b[B\x1b[B\r", 2, KeyEnter},
+		{"arrow up stops at the top", "\x1b[A\r", 0, KeyEnter},
+		{"ctrl-n and ctrl-p", "\x0e\x0e\x10\r", 1, KeyEnter},
+		{"down stops at the bottom", "\x1bOB\x1bOB\x1bOB\x1bOB\r", 2, KeyEnter},
+		{"action key", "\x0e\x0f", 1, KeyCtrlO},
+		{"backspace", "lx\x7f\r", 1, KeyEnter},
+		{"ctrl-u clears", "zzz\x15\r", 0, KeyEnter},
+		{"ctrl-w deletes a word", "fix/zzz\x17\r", 2, KeyEnter},
+		{"other sequences are ignored", "\x1b[5~\x1b[1;5C\r", 0, KeyEnter},
+		{"esc cancels", "\x1b", -1, 0},
+		{"ctrl-c cancels", "log\x03", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPickerState(testPicker())
+			require.True(t, s.handle([]byte(tt.input)))
+			assert.Equal(t, tt.picked, s.picked)
+			assert.Equal(t, tt.key, s.key)
+		})
+	}
+
+	t.Run("no match does not pick", func(t *testing.T) {
+		s := newPickerState(testPicker())
+		assert.False(t, s.handle([]byte("zzz\r")))
+		assert.Empty(t, s.matches)
+	})
+
+	t.Run("keys after the pick are ignored", func(t *testing.T) {
+		s := newPickerState(testPicker())
+		assert.True(t, s.handle([]byte("\rmain")))
+		assert.Empty(t, s.query)
+	})
+
+	t.Run("multi-byte input", func(t *testing.T) {
+		s := newPickerState(&Picker{Items: []PickerItem{{Label: "main"}, {Label: "機能"}}})
+		require.True(t, s.handle([]byte("機\r")))
+		assert.Equal(t, 1, s.picked)
+	})
+}
+
+// plainLines strips the escape sequences from a rendered picker.
+func plainLines(out string) []string {
+	out = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`).ReplaceAllString(out, "")
+	return strings.Split(out, "\r\n")
+}
+
+func TestPickerState_render(t *testing.T) {
+	SetNoColor(true)
+	t.Cleanup(func() { SetNoColor(false) })
+
+	t.Run("list", func(t *testing.T) {
+		s := newPickerState(testPicker())
+		s.handle([]byte("\x1b[B"))
+		lines := plainLines(s.render(40, 6))
+		assert.Equal(t, []string{
+			"> " + strings.Repeat(" ", 38),
+			fit("  3/3", 40),
+			fit("  main (default)", 40),
+			fit("> feature/login", 40),
+			fit("  fix/large-graph (no worktree)", 40),
+			fit("  enter switch · ctrl-o new · ctrl-x remove · esc cancel", 40),
+		}, lines)
+	})
+
+	t.Run("scrolls to the cursor", func(t *testing.T) {
+		s := newPickerState(testPicker())
+		s.handle([]byte("\x1b[B\x1b[B"))
+		lines := plainLines(s.render(40, 5))
+		require.Len(t, lines, 5)
+		assert.Equal(t, fit("  feature/login", 40), lines[2])
+		assert.Equal(t, fit("> fix/large-graph (no worktree)", 40), lines[3])
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		p := testPicker()
+		calls := 0
+		p.Preview = func(item int) []string {
+			calls++
+			return []string{"preview of " + p.Items[item].Label}
+		}
+		s := newPickerState(p)
+		lines := plainLines(s.render(80, 5))
+		assert.Equal(t, fit("> main (default)", 32)+" │ "+fit("preview of main", 45), lines[2])
+		assert.Equal(t, fit("  feature/login", 32)+" │ "+strings.Repeat(" ", 45), lines[3])
+
+		s.render(80, 5)
+		assert.Equal(t, 1, calls, "previews are cached")
+
+		lines = plainLines(s.render(50, 5))
+		assert.Equal(t, fit("> main (default)", 50), lines[2], "no preview on narrow terminals")
+	})
+
+	t.Run("truncates", func(t *testing.T) {
+		s := newPickerState(testPicker())
+		lines := plainLines(s.render(10, 4))
+		assert.Equal(t, "> main (de", lines[2])
+	})
+}
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, "ab  ", fit("ab", 4))
+	assert.Equal(t, "abc", fit("abcdef", 3))
+	assert.Equal(t, "機 ", fit("機能", 3))
+	assert.Equal(t, "", fit("abc", 0))
+}
+
+func TestKeyName(t *testing.T) {
+	assert.Equal(t, "ctrl-o", keyName(KeyCtrlO))
+	assert.Equal(t, "ctrl-x", keyName(KeyCtrlX))
+}